@@ -0,0 +1,290 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// swag init 风格的 Swagger 2.0 文档
+const swaggerJSON = `{
+    "swagger": "2.0",
+    "basePath": "/api",
+    "paths": {
+        "/admin/users/{id}": {
+            "get": {
+                "tags": ["用户管理"],
+                "summary": "用户详情",
+                "parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {"$ref": "#/definitions/response.Result"},
+                                {"type": "object", "properties": {"data": {"$ref": "#/definitions/models.User"}}}
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "tags": ["用户管理"],
+                "parameters": [
+                    {"name": "id", "in": "path", "required": true, "type": "integer"},
+                    {"name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/requests.UserRequest"}}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "tags": ["用户管理"],
+                "operationId": "list-users",
+                "parameters": [
+                    {"name": "pageSize", "in": "query", "type": "integer"},
+                    {"name": "page", "in": "query", "required": true, "type": "integer"},
+                    {"name": "status", "in": "query", "type": "string", "enum": ["active", "disabled"]}
+                ],
+                "responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/models.User"}}}}
+            }
+        },
+        "/auth/avatar": {
+            "post": {
+                "tags": ["Auth"],
+                "parameters": [{"name": "file", "in": "formData", "required": true, "type": "file"}],
+                "responses": {"200": {"description": "OK"}}
+            }
+        }
+    },
+    "definitions": {
+        "models.User": {
+            "type": "object",
+            "required": ["id"],
+            "properties": {
+                "id": {"type": "integer", "description": "主键"},
+                "name": {"type": "string"},
+                "roles": {"type": "array", "items": {"type": "string"}},
+                "created-at": {"type": "string", "format": "date-time"}
+            }
+        },
+        "admin.User": {
+            "type": "object",
+            "properties": {"id": {"type": "integer"}}
+        },
+        "requests.UserRequest": {
+            "type": "object",
+            "properties": {
+                "name": {"type": "string"},
+                "meta": {"type": "object", "additionalProperties": {"type": "string"}}
+            }
+        },
+        "response.Result": {
+            "type": "object",
+            "properties": {"code": {"type": "integer"}, "message": {"type": "string"}}
+        }
+    }
+}`
+
+// OpenAPI 3 的 YAML 文档，响应码作为 YAML 整数键
+const openapiYAML = `openapi: 3.0.3
+paths:
+  /posts/{postId}:
+    parameters:
+      - name: postId
+        in: path
+        required: true
+        schema:
+          type: string
+    patch:
+      tags: [posts]
+      operationId: updatePost
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Post'
+      responses:
+        200:
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Post'
+    delete:
+      tags: [posts]
+      responses:
+        204:
+          description: No Content
+components:
+  schemas:
+    Post:
+      type: object
+      required: [title]
+      properties:
+        title:
+          type: string
+        summary:
+          type: string
+          nullable: true
+`
+
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func assertContains(t *testing.T, content string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateSwagger(t *testing.T) {
+	dir := writeProject(t, map[string]string{"docs/swagger.json": swaggerJSON})
+
+	report, err := Generate(dir, Options{RequestImport: "@/utils/request"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if report.Spec != "docs/swagger.json" || report.Operations != 4 || report.Schemas != 4 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	wantFiles := []string{
+		DefaultOut + "/auth.ts",
+		DefaultOut + "/index.ts",
+		DefaultOut + "/types.ts",
+		DefaultOut + "/用户管理.ts",
+	}
+	if !reflect.DeepEqual(report.Files, wantFiles) || !reflect.DeepEqual(report.Changed, wantFiles) {
+		t.Fatalf("unexpected files: %v, changed: %v", report.Files, report.Changed)
+	}
+
+	types := read(t, dir, DefaultOut+"/types.ts")
+	assertContains(t, types,
+		header,
+		// 重名的 User 使用完整名称区分
+		"export interface AdminUser {\n  id?: number\n}",
+		"export interface ModelsUser {\n  'created-at'?: string\n  /** 主键 */\n  id: number\n  name?: string\n  roles?: string[]\n}",
+		"export interface Result {",
+		"export interface UserRequest {\n  meta?: Record<string, string>\n  name?: string\n}",
+	)
+
+	users := read(t, dir, DefaultOut+"/用户管理.ts")
+	assertContains(t, users,
+		"import request from '@/utils/request'\n",
+		"import type { ModelsUser, Result, UserRequest } from './types'\n",
+		"export function listUsers(params: { page: number; pageSize?: number; status?: 'active' | 'disabled' }) {\n  return request.get<ModelsUser[]>('/api/admin/users', { params })\n}",
+		"/** 用户详情 */\nexport function getApiAdminUsersById(id: number) {\n  return request.get<Result & {\n    data?: ModelsUser\n  }>(`/api/admin/users/${id}`)\n}",
+		"export function putApiAdminUsersById(id: number, data: UserRequest) {\n  return request.put(`/api/admin/users/${id}`, data)\n}",
+	)
+	assertContains(t, read(t, dir, DefaultOut+"/auth.ts"),
+		"export function postApiAuthAvatar(data: FormData) {\n  return request.post('/api/auth/avatar', data)\n}",
+	)
+	assertContains(t, read(t, dir, DefaultOut+"/index.ts"),
+		"export * from './types'\nexport * from './auth'\nexport * from './用户管理'\n",
+	)
+}
+
+func TestGenerateOpenAPIYAML(t *testing.T) {
+	dir := writeProject(t, map[string]string{"docs/openapi.yaml": openapiYAML})
+
+	if _, err := Generate(dir, Options{Out: "frontend/src/api/openapi"}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	assertContains(t, read(t, dir, "frontend/src/api/openapi/types.ts"),
+		"export interface Post {\n  summary?: string | null\n  title: string\n}",
+	)
+	assertContains(t, read(t, dir, "frontend/src/api/openapi/posts.ts"),
+		"import request from 'axios'\nimport type { Post } from './types'\n",
+		"export function updatePost(postId: string, data: Post) {\n  return request.patch<Post>(`/posts/${postId}`, data)\n}",
+		"export function deletePostsByPostId(postId: string) {\n  return request.delete(`/posts/${postId}`)\n}",
+	)
+}
+
+func TestGenerateIsStable(t *testing.T) {
+	dir := writeProject(t, map[string]string{"docs/swagger.json": swaggerJSON})
+	if _, err := Generate(dir, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	first := read(t, dir, DefaultOut+"/用户管理.ts")
+
+	for i := 0; i < 5; i++ {
+		report, err := Generate(dir, Options{})
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if len(report.Changed) != 0 || len(report.Removed) != 0 {
+			t.Fatalf("expected no changes on regeneration, got %+v", report)
+		}
+	}
+	if read(t, dir, DefaultOut+"/用户管理.ts") != first {
+		t.Fatalf("output changed between runs")
+	}
+}
+
+func TestGenerateCheckAndStaleFiles(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"docs/swagger.json":            swaggerJSON,
+		DefaultOut + "/orders.ts":      header + "\n",
+		DefaultOut + "/handwritten.ts": "export const keep = true\n",
+	})
+
+	report, err := Generate(dir, Options{Check: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(report.Changed) != 4 || !reflect.DeepEqual(report.Removed, []string{DefaultOut + "/orders.ts"}) {
+		t.Fatalf("unexpected check report: %+v", report)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DefaultOut, "types.ts")); !os.IsNotExist(err) {
+		t.Fatalf("check mode must not write files")
+	}
+
+	if _, err := Generate(dir, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DefaultOut, "orders.ts")); !os.IsNotExist(err) {
+		t.Fatalf("expected stale generated file to be removed")
+	}
+	if read(t, dir, DefaultOut+"/handwritten.ts") != "export const keep = true\n" {
+		t.Fatalf("handwritten file must be kept")
+	}
+}
+
+func TestGenerateMissingSpec(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(dir, Options{}); err == nil || !strings.Contains(err.Error(), "swag init") {
+		t.Fatalf("expected missing spec error, got %v", err)
+	}
+
+	dir = writeProject(t, map[string]string{"docs/swagger.json": `{"info": {}}`})
+	if _, err := Generate(dir, Options{}); err == nil {
+		t.Fatalf("expected error for a non-swagger document")
+	}
+}
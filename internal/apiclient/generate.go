@@ -0,0 +1,753 @@
+package apiclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultOut 生成文件的默认目录（相对项目根目录），与 make:crud 手写风格的接口文件分开存放
+const DefaultOut = "frontend/src/api/generated"
+
+// header 生成文件的首行，同时用来识别可以安全删除的旧文件
+const header = "// Code generated by goravel-kit-cli gen:api-client. DO NOT EDIT."
+
+// Options 生成前端接口客户端的选项
+type Options struct {
+	// Spec 接口文档路径（相对项目目录），为空时按 SpecFiles 查找
+	Spec string
+	// Out 输出目录（相对项目目录），为空时使用 DefaultOut
+	Out string
+	// RequestImport 请求实例的导入路径，为空时使用 axios
+	RequestImport string
+	// Check 只比较生成结果与磁盘上的文件，不写入
+	Check bool
+}
+
+// Report 生成结果
+type Report struct {
+	Spec       string   `json:"spec"`
+	Out        string   `json:"out"`
+	Files      []string `json:"files"`
+	Operations int      `json:"operations"`
+	Schemas    int      `json:"schemas"`
+	// Changed 内容有变化的文件；Check 模式下为需要重新生成的文件
+	Changed []string `json:"changed,omitempty"`
+	// Removed 不再生成而删除的旧文件
+	Removed []string `json:"removed,omitempty"`
+}
+
+// endpoint 一个接口对应的请求函数
+type endpoint struct {
+	name    string
+	method  string
+	path    string
+	summary string
+	op      *operation
+}
+
+// methodOrder 同一路径下接口的输出顺序
+var methodOrder = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// Generate 读取接口文档，在输出目录中生成 types.ts（数据结构）、按 tag 划分的请求函数文件和 index.ts。
+// 输出只取决于文档内容：类型、接口和属性都按名称排序，不写入时间戳，方便在代码评审中查看差异
+func Generate(dir string, opts Options) (*Report, error) {
+	spec := opts.Spec
+	if spec == "" {
+		found, err := FindSpec(dir)
+		if err != nil {
+			return nil, err
+		}
+		spec = found
+	}
+	specPath := spec
+	if !filepath.IsAbs(specPath) {
+		specPath = filepath.Join(dir, filepath.FromSlash(spec))
+	}
+	doc, err := loadDocument(specPath)
+	if err != nil {
+		return nil, err
+	}
+	out := strings.Trim(filepath.ToSlash(opts.Out), "/")
+	if out == "" {
+		out = DefaultOut
+	}
+	requestImport := opts.RequestImport
+	if requestImport == "" {
+		requestImport = "axios"
+	}
+
+	g := newGenerator(doc)
+	files := g.render(requestImport)
+	report := &Report{Spec: filepath.ToSlash(spec), Out: out, Operations: g.operations, Schemas: len(g.names)}
+
+	outDir := filepath.Join(dir, filepath.FromSlash(out))
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rel := path.Join(out, name)
+		report.Files = append(report.Files, rel)
+		existing, err := os.ReadFile(filepath.Join(outDir, name))
+		if err == nil && bytes.Equal(existing, files[name]) {
+			continue
+		}
+		report.Changed = append(report.Changed, rel)
+	}
+	for _, name := range staleFiles(outDir, files) {
+		report.Removed = append(report.Removed, path.Join(out, name))
+	}
+	if opts.Check {
+		return report, nil
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, err
+	}
+	for _, rel := range report.Changed {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(rel)), files[path.Base(rel)], 0644); err != nil {
+			return nil, err
+		}
+	}
+	for _, rel := range report.Removed {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// staleFiles 返回输出目录中由本命令生成、但本次不再生成的文件，手写的文件不会被删除
+func staleFiles(outDir string, files map[string][]byte) []string {
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		return nil
+	}
+	var stale []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".ts") || files[name] != nil {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(outDir, name))
+		if err == nil && strings.HasPrefix(string(content), header) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// generator 将接口文档转换为 TypeScript 代码
+type generator struct {
+	doc *document
+	// names 文档中的结构名称到 TypeScript 类型名称的映射
+	names      map[string]string
+	groups     map[string][]endpoint
+	operations int
+	// used 当前文件引用到的类型
+	used map[string]bool
+}
+
+func newGenerator(doc *document) *generator {
+	g := &generator{doc: doc, names: typeNames(doc.schemas()), groups: map[string][]endpoint{}}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	taken := map[string]bool{}
+	for _, p := range paths {
+		item := doc.Paths[p]
+		var shared []parameter
+		if raw, ok := item["parameters"]; ok {
+			_ = json.Unmarshal(raw, &shared)
+		}
+		for _, method := range methodOrder {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			op := &operation{}
+			if err := json.Unmarshal(raw, op); err != nil {
+				continue
+			}
+			op.Parameters = mergeParameters(shared, op.Parameters)
+
+			url := path.Join("/", doc.BasePath, p)
+			name := functionName(method, url, op.OperationID)
+			for i := 2; taken[name]; i++ {
+				name = functionName(method, url, op.OperationID) + strconv.Itoa(i)
+			}
+			taken[name] = true
+
+			tag := "default"
+			if len(op.Tags) > 0 && fileName(op.Tags[0]) != "" {
+				tag = fileName(op.Tags[0])
+			}
+			if tag == "types" || tag == "index" {
+				tag += "-api"
+			}
+			g.groups[tag] = append(g.groups[tag], endpoint{
+				name:    name,
+				method:  method,
+				path:    url,
+				summary: firstNonEmpty(op.Summary, op.Description),
+				op:      op,
+			})
+			g.operations++
+		}
+	}
+	return g
+}
+
+// mergeParameters 合并路径级与接口级参数，同名同位置的参数以接口级为准
+func mergeParameters(shared, own []parameter) []parameter {
+	if len(shared) == 0 {
+		return own
+	}
+	merged := append([]parameter{}, own...)
+	for _, p := range shared {
+		found := false
+		for _, o := range own {
+			found = found || (o.Name == p.Name && o.In == p.In)
+		}
+		if !found {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// render 生成所有文件的内容
+func (g *generator) render(requestImport string) map[string][]byte {
+	files := map[string][]byte{"types.ts": g.renderTypes()}
+
+	tags := make([]string, 0, len(g.groups))
+	for tag := range g.groups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var index strings.Builder
+	index.WriteString(header + "\n\n")
+	index.WriteString("export * from './types'\n")
+	for _, tag := range tags {
+		files[tag+".ts"] = g.renderGroup(g.groups[tag], requestImport)
+		fmt.Fprintf(&index, "export * from './%s'\n", tag)
+	}
+	files["index.ts"] = []byte(index.String())
+	return files
+}
+
+// renderTypes 生成 types.ts，包含文档中定义的全部数据结构
+func (g *generator) renderTypes() []byte {
+	schemas := g.doc.schemas()
+	originals := make([]string, 0, len(schemas))
+	for original := range schemas {
+		originals = append(originals, original)
+	}
+	sort.Slice(originals, func(i, j int) bool { return g.names[originals[i]] < g.names[originals[j]] })
+
+	var b strings.Builder
+	b.WriteString(header + "\n")
+	for _, original := range originals {
+		s := schemas[original]
+		name := g.names[original]
+		b.WriteString("\n")
+		if s != nil {
+			writeDoc(&b, "", s.Description)
+		}
+		if isInterface(s) {
+			fmt.Fprintf(&b, "export interface %s %s\n", name, g.objectType(s, ""))
+		} else {
+			fmt.Fprintf(&b, "export type %s = %s\n", name, g.tsType(s, ""))
+		}
+	}
+	return []byte(b.String())
+}
+
+// isInterface 只有属性、没有组合关系的对象生成 interface，其余生成 type 别名
+func isInterface(s *Schema) bool {
+	return s != nil && s.Ref == "" && len(s.Properties) > 0 && len(s.AdditionalProperties) == 0 &&
+		len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && !s.Nullable && !s.Type.Nullable
+}
+
+// argument 请求函数的一个参数
+type argument struct {
+	name     string
+	typ      string
+	optional bool
+}
+
+// renderGroup 生成一个 tag 下的全部请求函数
+func (g *generator) renderGroup(endpoints []endpoint, requestImport string) []byte {
+	g.used = map[string]bool{}
+	var body strings.Builder
+	for _, e := range endpoints {
+		body.WriteString("\n")
+		g.writeEndpoint(&body, e)
+	}
+
+	var b strings.Builder
+	b.WriteString(header + "\n\n")
+	fmt.Fprintf(&b, "import request from '%s'\n", requestImport)
+	if len(g.used) > 0 {
+		used := make([]string, 0, len(g.used))
+		for name := range g.used {
+			used = append(used, name)
+		}
+		sort.Strings(used)
+		fmt.Fprintf(&b, "import type { %s } from './types'\n", strings.Join(used, ", "))
+	}
+	b.WriteString(body.String())
+	return []byte(b.String())
+}
+
+// writeEndpoint 生成一个请求函数：路径参数依次作为参数，请求体为 data，查询参数合并为 params 对象
+func (g *generator) writeEndpoint(b *strings.Builder, e endpoint) {
+	var args []argument
+	url := e.path
+	var query []parameter
+	var formData bool
+	for _, p := range e.op.Parameters {
+		switch p.In {
+		case "path":
+			arg := identifier(p.Name)
+			args = append(args, argument{name: arg, typ: g.tsType(p.schema(), "")})
+			url = strings.ReplaceAll(url, "{"+p.Name+"}", "${"+arg+"}")
+		case "query":
+			query = append(query, p)
+		case "formData":
+			formData = true
+		}
+	}
+
+	var data *argument
+	for _, p := range e.op.Parameters {
+		if p.In == "body" {
+			data = &argument{name: "data", typ: g.tsType(p.schema(), ""), optional: !p.Required}
+		}
+	}
+	if formData {
+		data = &argument{name: "data", typ: "FormData"}
+	}
+	if rb := e.op.RequestBody; rb != nil && data == nil {
+		typ := "any"
+		if media, ok := rb.Content["application/json"]; ok && media.Schema != nil {
+			typ = g.tsType(media.Schema, "")
+		} else if _, ok := rb.Content["multipart/form-data"]; ok {
+			typ = "FormData"
+		} else if media := firstMedia(rb.Content); media.Schema != nil {
+			typ = g.tsType(media.Schema, "")
+		}
+		data = &argument{name: "data", typ: typ, optional: !rb.Required}
+	}
+	if data != nil {
+		args = append(args, *data)
+	}
+	if len(query) > 0 {
+		required := false
+		for _, p := range query {
+			required = required || p.Required
+		}
+		args = append(args, argument{name: "params", typ: g.queryType(query), optional: !required})
+	}
+	// 必填参数之前的可选参数不能省略，改为显式接受 undefined
+	for i := len(args) - 2; i >= 0; i-- {
+		if args[i].optional && !args[i+1].optional {
+			args[i].optional = false
+			args[i].typ += " | undefined"
+		}
+	}
+
+	signature := make([]string, 0, len(args))
+	for _, arg := range args {
+		optional := ""
+		if arg.optional {
+			optional = "?"
+		}
+		signature = append(signature, fmt.Sprintf("%s%s: %s", arg.name, optional, arg.typ))
+	}
+
+	quoted := "'" + url + "'"
+	if strings.Contains(url, "${") {
+		quoted = "`" + url + "`"
+	}
+	call := []string{quoted}
+	config := []string{}
+	if len(query) > 0 {
+		config = append(config, "params")
+	}
+	switch e.method {
+	case "post", "put", "patch":
+		if data != nil {
+			call = append(call, "data")
+		} else if len(config) > 0 {
+			call = append(call, "undefined")
+		}
+	default:
+		if data != nil {
+			config = append(config, "data")
+		}
+	}
+	if len(config) > 0 {
+		call = append(call, "{ "+strings.Join(config, ", ")+" }")
+	}
+
+	generic := ""
+	if response := g.responseType(e.op); response != "" {
+		generic = "<" + response + ">"
+	}
+	writeDoc(b, "", e.summary)
+	if e.op.Deprecated {
+		b.WriteString("/** @deprecated */\n")
+	}
+	fmt.Fprintf(b, "export function %s(%s) {\n", e.name, strings.Join(signature, ", "))
+	fmt.Fprintf(b, "  return request.%s%s(%s)\n", e.method, generic, strings.Join(call, ", "))
+	b.WriteString("}\n")
+}
+
+// queryType 将查询参数合并为一个对象类型
+func (g *generator) queryType(params []parameter) string {
+	sort.SliceStable(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	fields := make([]string, 0, len(params))
+	for _, p := range params {
+		optional := "?"
+		if p.Required {
+			optional = ""
+		}
+		fields = append(fields, fmt.Sprintf("%s%s: %s", propertyName(p.Name), optional, g.tsType(p.schema(), "")))
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// responseType 返回第一个带结构的 2xx 响应的类型，内联对象按 return 语句缩进
+func (g *generator) responseType(op *operation) string {
+	const indent = "  "
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		response := op.Responses[code]
+		if response.Schema != nil {
+			return g.tsType(response.Schema, indent)
+		}
+		if media, ok := response.Content["application/json"]; ok && media.Schema != nil {
+			return g.tsType(media.Schema, indent)
+		}
+		if media := firstMedia(response.Content); media.Schema != nil {
+			return g.tsType(media.Schema, indent)
+		}
+	}
+	return ""
+}
+
+// firstMedia 按内容类型排序取第一个，保证输出稳定
+func firstMedia(content map[string]mediaType) mediaType {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return mediaType{}
+	}
+	return content[keys[0]]
+}
+
+// tsType 将 Schema 转换为 TypeScript 类型，indent 为内联对象所在的缩进
+func (g *generator) tsType(s *Schema, indent string) string {
+	if s == nil {
+		return "any"
+	}
+	typ := g.baseType(s, indent)
+	if (s.Nullable || s.Type.Nullable) && typ != "any" {
+		typ += " | null"
+	}
+	return typ
+}
+
+func (g *generator) baseType(s *Schema, indent string) string {
+	if s.Ref != "" {
+		name, ok := g.names[refName(s.Ref)]
+		if !ok {
+			return "any"
+		}
+		if g.used != nil {
+			g.used[name] = true
+		}
+		return name
+	}
+	if len(s.AllOf) > 0 {
+		return g.combine(s.AllOf, " & ", indent)
+	}
+	if len(s.OneOf) > 0 {
+		return g.combine(s.OneOf, " | ", indent)
+	}
+	if len(s.AnyOf) > 0 {
+		return g.combine(s.AnyOf, " | ", indent)
+	}
+	if len(s.Enum) > 0 {
+		literals := make([]string, 0, len(s.Enum))
+		for _, value := range s.Enum {
+			literals = append(literals, literal(value))
+		}
+		return strings.Join(literals, " | ")
+	}
+
+	switch s.Type.Name {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "file":
+		return "File"
+	case "array":
+		item := g.tsType(s.Items, indent)
+		if strings.ContainsAny(item, " |&") && !strings.HasPrefix(item, "{") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object", "":
+		if len(s.Properties) > 0 {
+			return g.objectType(s, indent)
+		}
+		if len(s.AdditionalProperties) > 0 {
+			var additional Schema
+			if err := json.Unmarshal(s.AdditionalProperties, &additional); err == nil {
+				return "Record<string, " + g.tsType(&additional, indent) + ">"
+			}
+		}
+		if s.Type.Name == "object" {
+			return "Record<string, any>"
+		}
+	}
+	return "any"
+}
+
+// combine 生成交叉或联合类型
+func (g *generator) combine(schemas []*Schema, sep, indent string) string {
+	types := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		types = append(types, g.tsType(s, indent))
+	}
+	return strings.Join(types, sep)
+}
+
+// objectType 生成多行对象类型，属性按名称排序
+func (g *generator) objectType(s *Schema, indent string) string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range names {
+		prop := s.Properties[name]
+		if prop != nil {
+			writeDoc(&b, indent+"  ", prop.Description)
+		}
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s\n", indent, propertyName(name), optional, g.tsType(prop, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// writeDoc 写入单行 JSDoc 注释
+func writeDoc(b *strings.Builder, indent, text string) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return
+	}
+	text = strings.ReplaceAll(text, "*/", "*\\/")
+	fmt.Fprintf(b, "%s/** %s */\n", indent, text)
+}
+
+// refName 取 $ref 指向的结构名称，如 #/definitions/models.User 中的 models.User
+func refName(ref string) string {
+	for _, prefix := range []string{"#/definitions/", "#/components/schemas/"} {
+		if strings.HasPrefix(ref, prefix) {
+			return strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return ref
+}
+
+// typeNames 为文档中的结构分配 TypeScript 类型名称：swag 生成的 models.User 优先取最后一段 User，
+// 与其他结构重名时使用完整名称 ModelsUser
+func typeNames(schemas map[string]*Schema) map[string]string {
+	originals := make([]string, 0, len(schemas))
+	for original := range schemas {
+		originals = append(originals, original)
+	}
+	sort.Strings(originals)
+
+	short := map[string]int{}
+	for _, original := range originals {
+		short[shortName(original)]++
+	}
+	names := map[string]string{}
+	taken := map[string]bool{}
+	for _, original := range originals {
+		name := shortName(original)
+		if short[name] > 1 {
+			name = pascal(original)
+		}
+		base := name
+		for i := 2; taken[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		taken[name] = true
+		names[original] = name
+	}
+	return names
+}
+
+func shortName(original string) string {
+	if i := strings.LastIndex(original, "."); i >= 0 && i < len(original)-1 {
+		if name := pascal(original[i+1:]); name != "" {
+			return name
+		}
+	}
+	return pascal(original)
+}
+
+// functionName 生成请求函数名称，优先使用 operationId，否则由方法和路径组成，如 GET /users/{id} 为 getUsersById
+func functionName(method, p, operationID string) string {
+	if name := camel(operationID); name != "" {
+		return name
+	}
+	name := method
+	for _, segment := range strings.Split(p, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name += "By" + pascal(strings.Trim(segment, "{}"))
+		} else {
+			name += pascal(segment)
+		}
+	}
+	return name
+}
+
+// words 将名称按非字母数字字符拆分为单词
+func words(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+func pascal(s string) string {
+	var b strings.Builder
+	for _, word := range words(s) {
+		runes := []rune(word)
+		b.WriteString(strings.ToUpper(string(runes[0])) + string(runes[1:]))
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit([]rune(name)[0]) {
+		name = "T" + name
+	}
+	return name
+}
+
+func camel(s string) string {
+	name := pascal(s)
+	if name == "" {
+		return ""
+	}
+	runes := []rune(name)
+	return strings.ToLower(string(runes[0])) + string(runes[1:])
+}
+
+// fileName 将 tag 转换为文件名，保留中文等非 ASCII 字母
+func fileName(tag string) string {
+	parts := words(tag)
+	for i, part := range parts {
+		parts[i] = strings.ToLower(part)
+	}
+	return strings.Join(parts, "-")
+}
+
+// reserved 不能用作参数名的 JavaScript 保留字
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"data": true, "params": true, "request": true,
+}
+
+// identifier 将路径参数名称转换为合法且不与 data/params 冲突的变量名
+func identifier(name string) string {
+	id := camel(name)
+	if id == "" {
+		id = "param"
+	}
+	if reserved[id] {
+		id += "Value"
+	}
+	return id
+}
+
+// propertyName 属性名不是合法标识符时加引号
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return literal(name)
+		}
+	}
+	if name == "" {
+		return "''"
+	}
+	return name
+}
+
+// literal 生成 TypeScript 字面量，字符串使用单引号
+func literal(value any) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`).Replace(v) + "'"
+	case nil:
+		return "null"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "any"
+	}
+	return string(encoded)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
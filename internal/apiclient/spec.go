@@ -0,0 +1,194 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFiles 未指定 --spec 时按顺序查找的接口文档，swag init 默认输出到 docs 目录
+var SpecFiles = []string{
+	"docs/swagger.json",
+	"docs/swagger.yaml",
+	"docs/swagger.yml",
+	"docs/openapi.json",
+	"docs/openapi.yaml",
+	"docs/openapi.yml",
+	"swagger.json",
+	"swagger.yaml",
+	"openapi.json",
+	"openapi.yaml",
+}
+
+// FindSpec 在项目目录中查找接口文档，返回相对项目目录的路径
+func FindSpec(dir string) (string, error) {
+	for _, rel := range SpecFiles {
+		if info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel))); err == nil && !info.IsDir() {
+			return rel, nil
+		}
+	}
+	return "", fmt.Errorf("no swagger/openapi document found (looked for %s); run swag init or pass --spec", strings.Join(SpecFiles[:2], ", "))
+}
+
+// Schema Swagger 2.0 与 OpenAPI 3 共用的 JSON Schema 子集
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Items                *Schema            `json:"items"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Enum                 []any              `json:"enum"`
+	AllOf                []*Schema          `json:"allOf"`
+	OneOf                []*Schema          `json:"oneOf"`
+	AnyOf                []*Schema          `json:"anyOf"`
+	Nullable             bool               `json:"nullable"`
+}
+
+// schemaType OpenAPI 3.1 中 type 可以是数组，如 ["string", "null"]
+type schemaType struct {
+	Name     string
+	Nullable bool
+}
+
+func (t *schemaType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		t.Name = name
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == "null" {
+			t.Nullable = true
+		} else if t.Name == "" {
+			t.Name = name
+		}
+	}
+	return nil
+}
+
+// parameter 接口参数；Swagger 2.0 直接在参数上声明类型，OpenAPI 3 放在 schema 中
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type"`
+	Format      string  `json:"format"`
+	Items       *Schema `json:"items"`
+	Enum        []any   `json:"enum"`
+	Schema      *Schema `json:"schema"`
+}
+
+// schema 返回参数的类型，body 参数和 OpenAPI 3 参数直接使用 schema
+func (p parameter) schema() *Schema {
+	if p.Schema != nil {
+		return p.Schema
+	}
+	return &Schema{Type: schemaType{Name: p.Type}, Format: p.Format, Items: p.Items, Enum: p.Enum}
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Tags        []string    `json:"tags"`
+	Deprecated  bool        `json:"deprecated"`
+	Parameters  []parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                 `json:"required"`
+		Content  map[string]mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Schema  *Schema              `json:"schema"`
+		Content map[string]mediaType `json:"content"`
+	} `json:"responses"`
+}
+
+// document 接口文档中生成客户端需要的部分
+type document struct {
+	Swagger     string                                `json:"swagger"`
+	OpenAPI     string                                `json:"openapi"`
+	BasePath    string                                `json:"basePath"`
+	Paths       map[string]map[string]json.RawMessage `json:"paths"`
+	Definitions map[string]*Schema                    `json:"definitions"`
+	Components  struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// methods 生成请求函数的 HTTP 方法，路径项中的 parameters 等其他字段不是接口
+var methods = map[string]bool{"get": true, "post": true, "put": true, "patch": true, "delete": true, "head": true, "options": true}
+
+// loadDocument 读取 JSON 或 YAML 格式的接口文档
+func loadDocument(path string) (*document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(trimmed, "{") {
+		// YAML 先解码为通用结构再转成 JSON，复用同一套结构体定义
+		var raw any
+		if err := yaml.Unmarshal(content, &raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		if content, err = json.Marshal(normalizeYAML(raw)); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if doc.Swagger == "" && doc.OpenAPI == "" {
+		return nil, fmt.Errorf("%s is not a swagger 2.0 or openapi 3 document", filepath.Base(path))
+	}
+	return &doc, nil
+}
+
+// normalizeYAML 将 YAML 中的非字符串键（如响应码 200）转换为字符串，以便编码为 JSON
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	}
+	return value
+}
+
+// schemas 返回文档中定义的数据结构
+func (d *document) schemas() map[string]*Schema {
+	if d.OpenAPI != "" {
+		return d.Components.Schemas
+	}
+	return d.Definitions
+}
@@ -0,0 +1,178 @@
+package ci
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Provider 一种 CI 平台的工作流文件
+type Provider struct {
+	Name string
+	// File 工作流文件路径（相对项目根目录）
+	File     string
+	template string
+}
+
+// Providers 支持的 CI 平台，Gitea Actions 兼容 GitHub Actions 的工作流语法
+var Providers = []Provider{
+	{Name: "github", File: ".github/workflows/ci.yml", template: "templates/github.yml.tmpl"},
+	{Name: "gitlab", File: ".gitlab-ci.yml", template: "templates/gitlab.yml.tmpl"},
+	{Name: "gitea", File: ".gitea/workflows/ci.yml", template: "templates/github.yml.tmpl"},
+}
+
+// defaultGoVersion go.mod 未声明 go 版本时使用的版本
+const defaultGoVersion = "1.22"
+
+// Options 生成 CI 配置的选项
+type Options struct {
+	Provider string
+	// Force 覆盖已存在的工作流文件
+	Force bool
+}
+
+// Report 生成结果
+type Report struct {
+	Provider string `json:"provider"`
+	File     string `json:"file"`
+	Created  bool   `json:"created"`
+	// Jobs 生成的任务：backend（go vet/go test）、frontend（lint/build）、docker（镜像构建）
+	Jobs []string `json:"jobs"`
+}
+
+// data 渲染模板使用的数据
+type data struct {
+	Module     string
+	GoVersion  string
+	Image      string
+	EnvExample bool
+	Frontend   bool
+	Lockfile   bool
+	Lint       bool
+	Docker     bool
+	Needs      string
+}
+
+// LookupProvider 按名称查找 CI 平台
+func LookupProvider(name string) (Provider, error) {
+	for _, provider := range Providers {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+	names := make([]string, 0, len(Providers))
+	for _, provider := range Providers {
+		names = append(names, provider.Name)
+	}
+	return Provider{}, fmt.Errorf("unsupported CI provider %q (%s)", name, strings.Join(names, ", "))
+}
+
+// Generate 根据项目的模块路径、go.mod 中的 Go 版本、是否包含前端和 Dockerfile 生成 CI 工作流
+func Generate(dir string, opts Options) (*Report, error) {
+	provider, err := LookupProvider(opts.Provider)
+	if err != nil {
+		return nil, err
+	}
+	mod, err := utils.ReadGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	d := data{
+		Module:     mod.Module,
+		GoVersion:  mod.Go,
+		Image:      imageName(mod.Module),
+		EnvExample: utils.FileExists(filepath.Join(dir, ".env.example")),
+		Frontend:   utils.FileExists(filepath.Join(dir, "frontend", "package.json")),
+		Lockfile:   utils.FileExists(filepath.Join(dir, "frontend", "pnpm-lock.yaml")),
+		Docker:     utils.FileExists(filepath.Join(dir, "Dockerfile")),
+	}
+	if d.GoVersion == "" {
+		d.GoVersion = defaultGoVersion
+	}
+	if provider.Name == "gitlab" {
+		// 镜像标签取 go.mod 中声明的主版本.次版本
+		d.GoVersion = (&utils.GoMod{Go: d.GoVersion}).MinorVersion()
+	}
+	if d.Frontend {
+		d.Lint = hasScript(filepath.Join(dir, "frontend", "package.json"), "lint")
+	}
+
+	report := &Report{Provider: provider.Name, File: provider.File, Jobs: []string{"backend"}}
+	if d.Frontend {
+		report.Jobs = append(report.Jobs, "frontend")
+	}
+	d.Needs = "[" + strings.Join(report.Jobs, ", ") + "]"
+	if d.Docker {
+		report.Jobs = append(report.Jobs, "docker")
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(provider.File))
+	if utils.FileExists(target) && !opts.Force {
+		return report, nil
+	}
+	content, err := render(provider.template, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return nil, err
+	}
+	report.Created = true
+	return report, nil
+}
+
+// render 渲染内置模板，使用 [[ ]] 作为分隔符以保留工作流中的 ${{ }} 表达式
+func render(name string, d data) ([]byte, error) {
+	tmpl, err := template.New(path.Base(name)).Delims("[[", "]]").ParseFS(templates, name)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// hasScript 判断 package.json 是否定义了指定的 npm 脚本
+func hasScript(packageJSON, name string) bool {
+	content, err := os.ReadFile(packageJSON)
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return false
+	}
+	_, ok := pkg.Scripts[name]
+	return ok
+}
+
+var invalidImageChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// imageName 取模块路径的最后一段作为镜像名称
+func imageName(module string) string {
+	name := strings.Trim(invalidImageChars.ReplaceAllString(strings.ToLower(path.Base(module)), "-"), "-._")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
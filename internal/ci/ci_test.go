@@ -0,0 +1,124 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func assertContains(t *testing.T, content string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+}
+
+var fullProject = map[string]string{
+	"go.mod":                  "module github.com/acme/Shop-API\n\ngo 1.22.3\n",
+	".env.example":            "APP_NAME=shop\n",
+	"Dockerfile":              "FROM scratch\n",
+	"frontend/package.json":   `{"scripts":{"dev":"vite","build":"vite build","lint":"eslint ."}}`,
+	"frontend/pnpm-lock.yaml": "lockfileVersion: '9.0'\n",
+}
+
+func TestGenerate_GitHub(t *testing.T) {
+	dir := writeProject(t, fullProject)
+	report, err := Generate(dir, Options{Provider: "github"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !report.Created || strings.Join(report.Jobs, ",") != "backend,frontend,docker" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	assertContains(t, read(t, dir, ".github/workflows/ci.yml"),
+		`go-version: "1.22.3"`,
+		"cp .env.example .env",
+		"go vet ./...",
+		"-coverpkg=github.com/acme/Shop-API/...",
+		"cache-dependency-path: frontend/pnpm-lock.yaml",
+		"pnpm install --frozen-lockfile",
+		"run: pnpm lint",
+		"run: pnpm build",
+		"needs: [backend, frontend]",
+		"tags: shop-api:${{ github.sha }}",
+	)
+
+	// 已存在的工作流不会被覆盖
+	if err := os.WriteFile(filepath.Join(dir, ".github/workflows/ci.yml"), []byte("custom\n"), 0644); err != nil {
+		t.Fatalf("failed to write workflow: %v", err)
+	}
+	report, err = Generate(dir, Options{Provider: "github"})
+	if err != nil || report.Created || read(t, dir, ".github/workflows/ci.yml") != "custom\n" {
+		t.Fatalf("expected existing workflow kept, got %+v, %v", report, err)
+	}
+}
+
+func TestGenerate_GitLabBackendOnly(t *testing.T) {
+	dir := writeProject(t, map[string]string{"go.mod": "module goravel\n\ngo 1.21.5\n"})
+	report, err := Generate(dir, Options{Provider: "gitlab"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Join(report.Jobs, ",") != "backend" || report.File != ".gitlab-ci.yml" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	content := read(t, dir, ".gitlab-ci.yml")
+	assertContains(t, content, "image: golang:1.21\n", "-coverpkg=goravel/...", "go tool cover -func=coverage.out")
+	for _, unwanted := range []string{"frontend", "docker", ".env.example"} {
+		if strings.Contains(content, unwanted) {
+			t.Fatalf("unexpected %q in:\n%s", unwanted, content)
+		}
+	}
+}
+
+func TestGenerate_GiteaWithoutLint(t *testing.T) {
+	files := map[string]string{}
+	for name, content := range fullProject {
+		files[name] = content
+	}
+	files["frontend/package.json"] = `{"scripts":{"build":"vite build"}}`
+	delete(files, "frontend/pnpm-lock.yaml")
+	dir := writeProject(t, files)
+
+	if _, err := Generate(dir, Options{Provider: "gitea"}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	content := read(t, dir, ".gitea/workflows/ci.yml")
+	assertContains(t, content, "run: pnpm install\n", "run: pnpm build")
+	if strings.Contains(content, "pnpm lint") || strings.Contains(content, "cache: pnpm") {
+		t.Fatalf("unexpected lint or pnpm cache without lockfile:\n%s", content)
+	}
+}
+
+func TestLookupProvider(t *testing.T) {
+	if _, err := LookupProvider("jenkins"); err == nil || !strings.Contains(err.Error(), "gitlab") {
+		t.Fatalf("expected unsupported provider error, got: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/ci"
+	"github.com/hulutech-web/goravel-kit-cli/internal/docker"
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/urfave/cli/v2"
+)
+
+var AddCommand = &cli.Command{
+	Name:  "add",
+	Usage: "Add optional scaffolding to an existing project",
+	Subcommands: []*cli.Command{
+		addDockerCommand,
+		addCICommand,
+	},
+}
+
+var addDockerCommand = &cli.Command{
+	Name:   "docker",
+	Usage:  "Generate a Dockerfile and docker-compose.yml with the database and Redis",
+	Action: addDocker,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "db",
+			Usage: "Database service: mysql, postgres, sqlite or sqlserver (defaults to DB_CONNECTION in .env)",
+		},
+		&cli.StringFlag{
+			Name:  "cache",
+			Usage: "Cache driver: redis or memory (defaults to CACHE_STORE in .env)",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite existing Dockerfile, docker-compose.yml and .dockerignore",
+		},
+	},
+}
+
+var addCICommand = &cli.Command{
+	Name:   "ci",
+	Usage:  "Generate a CI workflow running go vet, go test, frontend lint/build and the Docker build",
+	Action: addCI,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "provider",
+			Usage: "CI provider: github, gitlab or gitea",
+			Value: "github",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite an existing workflow file",
+		},
+	},
+}
+
+// addResult add 子命令在 json/ndjson 输出模式下的最终结果
+type addResult struct {
+	Success bool           `json:"success"`
+	Kind    string         `json:"kind"`
+	Dir     string         `json:"dir"`
+	Docker  *docker.Report `json:"docker,omitempty"`
+	CI      *ci.Report     `json:"ci,omitempty"`
+}
+
+func addDocker(c *cli.Context) error {
+	projectDir := c.String("dir")
+	opts := docker.Options{
+		Database: strings.ToLower(c.String("db")),
+		Cache:    strings.ToLower(c.String("cache")),
+		Force:    c.Bool("force"),
+	}
+	if err := (driver.Options{Database: opts.Database, Cache: opts.Cache}).Validate(); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("driver.error.select"), err)
+	}
+	// 优先使用生成项目时记录的项目名称和驱动
+	if meta, err := project.ReadMetadata(projectDir); err == nil {
+		opts.Project = meta.Project
+		if opts.Database == "" {
+			opts.Database = meta.Database
+		}
+		if opts.Cache == "" {
+			opts.Cache = meta.Cache
+		}
+	}
+
+	report, err := project.GenerateDocker(projectDir, opts, output.Default(false))
+	if err != nil {
+		return err
+	}
+	color.New(color.FgHiCyan).Printf("💡 %s\n", i18n.T("docker.next"))
+	return output.Result(addResult{Success: true, Kind: "docker", Dir: projectDir, Docker: report})
+}
+
+func addCI(c *cli.Context) error {
+	projectDir := c.String("dir")
+	opts := ci.Options{Provider: strings.ToLower(c.String("provider")), Force: c.Bool("force")}
+	if _, err := ci.LookupProvider(opts.Provider); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("ci.error.provider"), err)
+	}
+	report, err := project.GenerateCI(projectDir, opts, output.Default(false))
+	if err != nil {
+		return err
+	}
+	return output.Result(addResult{Success: true, Kind: "ci", Dir: projectDir, CI: report})
+}
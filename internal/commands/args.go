@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// interspersedCommands 允许把参数写在位置参数之后的命令，以 : 结尾时按前缀匹配
+var interspersedCommands = []string{"make:", "new", "plugin install"}
+
+// InterspersedArgs 将 interspersedCommands 中命令的位置参数移到参数之后，使 make:crud Article --fields name:string、
+// new my-app --force 这样把参数写在位置参数之后的用法也能被解析（urfave/cli v2 遇到第一个位置参数后不再解析参数）。
+// 其他命令的参数原样返回
+func InterspersedArgs(app *cli.App, args []string) []string {
+	if len(args) < 2 {
+		return args
+	}
+	result := []string{args[0]}
+	i := 1
+
+	// 全局参数和命令（含子命令）名称保持原位
+	flags := app.Flags
+	commands := app.Commands
+	var command *cli.Command
+	var path []string
+	for i < len(args) {
+		arg := args[i]
+		if arg == "--" {
+			return append(result, args[i:]...)
+		}
+		if isFlag(arg) {
+			result = append(result, arg)
+			if takesValue(flags, arg) && i+1 < len(args) {
+				result = append(result, args[i+1])
+				i++
+			}
+			i++
+			continue
+		}
+		next := findCommand(commands, arg)
+		if next == nil {
+			break
+		}
+		command = next
+		path = append(path, command.Name)
+		result = append(result, arg)
+		flags, commands = command.Flags, command.Subcommands
+		i++
+	}
+	// 插件等跳过参数解析的命令原样接收其后的全部参数
+	if command == nil || command.SkipFlagParsing || !interspersed(strings.Join(path, " ")) {
+		return args
+	}
+
+	var positional []string
+	for ; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i:]...)
+			break
+		}
+		if !isFlag(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+		result = append(result, arg)
+		if takesValue(flags, arg) && i+1 < len(args) {
+			result = append(result, args[i+1])
+			i++
+		}
+	}
+	return append(result, positional...)
+}
+
+// interspersed 判断命令是否允许把参数写在位置参数之后
+func interspersed(command string) bool {
+	for _, name := range interspersedCommands {
+		if command == name || (strings.HasSuffix(name, ":") && strings.HasPrefix(command, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isFlag(arg string) bool {
+	return len(arg) > 1 && strings.HasPrefix(arg, "-")
+}
+
+// takesValue 判断参数是否需要单独的值，如 --fields name 而不是 --fields=name 或布尔参数
+func takesValue(flags []cli.Flag, arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if strings.Contains(name, "=") {
+		return false
+	}
+	for _, flag := range flags {
+		for _, flagName := range flag.Names() {
+			if flagName == name {
+				_, isBool := flag.(*cli.BoolFlag)
+				return !isBool
+			}
+		}
+	}
+	return false
+}
+
+func findCommand(commands []*cli.Command, name string) *cli.Command {
+	for _, command := range commands {
+		if command.HasName(name) {
+			return command
+		}
+	}
+	return nil
+}
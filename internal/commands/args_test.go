@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestInterspersedArgs(t *testing.T) {
+	app := &cli.App{
+		Flags: []cli.Flag{&cli.StringFlag{Name: "output"}, &cli.BoolFlag{Name: "quiet"}},
+		Commands: []*cli.Command{NewCommand, AddCommand, MakeCrudCommand, PluginCommand, {Name: "deploy", SkipFlagParsing: true},
+			{Name: "upgrade", Flags: []cli.Flag{&cli.BoolFlag{Name: "dry-run"}}}},
+	}
+	cases := map[string]string{
+		"make:crud Article --fields name:string --force":     "make:crud --fields name:string --force Article",
+		"--output json make:crud Article --fields=a --dir x": "--output json make:crud --fields=a --dir x Article",
+		"new my-app --branch develop --force":                "new --branch develop --force my-app",
+		"--quiet add docker --db mysql":                      "--quiet add docker --db mysql",
+		"make:crud Article -- --fields":                      "make:crud Article -- --fields",
+		"unknown a --b":                                      "unknown a --b",
+		"deploy staging --force":                             "deploy staging --force",
+		"plugin install ./bin/deploy --name ship":            "plugin install --name ship ./bin/deploy",
+		"upgrade extra --dry-run":                            "upgrade extra --dry-run",
+	}
+	for input, want := range cases {
+		got := InterspersedArgs(app, append([]string{"goravel-kit-cli"}, strings.Fields(input)...))
+		if strings.Join(got[1:], " ") != want {
+			t.Fatalf("InterspersedArgs(%q) = %q, want %q", input, strings.Join(got[1:], " "), want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/diff"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/urfave/cli/v2"
+)
+
+// 项目文件相对模板的状态
+const (
+	driftModified = "modified"
+	driftDeleted  = "deleted"
+)
+
+// envSecretPattern .env 中由 CLI 生成或由用户填写的敏感配置，比较前替换为占位符
+var envSecretPattern = regexp.MustCompile(`^(\s*(?:export\s+)?[A-Za-z0-9_]*(?:KEY|SECRET|PASSWORD|TOKEN)\s*=).*$`)
+
+var DiffCommand = &cli.Command{
+	Name:   "diff",
+	Usage:  "Show how far a project has drifted from its template",
+	Action: diffProject,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "ref",
+			Usage: "Template branch, tag or commit to compare with (defaults to the recorded commit)",
+		},
+		&cli.StringFlag{
+			Name:  "repo",
+			Usage: "Template repository URL (defaults to the recorded repository)",
+		},
+		&cli.BoolFlag{
+			Name:  "stat",
+			Usage: "Only show changed line counts per file",
+		},
+		&cli.IntFlag{
+			Name:  "context",
+			Usage: "Number of context lines in the unified diff",
+			Value: diff.DefaultContext,
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Timeout for template download",
+			Value: 3 * time.Minute,
+		},
+	},
+}
+
+// fileDrift 单个文件相对模板的差异
+type fileDrift struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"`
+	Binary  bool   `json:"binary,omitempty"`
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+
+	unified string
+}
+
+// diffResult diff 命令在 json/ndjson 输出模式下的最终结果
+type diffResult struct {
+	Success bool        `json:"success"`
+	Project string      `json:"project"`
+	Ref     string      `json:"ref"`
+	SHA     string      `json:"sha"`
+	Files   []fileDrift `json:"files"`
+	Added   int         `json:"added"`
+	Deleted int         `json:"deleted"`
+}
+
+func diffProject(c *cli.Context) error {
+	projectDir := c.String("dir")
+	meta, err := project.LoadMetadata(projectDir, c.String("repo"))
+	if err != nil {
+		return err
+	}
+	ref := c.String("ref")
+	if ref == "" {
+		ref = meta.SHA
+	}
+	if ref == "" {
+		return fmt.Errorf("❌ %s", i18n.T("diff.error.no_ref", project.MetadataFile))
+	}
+
+	color.New(color.FgHiCyan).Printf("🔍 %s\n", i18n.T("diff.fetching", meta.Repository, ref))
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+	defer cancel()
+	templateDir, sha, err := project.Prepare(ctx, ref, meta, output.Default(false))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("diff.error.fetch", ref), err)
+	}
+	defer os.RemoveAll(templateDir)
+
+	drifts, err := computeDrift(templateDir, projectDir, c.Int("context"))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("diff.error.compare"), err)
+	}
+
+	result := diffResult{Success: true, Project: meta.Project, Ref: ref, SHA: sha, Files: drifts}
+	for _, drift := range drifts {
+		result.Added += drift.Added
+		result.Deleted += drift.Deleted
+	}
+	printDrift(result, c.Bool("stat"))
+	return output.Result(result)
+}
+
+// computeDrift 比较模板目录与项目目录中模板包含的文件，.env 中的敏感配置不参与比较
+func computeDrift(templateDir, projectDir string, contextLines int) ([]fileDrift, error) {
+	files, err := templateFiles(templateDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(templateDir, ".env")); err == nil {
+		files = append(files, ".env")
+	}
+	sort.Strings(files)
+
+	drifts := []fileDrift{}
+	for _, path := range files {
+		native := filepath.FromSlash(path)
+		templateContent, _, err := readOptional(filepath.Join(templateDir, native))
+		if err != nil {
+			return nil, err
+		}
+		projectContent, exists, err := readOptional(filepath.Join(projectDir, native))
+		if err != nil {
+			return nil, err
+		}
+
+		a, b := string(templateContent), string(projectContent)
+		if path == ".env" {
+			a, b = redactEnv(a), redactEnv(b)
+		}
+		if exists && a == b {
+			continue
+		}
+
+		drift := fileDrift{Path: path, Status: driftModified}
+		bName := "b/" + path
+		if !exists {
+			drift.Status = driftDeleted
+			bName = "/dev/null"
+		}
+		if diff.IsBinary(templateContent) || diff.IsBinary(projectContent) {
+			drift.Binary = true
+			drifts = append(drifts, drift)
+			continue
+		}
+		stat := diff.Compute(a, b)
+		drift.Added, drift.Deleted = stat.Added, stat.Deleted
+		drift.unified = diff.Unified("a/"+path, bName, a, b, contextLines)
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// redactEnv 将 .env 中密钥、密码类配置的值替换为占位符，避免生成的密钥产生差异或被打印
+func redactEnv(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		body := strings.TrimRight(line, "\r\n")
+		if envSecretPattern.MatchString(body) {
+			lines[i] = envSecretPattern.ReplaceAllString(body, "${1}<redacted>") + line[len(body):]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// printDrift 按统一差异格式或统计格式输出差异
+func printDrift(result diffResult, statOnly bool) {
+	if len(result.Files) == 0 {
+		color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("diff.identical", shortSHA(result.SHA)))
+		return
+	}
+
+	if !statOnly {
+		for _, drift := range result.Files {
+			if drift.Binary {
+				output.Printf("%s\n", i18n.T("diff.binary", drift.Path))
+				continue
+			}
+			output.Printf("%s", drift.unified)
+		}
+		output.Printf("\n")
+	}
+
+	width := 0
+	for _, drift := range result.Files {
+		width = max(width, len(drift.Path))
+	}
+	for _, drift := range result.Files {
+		if drift.Binary {
+			output.Printf(" %-*s | %s\n", width, drift.Path, i18n.T("diff.binary_short"))
+			continue
+		}
+		output.Printf(" %-*s | %4d ", width, drift.Path, drift.Added+drift.Deleted)
+		color.New(color.FgHiGreen).Printf("%s", strings.Repeat("+", min(drift.Added, 40)))
+		color.New(color.FgHiRed).Printf("%s", strings.Repeat("-", min(drift.Deleted, 40)))
+		output.Printf("\n")
+	}
+	color.New(color.FgHiWhite, color.Bold).Printf("📊 %s\n", i18n.T("diff.summary", len(result.Files), shortSHA(result.SHA), result.Added, result.Deleted))
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactEnv(t *testing.T) {
+	content := "APP_NAME=demo\nAPP_KEY=base64:abc\nJWT_SECRET=xyz\r\nDB_PASSWORD=\nexport API_TOKEN=t\n"
+	got := redactEnv(content)
+	want := "APP_NAME=demo\nAPP_KEY=<redacted>\nJWT_SECRET=<redacted>\r\nDB_PASSWORD=<redacted>\nexport API_TOKEN=<redacted>\n"
+	if got != want {
+		t.Fatalf("unexpected redaction:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestComputeDrift(t *testing.T) {
+	root := t.TempDir()
+	templateDir := root + "/template"
+	projectDir := root + "/project"
+	writeTree(t, templateDir, map[string]string{
+		"main.go":      "package main\n",
+		"config/db.go": "a\nb\n",
+		"removed.go":   "x\n",
+		".env":         "APP_NAME=demo\nAPP_KEY=\nJWT_SECRET=\n",
+	})
+	writeTree(t, projectDir, map[string]string{
+		"main.go":      "package main\n",
+		"config/db.go": "a\nb changed\nc\n",
+		"own.go":       "mine\n",
+		".env":         "APP_NAME=demo\nAPP_KEY=base64:generated\nJWT_SECRET=generated\n",
+	})
+
+	drifts, err := computeDrift(templateDir, projectDir, 3)
+	if err != nil {
+		t.Fatalf("computeDrift failed: %v", err)
+	}
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drifted files, got %+v", drifts)
+	}
+
+	if drifts[0].Path != "config/db.go" || drifts[0].Status != driftModified || drifts[0].Added != 2 || drifts[0].Deleted != 1 {
+		t.Fatalf("unexpected drift for config/db.go: %+v", drifts[0])
+	}
+	if !strings.Contains(drifts[0].unified, "+b changed\n") {
+		t.Fatalf("expected unified diff, got: %q", drifts[0].unified)
+	}
+	if drifts[1].Path != "removed.go" || drifts[1].Status != driftDeleted || drifts[1].Deleted != 1 {
+		t.Fatalf("unexpected drift for removed.go: %+v", drifts[1])
+	}
+}
@@ -0,0 +1,283 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+	"github.com/urfave/cli/v2"
+)
+
+// 检查结果状态
+const (
+	statusPass = "pass"
+	statusWarn = "warn"
+	statusFail = "fail"
+)
+
+// defaultRequirements 目录中没有 go.mod 和 package.json 时使用的 goravel-kit 模板最低版本要求
+var defaultRequirements = map[string]string{
+	"go":   "1.18",
+	"node": "14.0",
+}
+
+// enginePattern package.json engines 中的版本号，只有主版本号时也能匹配，如 ">=18"、"^8.6.0"
+var enginePattern = regexp.MustCompile(`\d+(?:\.\d+){0,2}`)
+
+// checkResult 单项环境检查结果
+type checkResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Remedy  string `json:"remedy,omitempty"`
+}
+
+// doctorReport doctor 命令的完整输出
+type doctorReport struct {
+	Checks []checkResult `json:"checks"`
+	Pass   int           `json:"pass"`
+	Warn   int           `json:"warn"`
+	Fail   int           `json:"fail"`
+}
+
+var DoctorCommand = &cli.Command{
+	Name:   "doctor",
+	Usage:  "Check the local environment against goravel-kit template requirements",
+	Action: runDoctor,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Alias for the global --output json",
+		},
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Template or project directory whose go.mod and package.json engines define the required versions",
+			Value: ".",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Timeout for each network check",
+			Value: 3 * time.Second,
+		},
+	},
+}
+
+func runDoctor(c *cli.Context) error {
+	timeout := c.Duration("timeout")
+	if c.Bool("json") {
+		if err := output.SetFormat(output.FormatJSON); err != nil {
+			return err
+		}
+	}
+	required := readRequirements(c.String("dir"))
+
+	var checks []checkResult
+	checks = append(checks, checkToolVersion("go", "Go", []string{"version"}, required["go"], statusFail, "https://go.dev/dl/"))
+	// new 命令依赖 git 克隆模板，缺失时无法创建项目
+	checks = append(checks, checkToolVersion("git", "Git", []string{"--version"}, required["git"], statusFail, "https://git-scm.com/downloads"))
+	checks = append(checks, checkToolVersion("node", "Node.js", []string{"--version"}, required["node"], statusFail, "https://nodejs.org/"))
+	// 纯 API 项目不需要 pnpm，缺失时只给出警告
+	checks = append(checks, checkToolVersion("pnpm", "pnpm", []string{"--version"}, required["pnpm"], statusWarn, "npm install -g pnpm"))
+	checks = append(checks, checkGoBinInPath())
+	checks = append(checks, checkSSHKeys())
+	checks = append(checks,
+		checkAddress("GitHub (HTTPS)", "github.com:443", timeout, statusWarn, i18n.T("doctor.remedy.github_https")),
+		checkAddress("GitHub (SSH)", "github.com:22", timeout, statusWarn, i18n.T("doctor.remedy.ssh_port")),
+		checkAddress("Gitee (HTTPS)", "gitee.com:443", timeout, statusWarn, i18n.T("doctor.remedy.gitee_https")),
+		checkAddress("Gitee (SSH)", "gitee.com:22", timeout, statusWarn, i18n.T("doctor.remedy.ssh_port")),
+		checkAddress("MySQL", "127.0.0.1:3306", timeout, statusWarn, i18n.T("doctor.remedy.mysql")),
+		checkAddress("Redis", "127.0.0.1:6379", timeout, statusWarn, i18n.T("doctor.remedy.redis")),
+	)
+
+	report := summarizeChecks(checks)
+
+	if output.IsText() {
+		printDoctorReport(report)
+	} else if err := output.Result(report); err != nil {
+		return err
+	}
+
+	if report.Fail > 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+// summarizeChecks 统计各状态数量
+func summarizeChecks(checks []checkResult) doctorReport {
+	report := doctorReport{Checks: checks}
+	for _, check := range checks {
+		switch check.Status {
+		case statusPass:
+			report.Pass++
+		case statusWarn:
+			report.Warn++
+		case statusFail:
+			report.Fail++
+		}
+	}
+	return report
+}
+
+func printDoctorReport(report doctorReport) {
+	color.New(color.FgHiWhite, color.Bold).Printf("🩺 %s\n\n", i18n.T("doctor.title"))
+	for _, check := range report.Checks {
+		switch check.Status {
+		case statusPass:
+			color.New(color.FgHiGreen).Printf("✅ %-16s %s\n", check.Name, check.Message)
+		case statusWarn:
+			color.New(color.FgHiYellow).Printf("⚠️  %-16s %s\n", check.Name, check.Message)
+		default:
+			color.New(color.FgHiRed).Printf("❌ %-16s %s\n", check.Name, check.Message)
+		}
+		if check.Remedy != "" && check.Status != statusPass {
+			color.New(color.FgHiCyan).Printf("   💡 %s\n", check.Remedy)
+		}
+	}
+	output.Printf("\n")
+	color.New(color.FgHiWhite).Printf("%s\n", i18n.T("doctor.summary", report.Pass, report.Warn, report.Fail))
+}
+
+// checkToolVersion 检查工具是否已安装且不低于 required，未安装时返回 missingStatus；required 为空时只检查是否安装。
+// 包装脚本或本地化的构建可能输出不了版本号，此时无法比较，只作为警告
+func checkToolVersion(bin, name string, args []string, required, missingStatus, remedy string) checkResult {
+	version, err := utils.CommandVersion(bin, args...)
+	if err != nil {
+		return checkResult{Name: name, Status: missingStatus, Message: i18n.T("doctor.not_installed"), Remedy: remedy}
+	}
+	if version == "" {
+		if required == "" {
+			return checkResult{Name: name, Status: statusPass, Message: i18n.T("doctor.unknown_version")}
+		}
+		return checkResult{Name: name, Status: statusWarn, Message: i18n.T("doctor.unknown_version"), Remedy: remedy}
+	}
+
+	if required != "" && utils.CompareVersions(version, required) < 0 {
+		return checkResult{
+			Name:    name,
+			Status:  statusFail,
+			Message: i18n.T("doctor.too_old", version, required),
+			Remedy:  remedy,
+		}
+	}
+	return checkResult{Name: name, Status: statusPass, Message: version}
+}
+
+// readRequirements 读取 dir 中模板或项目要求的最低版本：go.mod 的 go 指令，
+// 以及 package.json（dir 或 dir/frontend 下）engines 中的 node、pnpm；读取不到的项使用 defaultRequirements
+func readRequirements(dir string) map[string]string {
+	required := map[string]string{}
+	for tool, version := range defaultRequirements {
+		required[tool] = version
+	}
+	if mod, err := utils.ReadGoMod(dir); err == nil && mod.Go != "" {
+		required["go"] = mod.Go
+	}
+	for _, path := range []string{filepath.Join(dir, "package.json"), filepath.Join(dir, "frontend", "package.json")} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var pkg struct {
+			Engines map[string]string `json:"engines"`
+		}
+		if err := json.Unmarshal(content, &pkg); err != nil {
+			continue
+		}
+		for _, tool := range []string{"node", "pnpm"} {
+			if version := minimumVersion(pkg.Engines[tool]); version != "" {
+				required[tool] = version
+			}
+		}
+		break
+	}
+	return required
+}
+
+// minimumVersion 返回 engines 版本范围中的第一个版本号作为最低版本，如 ">=18.12 <21" 为 18.12、"^8 || ^9" 为 8.0
+func minimumVersion(constraint string) string {
+	version := enginePattern.FindString(constraint)
+	if version != "" && !strings.Contains(version, ".") {
+		version += ".0"
+	}
+	return version
+}
+
+// checkGoBinInPath 检查 go install 的安装目录是否在 PATH 中
+func checkGoBinInPath() checkResult {
+	const name = "GOBIN"
+
+	goBin := goEnv("GOBIN")
+	if goBin == "" {
+		goPath := goEnv("GOPATH")
+		if goPath == "" {
+			return checkResult{Name: name, Status: statusWarn, Message: i18n.T("doctor.goenv_failed"), Remedy: i18n.T("doctor.goenv_remedy")}
+		}
+		goBin = filepath.Join(filepath.SplitList(goPath)[0], "bin")
+	}
+
+	if pathContains(os.Getenv("PATH"), goBin) {
+		return checkResult{Name: name, Status: statusPass, Message: goBin}
+	}
+	return checkResult{
+		Name:    name,
+		Status:  statusWarn,
+		Message: i18n.T("doctor.not_in_path", goBin),
+		Remedy:  fmt.Sprintf("echo 'export PATH=\"%s:$PATH\"' >> ~/.zshrc && source ~/.zshrc", goBin),
+	}
+}
+
+// checkSSHKeys 检查是否有可用于 GitHub/Gitee 的 SSH 凭证
+func checkSSHKeys() checkResult {
+	const name = "SSH Key"
+
+	if keys := utils.FindSSHKeys(); len(keys) > 0 {
+		return checkResult{Name: name, Status: statusPass, Message: strings.Join(keys, ", ")}
+	}
+	if utils.HasSSHAgent() {
+		return checkResult{Name: name, Status: statusPass, Message: "ssh-agent"}
+	}
+	return checkResult{
+		Name:    name,
+		Status:  statusWarn,
+		Message: i18n.T("doctor.ssh_missing"),
+		Remedy:  i18n.T("doctor.ssh_remedy"),
+	}
+}
+
+// checkAddress 检查 TCP 地址是否可达，不可达时返回 failStatus
+func checkAddress(name, address string, timeout time.Duration, failStatus, remedy string) checkResult {
+	if utils.CheckHostAccess(address, timeout) {
+		return checkResult{Name: name, Status: statusPass, Message: i18n.T("doctor.reachable", address)}
+	}
+	return checkResult{Name: name, Status: failStatus, Message: i18n.T("doctor.unreachable", address), Remedy: remedy}
+}
+
+func goEnv(key string) string {
+	out, err := exec.Command("go", "env", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// pathContains 判断 PATH 列表中是否包含指定目录
+func pathContains(pathList, dir string) bool {
+	dir = filepath.Clean(dir)
+	for _, entry := range filepath.SplitList(pathList) {
+		if entry != "" && filepath.Clean(entry) == dir {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+)
+
+func TestSummarizeChecks(t *testing.T) {
+	report := summarizeChecks([]checkResult{
+		{Name: "Go", Status: statusPass},
+		{Name: "Node.js", Status: statusFail},
+		{Name: "pnpm", Status: statusWarn},
+		{Name: "Git", Status: statusPass},
+	})
+	if report.Pass != 2 || report.Warn != 1 || report.Fail != 1 {
+		t.Fatalf("unexpected summary: %+v", report)
+	}
+}
+
+func TestDoctorReport_JSONOutput(t *testing.T) {
+	report := summarizeChecks([]checkResult{
+		{Name: "Redis", Status: statusWarn, Message: "127.0.0.1:6379 无法连接", Remedy: "启动本地 Redis"},
+	})
+
+	var buf bytes.Buffer
+	output.SetWriter(&buf)
+	if err := output.SetFormat(output.FormatJSON); err != nil {
+		t.Fatalf("SetFormat failed: %v", err)
+	}
+	t.Cleanup(func() {
+		output.SetFormat(output.FormatText)
+		output.SetWriter(os.Stdout)
+	})
+	if err := output.Result(report); err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+
+	var decoded doctorReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if len(decoded.Checks) != 1 || decoded.Checks[0].Remedy == "" || decoded.Warn != 1 {
+		t.Fatalf("unexpected decoded report: %+v", decoded)
+	}
+	if !strings.Contains(buf.String(), `"status": "warn"`) {
+		t.Fatalf("expected status field in output, got: %s", buf.String())
+	}
+}
+
+func TestCheckToolVersion_Missing(t *testing.T) {
+	result := checkToolVersion("goravel-kit-cli-does-not-exist", "Missing", []string{"--version"}, "", statusFail, "install it")
+	if result.Status != statusFail || result.Remedy != "install it" {
+		t.Fatalf("expected fail with remedy, got: %+v", result)
+	}
+	result = checkToolVersion("goravel-kit-cli-does-not-exist", "Missing", []string{"--version"}, "1.0", statusWarn, "install it")
+	if result.Status != statusWarn {
+		t.Fatalf("expected optional tool to only warn, got: %+v", result)
+	}
+}
+
+func TestCheckToolVersion_UnknownVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "wrapped"), []byte("#!/bin/sh\necho custom build\n"), 0755); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	t.Setenv("PATH", bin)
+
+	result := checkToolVersion("wrapped", "Wrapped", []string{"--version"}, "1.0", statusFail, "install it")
+	if result.Status != statusWarn || result.Message != i18n.T("doctor.unknown_version") || result.Remedy != "install it" {
+		t.Fatalf("expected unknown version to only warn, got: %+v", result)
+	}
+	result = checkToolVersion("wrapped", "Wrapped", []string{"--version"}, "", statusFail, "install it")
+	if result.Status != statusPass {
+		t.Fatalf("expected installed tool without requirement to pass, got: %+v", result)
+	}
+}
+
+func TestReadRequirements(t *testing.T) {
+	if got := readRequirements(t.TempDir()); got["go"] != defaultRequirements["go"] || got["node"] != defaultRequirements["node"] || got["pnpm"] != "" {
+		t.Fatalf("expected defaults without go.mod and package.json, got %v", got)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                "module goravel\n\ngo 1.22.1\n",
+		"frontend/package.json": `{"name": "admin", "engines": {"node": ">=18.12 <23", "pnpm": "^8 || ^9"}}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	got := readRequirements(dir)
+	if got["go"] != "1.22.1" || got["node"] != "18.12" || got["pnpm"] != "8.0" {
+		t.Fatalf("expected requirements from go.mod and package.json engines, got %v", got)
+	}
+}
+
+func TestPathContains(t *testing.T) {
+	dir := filepath.Join("/home", "user", "go", "bin")
+	pathList := strings.Join([]string{"/usr/bin", dir + "/", "/bin"}, string(filepath.ListSeparator))
+	if !pathContains(pathList, dir) {
+		t.Fatalf("expected %q to be found in %q", dir, pathList)
+	}
+	if pathContains("/usr/bin", dir) {
+		t.Fatalf("expected %q not to be found", dir)
+	}
+}
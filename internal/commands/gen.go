@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/apiclient"
+	"github.com/hulutech-web/goravel-kit-cli/internal/crud"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/urfave/cli/v2"
+)
+
+var GenApiClientCommand = &cli.Command{
+	Name:   "gen:api-client",
+	Usage:  "Generate typed TypeScript request functions from the project's swagger.json/yaml",
+	Action: genApiClient,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "spec",
+			Usage: "Swagger/OpenAPI document relative to the project (defaults to docs/swagger.json or docs/swagger.yaml)",
+		},
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "Output directory relative to the project",
+			Value: apiclient.DefaultOut,
+		},
+		&cli.BoolFlag{
+			Name:  "check",
+			Usage: "Do not write files; fail if the generated client is out of date (for CI)",
+		},
+	},
+}
+
+// genResult gen:* 命令在 json/ndjson 输出模式下的最终结果
+type genResult struct {
+	Success bool `json:"success"`
+	*apiclient.Report
+}
+
+func genApiClient(c *cli.Context) error {
+	dir := c.String("dir")
+	report, err := apiclient.Generate(dir, apiclient.Options{
+		Spec:          c.String("spec"),
+		Out:           c.String("out"),
+		RequestImport: crud.RequestImport(filepath.Join(dir, "frontend")),
+		Check:         c.Bool("check"),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("gen.error.generate"), err)
+	}
+
+	color.New(color.FgHiCyan).Printf("📖 %s\n", i18n.T("gen.spec", report.Spec, report.Operations, report.Schemas))
+	stale := len(report.Changed)+len(report.Removed) > 0
+	if !stale {
+		color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("gen.unchanged", report.Out))
+		return output.Result(genResult{Success: true, Report: report})
+	}
+
+	if c.Bool("check") {
+		for _, file := range append(append([]string{}, report.Changed...), report.Removed...) {
+			color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("gen.stale", file))
+		}
+		color.New(color.FgHiRed).Printf("❌ %s\n", i18n.T("gen.error.stale"))
+		if err := output.Result(genResult{Success: false, Report: report}); err != nil {
+			return err
+		}
+		return cli.Exit("", 1)
+	}
+
+	for _, file := range report.Changed {
+		color.New(color.FgHiGreen).Printf("📄 %s\n", i18n.T("gen.updated", file))
+	}
+	for _, file := range report.Removed {
+		color.New(color.FgHiYellow).Printf("🗑️  %s\n", i18n.T("gen.removed", file))
+	}
+	return output.Result(genResult{Success: true, Report: report})
+}
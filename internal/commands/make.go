@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/crud"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/urfave/cli/v2"
+)
+
+var MakeCrudCommand = &cli.Command{
+	Name:      "make:crud",
+	Usage:     "Generate model, migration, request, controller, routes and Vue view/API files for a resource",
+	ArgsUsage: "<Resource>",
+	Action:    makeCrud,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringSliceFlag{
+			Name:     "fields",
+			Usage:    "Resource fields as name:type, e.g. --fields name:string,age:int,remark:text? (? marks optional fields)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "prefix",
+			Usage: "API route prefix used by the frontend client",
+			Value: crud.DefaultRoutePrefix,
+		},
+		&cli.BoolFlag{
+			Name:  "skip-frontend",
+			Usage: "Do not generate the Vue view and API client",
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite existing files",
+		},
+	},
+}
+
+var MakePermissionsCommand = &cli.Command{
+	Name:      "make:permissions",
+	Usage:     "Generate a database seeder with CRUD permission codes and a menu entry for a resource",
+	ArgsUsage: "<Resource>",
+	Action:    makePermissions,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "title",
+			Usage: "Display name used for the menu and permission names (defaults to the resource name)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "actions",
+			Usage: "Permission actions",
+			Value: cli.NewStringSlice(crud.PermissionActions...),
+		},
+		&cli.StringFlag{
+			Name:  "code",
+			Usage: "Permission code format using {resource} and {action}",
+			Value: crud.DefaultPermissionCode,
+		},
+		&cli.StringFlag{
+			Name:  "icon",
+			Usage: "Menu icon",
+		},
+		&cli.StringFlag{
+			Name:  "parent",
+			Usage: "Route path of the parent menu, e.g. system",
+		},
+		&cli.StringFlag{
+			Name:  "prefix",
+			Usage: "API route prefix recorded on permissions",
+			Value: crud.DefaultRoutePrefix,
+		},
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Overwrite an existing seeder",
+		},
+	},
+}
+
+// makeResult make:* 命令在 json/ndjson 输出模式下的最终结果
+type makeResult struct {
+	Success bool `json:"success"`
+	*crud.Report
+}
+
+func makeCrud(c *cli.Context) error {
+	if c.Args().Len() < 1 {
+		return fmt.Errorf("%s\n%s: goravel-kit-cli make:crud <Resource> --fields name:string,age:int", i18n.T("make.error.resource_required"), i18n.T("common.usage"))
+	}
+	fields, err := crud.ParseFields(c.StringSlice("fields"))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("make.error.fields"), err)
+	}
+
+	report, err := crud.Generate(c.String("dir"), crud.Options{
+		Resource:     c.Args().First(),
+		Fields:       fields,
+		RoutePrefix:  c.String("prefix"),
+		SkipFrontend: c.Bool("skip-frontend"),
+		Force:        c.Bool("force"),
+	})
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("make.error.generate"), err)
+	}
+
+	for _, file := range report.Files {
+		color.New(color.FgHiGreen).Printf("📄 %s\n", i18n.T("make.created", file))
+	}
+	for _, file := range report.Registered {
+		color.New(color.FgHiGreen).Printf("🔗 %s\n", i18n.T("make.registered", file))
+	}
+	if len(report.Manual) > 0 {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("make.manual"))
+		for _, step := range report.Manual {
+			color.New(color.FgHiYellow).Printf("   - %s\n", step)
+		}
+	}
+	color.New(color.FgHiCyan).Printf("\n💡 %s\n", i18n.T("make.next", strings.ToLower(report.Resource)))
+	return output.Result(makeResult{Success: true, Report: report})
+}
+
+// permissionResult make:permissions 在 json/ndjson 输出模式下的最终结果
+type permissionResult struct {
+	Success bool `json:"success"`
+	*crud.PermissionReport
+}
+
+// permissionActionKeys 权限名称中常用动作的翻译
+var permissionActionKeys = map[string]string{
+	"index":   "make.permission.index",
+	"list":    "make.permission.list",
+	"option":  "make.permission.option",
+	"show":    "make.permission.show",
+	"store":   "make.permission.store",
+	"update":  "make.permission.update",
+	"destroy": "make.permission.destroy",
+}
+
+func makePermissions(c *cli.Context) error {
+	if c.Args().Len() < 1 {
+		return fmt.Errorf("%s\n%s: goravel-kit-cli make:permissions <Resource>", i18n.T("make.error.resource_required"), i18n.T("common.usage"))
+	}
+
+	var actions []string
+	for _, value := range c.StringSlice("actions") {
+		for _, action := range strings.Split(value, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				actions = append(actions, action)
+			}
+		}
+	}
+	report, err := crud.GeneratePermissions(c.String("dir"), crud.PermissionOptions{
+		Resource:    c.Args().First(),
+		Title:       c.String("title"),
+		Actions:     actions,
+		Code:        c.String("code"),
+		Icon:        c.String("icon"),
+		Parent:      c.String("parent"),
+		RoutePrefix: c.String("prefix"),
+		Force:       c.Bool("force"),
+		Label: func(title, action string) string {
+			if key, ok := permissionActionKeys[action]; ok {
+				return i18n.T(key, title)
+			}
+			return i18n.T("make.permission.custom", title, action)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("make.error.permissions"), err)
+	}
+
+	color.New(color.FgHiGreen).Printf("📄 %s\n", i18n.T("make.created", report.File))
+	if report.Menu != "" {
+		color.New(color.FgHiGreen).Printf("📋 %s\n", i18n.T("make.permission.menu", report.Menu))
+	}
+	color.New(color.FgHiGreen).Printf("🔑 %s\n", i18n.T("make.permission.codes", strings.Join(report.Permissions, ", ")))
+	for _, file := range report.Registered {
+		color.New(color.FgHiGreen).Printf("🔗 %s\n", i18n.T("make.registered", file))
+	}
+	if len(report.Manual) > 0 {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("make.manual"))
+		for _, step := range report.Manual {
+			color.New(color.FgHiYellow).Printf("   - %s\n", step)
+		}
+	}
+	color.New(color.FgHiCyan).Printf("\n💡 %s\n", i18n.T("make.permission.next", report.Seeder))
+	return output.Result(permissionResult{Success: true, PermissionReport: report})
+}
@@ -0,0 +1,423 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/config"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/urfave/cli/v2"
+)
+
+// 端到端测试使用本地的裸仓库和 HTTP git 服务代替 GitHub/Gitee，不需要网络
+
+// e2eTemplate 测试模板的文件，包含模板仓库自身的文件和一个可移除的功能模块
+var e2eTemplate = map[string]string{
+	"go.mod":                    "module goravel\n\ngo 1.21\n",
+	"main.go":                   "package main\n\nfunc main() {}\n",
+	".env.example":              "APP_NAME=Goravel\nAPP_URL=http://localhost\n",
+	"app/pdf/pdf.go":            "package pdf\n",
+	"README.md":                 "# goravel-kit\n",
+	"LICENSE":                   "MIT\n",
+	".gitignore":                ".env\n",
+	".github/workflows/ci.yml":  "on: push\n",
+	"goravel-kit.manifest.json": `{"features": [{"name": "pdf", "paths": ["app/pdf"]}]}`,
+}
+
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+dir,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newBareTemplate 创建包含测试模板的裸仓库，master 之外还有一个多了 v2.txt 的 v2 分支，
+// 返回裸仓库路径和 master 的提交 SHA
+func newBareTemplate(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	work := filepath.Join(root, "work")
+	writeTree(t, work, e2eTemplate)
+	runGit(t, work, "init", "-q", "-b", "master")
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "template")
+	sha := runGit(t, work, "rev-parse", "HEAD")
+	runGit(t, work, "checkout", "-q", "-b", "v2")
+	writeTree(t, work, map[string]string{"v2.txt": "v2\n"})
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "v2")
+	runGit(t, work, "checkout", "-q", "master")
+
+	bare := filepath.Join(root, "goravel-kit.git")
+	runGit(t, root, "clone", "-q", "--bare", work, bare)
+	return bare, sha
+}
+
+// newGitHTTPServer 通过 git http-backend 以智能 HTTP 协议提供 dir 下的裸仓库
+func newGitHTTPServer(t *testing.T, dir string) *httptest.Server {
+	t.Helper()
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not installed")
+	}
+	if err := exec.Command(git, "http-backend").Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Skipf("git http-backend is not available: %v", err)
+		}
+	}
+	server := httptest.NewServer(&cgi.Handler{
+		Path: git,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + dir, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newHangingServer 接受连接但不返回响应，用于模拟超时的镜像源
+func newHangingServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		server.Close()
+	})
+	return server
+}
+
+// runNew 使用 mirrors 配置执行 new 命令，返回输出
+func runNew(t *testing.T, mirrors []config.Mirror, args ...string) (string, error) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	content, err := json.Marshal(config.Config{Mirrors: mirrors})
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv(config.EnvConfigPath, configPath)
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	config.Set(cfg)
+	t.Cleanup(func() { config.Set(nil) })
+
+	locale := i18n.Locale()
+	if err := i18n.SetLocale("en"); err != nil {
+		t.Fatalf("SetLocale failed: %v", err)
+	}
+	t.Cleanup(func() { i18n.SetLocale(locale) })
+
+	var buf bytes.Buffer
+	output.SetWriter(&buf)
+	t.Cleanup(func() {
+		output.SetFormat(output.FormatText)
+		output.SetWriter(os.Stdout)
+	})
+
+	app := &cli.App{
+		Name: "goravel-kit-cli",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Value: output.FormatText},
+		},
+		Before: func(c *cli.Context) error {
+			return output.SetFormat(c.String("output"))
+		},
+		Commands: []*cli.Command{NewCommand},
+	}
+	err = app.Run(InterspersedArgs(app, append([]string{"goravel-kit-cli"}, args...)))
+	return buf.String(), err
+}
+
+func TestNewE2E_LocalBareRepository(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, sha := newBareTemplate(t)
+
+	out, err := runNew(t, []config.Mirror{{Name: "local", URL: bare}}, "new", "shop", "--dir", dir, "--no-banner", "--without", "pdf")
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Template downloaded from local") {
+		t.Fatalf("expected success message for the local mirror, got:\n%s", out)
+	}
+
+	for _, name := range []string{"go.mod", "main.go", ".env.example", ".env", project.MetadataFile} {
+		if _, err := os.Stat(filepath.Join(shop, name)); err != nil {
+			t.Fatalf("expected %s in the generated project: %v", name, err)
+		}
+	}
+	for _, name := range []string{".git", "README.md", "LICENSE", ".gitignore", ".github", "goravel-kit.manifest.json", "app/pdf", "v2.txt"} {
+		if _, err := os.Stat(filepath.Join(shop, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be absent from the generated project, stat err=%v", name, err)
+		}
+	}
+	if env := readTreeFile(t, shop, ".env"); !strings.Contains(env, "APP_NAME=shop") || !strings.Contains(env, "APP_URL=http://localhost:3000") {
+		t.Fatalf("expected .env to be rendered, got %q", env)
+	}
+
+	meta, err := project.ReadMetadata(shop)
+	if err != nil {
+		t.Fatalf("ReadMetadata failed: %v", err)
+	}
+	if meta.Mirror != "local" || meta.Repository != bare || meta.SHA != sha || meta.Ref != "master" {
+		t.Fatalf("unexpected metadata: %+v", meta)
+	}
+	if strings.Join(meta.Without, ",") != "pdf" {
+		t.Fatalf("expected removed features to be recorded, got %v", meta.Without)
+	}
+}
+
+func TestNewE2E_Branch(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, _ := newBareTemplate(t)
+
+	out, err := runNew(t, []config.Mirror{{Name: "local", URL: "file://" + filepath.ToSlash(bare)}}, "new", "shop", "--dir", dir, "--no-banner", "--branch", "v2")
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	if content := readTreeFile(t, shop, "v2.txt"); content != "v2\n" {
+		t.Fatalf("expected the v2 branch to be used, got %q", content)
+	}
+}
+
+func TestNewE2E_HTTPMirrorAfterFailure(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, sha := newBareTemplate(t)
+	server := newGitHTTPServer(t, filepath.Dir(bare))
+
+	mirrors := []config.Mirror{
+		{Name: "missing", URL: filepath.Join(t.TempDir(), "missing.git")},
+		{Name: "http", URL: server.URL + "/" + filepath.Base(bare)},
+	}
+	out, err := runNew(t, mirrors, "--output", "json", "new", "shop", "--dir", dir)
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+
+	var result struct {
+		Success bool   `json:"success"`
+		Mirror  string `json:"mirror"`
+		Repo    string `json:"repo_url"`
+		SHA     string `json:"sha"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("expected a JSON result, got %q: %v", out, err)
+	}
+	if !result.Success || result.Mirror != "http" || result.SHA != sha {
+		t.Fatalf("expected fallback to the HTTP mirror, got %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(shop, "main.go")); err != nil {
+		t.Fatalf("expected the project to be generated: %v", err)
+	}
+}
+
+func TestNewE2E_TimeoutFallsBackToNextMirror(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	bare, _ := newBareTemplate(t)
+	hanging := newHangingServer(t)
+
+	mirrors := []config.Mirror{
+		{Name: "slow", URL: hanging.URL + "/goravel-kit.git"},
+		{Name: "local", URL: bare},
+	}
+	start := time.Now()
+	out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner", "--timeout", "1s")
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	if elapsed := time.Since(start); elapsed > 15*time.Second {
+		t.Fatalf("expected the slow mirror to time out after 1s, took %s", elapsed)
+	}
+	if !strings.Contains(out, "Download from slow failed") || !strings.Contains(out, "Trying the next mirror") {
+		t.Fatalf("expected the slow mirror to fail, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Template downloaded from local") {
+		t.Fatalf("expected fallback to the local mirror, got:\n%s", out)
+	}
+}
+
+func TestNewE2E_AllMirrorsFail(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(notFound.Close)
+
+	mirrors := []config.Mirror{
+		{Name: "gone", URL: notFound.URL + "/goravel-kit.git"},
+		{Name: "missing", URL: filepath.Join(t.TempDir(), "missing.git")},
+	}
+	out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner")
+	if err == nil || err.Error() != i18n.T("new.error.all_failed") {
+		t.Fatalf("expected all mirrors to fail, got %v\n%s", err, out)
+	}
+	for _, want := range []string{"Download from gone failed", "Download from missing failed", "Download failed on all mirrors!", "Possible solutions:"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(shop); !os.IsNotExist(err) {
+		t.Fatalf("expected no project dir after a failed download, stat err=%v", err)
+	}
+}
+
+func TestNewE2E_ExistingDirectoryWithoutForce(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, _ := newBareTemplate(t)
+
+	mirrors := []config.Mirror{{Name: "local", URL: bare}}
+	out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner")
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	_, err = runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner")
+	if err == nil || !strings.Contains(err.Error(), i18n.T("preflight.dir_exists", shop)) {
+		t.Fatalf("expected preflight to reject the existing dir, got %v", err)
+	}
+}
+
+func TestNewE2E_ForceRefusesDirtyRepository(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, _ := newBareTemplate(t)
+
+	mirrors := []config.Mirror{{Name: "local", URL: bare}}
+	if out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner"); err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	runGit(t, shop, "init", "-q")
+	if err := os.WriteFile(filepath.Join(shop, "todo.txt"), []byte("uncommitted work\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner", "--force")
+	if err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Fatalf("expected --force to refuse a dirty repository, got %v", err)
+	}
+	if readTreeFile(t, shop, "todo.txt") != "uncommitted work\n" {
+		t.Fatalf("expected uncommitted work to be kept")
+	}
+
+	// 提交后没有未保存的改动，非交互环境中 --force 不需要确认
+	runGit(t, shop, "add", "-A")
+	runGit(t, shop, "commit", "-q", "-m", "work")
+	if out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner", "--force"); err != nil {
+		t.Fatalf("new --force failed: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(shop, "todo.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected the committed repository to be replaced, stat err=%v", err)
+	}
+}
+
+func TestNewE2E_MergeAndBackup(t *testing.T) {
+	requireGit(t)
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare, _ := newBareTemplate(t)
+
+	mirrors := []config.Mirror{{Name: "local", URL: bare}}
+	if out, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--no-banner"); err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	for name, content := range map[string]string{
+		"main.go":  "package main // custom\n",
+		".env":     "APP_NAME=shop\nDB_PASSWORD=secret\n",
+		"notes.md": "# notes\n",
+	} {
+		if err := os.WriteFile(filepath.Join(shop, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	os.Remove(filepath.Join(shop, "go.mod"))
+
+	out, err := runNew(t, mirrors, "--output", "json", "new", "shop", "--dir", dir, "--merge", "--conflict", "rename")
+	if err != nil {
+		t.Fatalf("new --merge failed: %v\n%s", err, out)
+	}
+	var merged struct {
+		Conflicts []project.MergeConflict `json:"conflicts"`
+	}
+	if err := json.Unmarshal([]byte(out), &merged); err != nil {
+		t.Fatalf("expected a JSON result, got %q: %v", out, err)
+	}
+	if len(merged.Conflicts) != 1 || merged.Conflicts[0].Path != "main.go" || merged.Conflicts[0].RenamedTo != "main.go.orig" {
+		t.Fatalf("expected main.go to be the only conflict, got %+v", merged.Conflicts)
+	}
+	for name, want := range map[string]string{
+		"main.go":      e2eTemplate["main.go"],
+		"main.go.orig": "package main // custom\n",
+		"go.mod":       e2eTemplate["go.mod"],
+		".env":         "APP_NAME=shop\nDB_PASSWORD=secret\n",
+		"notes.md":     "# notes\n",
+	} {
+		if got := readTreeFile(t, shop, name); got != want {
+			t.Fatalf("expected %s to contain %q after merge, got %q", name, want, got)
+		}
+	}
+
+	out, err = runNew(t, mirrors, "--output", "json", "new", "shop", "--dir", dir, "--backup")
+	if err != nil {
+		t.Fatalf("new --backup failed: %v\n%s", err, out)
+	}
+	var backedUp struct {
+		Backup string `json:"backup"`
+	}
+	if err := json.Unmarshal([]byte(out), &backedUp); err != nil {
+		t.Fatalf("expected a JSON result, got %q: %v", out, err)
+	}
+	if !strings.HasPrefix(backedUp.Backup, shop+".bak-") || readTreeFile(t, backedUp.Backup, "notes.md") != "# notes\n" {
+		t.Fatalf("expected the merged project to be backed up, got %+v", backedUp)
+	}
+	if _, err := os.Stat(filepath.Join(shop, "notes.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected a fresh project after --backup, stat err=%v", err)
+	}
+
+	if _, err := runNew(t, mirrors, "new", "shop", "--dir", dir, "--force", "--backup"); err == nil {
+		t.Fatalf("expected --force and --backup to be rejected together")
+	}
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/config"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/plugin"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+	"github.com/urfave/cli/v2"
+)
+
+// pluginCategory 插件命令在 help 中的分组
+const pluginCategory = "Plugins"
+
+var PluginCommand = &cli.Command{
+	Name:  "plugin",
+	Usage: "Manage goravel-kit-cli-<name> plugins",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "install",
+			Usage:     "Install a plugin from a local executable or a Go package (example.com/goravel-kit-cli-foo@latest)",
+			ArgsUsage: "<file|package>",
+			Action:    installPlugin,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "Command name of the plugin (defaults to the file name without the goravel-kit-cli- prefix)",
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List plugins found in the plugins directory and on PATH",
+			Action: listPlugins,
+		},
+		{
+			Name:      "remove",
+			Usage:     "Remove a plugin from the plugins directory",
+			ArgsUsage: "<name>",
+			Action:    removePlugin,
+		},
+	},
+}
+
+// pluginResult plugin 命令在 json/ndjson 输出模式下的最终结果
+type pluginResult struct {
+	Success bool            `json:"success"`
+	Dir     string          `json:"dir"`
+	Plugins []plugin.Plugin `json:"plugins,omitempty"`
+	Removed string          `json:"removed,omitempty"`
+}
+
+// pluginsDir 返回当前配置下的插件目录
+func pluginsDir() string {
+	return config.PluginsDir(config.Current())
+}
+
+func installPlugin(c *cli.Context) error {
+	source := c.Args().First()
+	if source == "" {
+		return fmt.Errorf("❌ %s", i18n.T("plugin.error.source_required"))
+	}
+	dir := pluginsDir()
+	installed, err := plugin.Install(c.Context, dir, source, c.String("name"))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("plugin.error.install"), err)
+	}
+	if isBuiltinCommand(c.App.Commands, installed.Name) {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("plugin.conflict", installed.Name))
+	}
+
+	color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("plugin.installed", installed.Name, installed.Path))
+	return output.Result(pluginResult{Success: true, Dir: dir, Plugins: []plugin.Plugin{installed}})
+}
+
+func listPlugins(c *cli.Context) error {
+	dir := pluginsDir()
+	plugins := plugin.Discover(dir)
+	if !output.IsText() {
+		return output.Result(pluginResult{Success: true, Dir: dir, Plugins: plugins})
+	}
+
+	if len(plugins) == 0 {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("plugin.empty", dir))
+		return nil
+	}
+	w := tabwriter.NewWriter(output.Text(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tPATH")
+	for _, p := range plugins {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, p.Source, p.Path)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, p := range plugins {
+		if isBuiltinCommand(c.App.Commands, p.Name) {
+			color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("plugin.conflict", p.Name))
+		}
+		for _, path := range p.Shadowed {
+			color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("plugin.shadowed", path, p.Path))
+		}
+	}
+	return nil
+}
+
+func removePlugin(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return fmt.Errorf("❌ %s", i18n.T("plugin.error.name_required"))
+	}
+	dir := pluginsDir()
+	path, err := plugin.Remove(dir, name)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("plugin.error.remove"), err)
+	}
+
+	color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("plugin.removed", path))
+	return output.Result(pluginResult{Success: true, Dir: dir, Removed: path})
+}
+
+// PluginCommands 将发现的插件包装成子命令，与内置命令同名的插件会被忽略
+func PluginCommands(builtin []*cli.Command) []*cli.Command {
+	var commands []*cli.Command
+	for _, p := range plugin.Discover(pluginsDir()) {
+		if isBuiltinCommand(builtin, p.Name) {
+			continue
+		}
+		commands = append(commands, pluginCommand(p))
+	}
+	return commands
+}
+
+func pluginCommand(p plugin.Plugin) *cli.Command {
+	return &cli.Command{
+		Name:     p.Name,
+		Usage:    fmt.Sprintf("Plugin (%s)", p.Path),
+		Category: pluginCategory,
+		// 参数（包括 --help）原样交给插件处理
+		SkipFlagParsing: true,
+		HideHelp:        true,
+		Action: func(c *cli.Context) error {
+			cwd, _ := os.Getwd()
+			err := plugin.Run(c.Context, p, c.Args().Slice(), pluginEnv(c.App.Version, p, cwd))
+			if err == nil {
+				return nil
+			}
+			if code := plugin.ExitCode(err); code > 0 {
+				return cli.Exit("", code)
+			}
+			return fmt.Errorf("❌ %s: %w", i18n.T("plugin.error.run", p.Name), err)
+		},
+	}
+}
+
+// isBuiltinCommand 判断名称是否被内置命令占用，commands 中已注册的插件命令不算在内
+func isBuiltinCommand(commands []*cli.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	command := findCommand(commands, name)
+	return command != nil && command.Category != pluginCategory
+}
+
+// pluginEnv 通过环境变量向插件传递 CLI 和当前项目的上下文，从 dir（通常是当前目录）向上查找项目
+func pluginEnv(version string, p plugin.Plugin, dir string) []string {
+	env := []string{
+		"GORAVEL_KIT_CLI_VERSION=" + version,
+		"GORAVEL_KIT_PLUGIN_NAME=" + p.Name,
+		"GORAVEL_KIT_LANG=" + i18n.Locale(),
+		"GORAVEL_KIT_OUTPUT=" + output.Format(),
+		"GORAVEL_KIT_PLUGINS_DIR=" + pluginsDir(),
+	}
+	if bin, err := os.Executable(); err == nil {
+		env = append(env, "GORAVEL_KIT_CLI_BIN="+bin)
+	}
+	if path := config.Path(); path != "" {
+		env = append(env, config.EnvConfigPath+"="+path)
+	}
+
+	if dir == "" {
+		return env
+	}
+	projectDir := findProjectDir(dir)
+	if projectDir == "" {
+		return env
+	}
+	env = append(env, "GORAVEL_KIT_PROJECT_DIR="+projectDir)
+	if mod, err := utils.ReadGoMod(projectDir); err == nil {
+		env = append(env, "GORAVEL_KIT_MODULE="+mod.Module)
+	}
+	if meta, err := project.ReadMetadata(projectDir); err == nil {
+		env = append(env,
+			"GORAVEL_KIT_TEMPLATE_REPO="+meta.Repository,
+			"GORAVEL_KIT_TEMPLATE_REF="+meta.Ref,
+			"GORAVEL_KIT_TEMPLATE_SHA="+meta.SHA,
+			"GORAVEL_KIT_DATABASE="+meta.Database,
+			"GORAVEL_KIT_CACHE="+meta.Cache,
+		)
+	}
+	return env
+}
+
+// findProjectDir 从 dir 向上查找包含模板元信息或 go.mod 的项目根目录，找不到时返回空字符串
+func findProjectDir(dir string) string {
+	for {
+		for _, name := range []string{project.MetadataFile, "go.mod"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/config"
+	"github.com/hulutech-web/goravel-kit-cli/internal/plugin"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/urfave/cli/v2"
+)
+
+func TestPluginCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in this test")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"deploy", "routes", "help"} {
+		if err := os.WriteFile(filepath.Join(dir, plugin.Prefix+name), []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("failed to write plugin: %v", err)
+		}
+	}
+	t.Setenv(config.EnvPluginsDir, dir)
+	t.Setenv("PATH", "")
+
+	commands := PluginCommands([]*cli.Command{RoutesCommand, PluginCommand})
+	if len(commands) != 1 || commands[0].Name != "deploy" {
+		t.Fatalf("expected only the deploy plugin, got %+v", commands)
+	}
+	if !commands[0].SkipFlagParsing || commands[0].Category != pluginCategory {
+		t.Fatalf("plugin commands must pass flags through and be grouped in help: %+v", commands[0])
+	}
+}
+
+func TestPluginEnv(t *testing.T) {
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module example.com/shop\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+	meta := `{"projectDir":"shop","repository":"https://example.com/kit.git","ref":"v1.2.0","sha":"abc123","database":"postgres"}`
+	if err := os.WriteFile(filepath.Join(projectDir, project.MetadataFile), []byte(meta), 0644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	sub := filepath.Join(projectDir, "app", "models")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	env := strings.Join(pluginEnv("v9.9.9", plugin.Plugin{Name: "deploy"}, sub), "\n")
+	for _, want := range []string{
+		"GORAVEL_KIT_CLI_VERSION=v9.9.9",
+		"GORAVEL_KIT_PLUGIN_NAME=deploy",
+		"GORAVEL_KIT_PROJECT_DIR=" + projectDir,
+		"GORAVEL_KIT_MODULE=example.com/shop",
+		"GORAVEL_KIT_TEMPLATE_REF=v1.2.0",
+		"GORAVEL_KIT_TEMPLATE_SHA=abc123",
+		"GORAVEL_KIT_DATABASE=postgres",
+	} {
+		if !strings.Contains(env, want+"\n") && !strings.HasSuffix(env, want) {
+			t.Fatalf("expected %q in plugin env:\n%s", want, env)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/progress"
+	"github.com/hulutech-web/goravel-kit-cli/pkg/scaffold"
+)
+
+// terminalPrompter 在终端上询问用户。提示写到标准错误，不影响 json/ndjson 输出
+type terminalPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// yes 跳过确认，直接同意
+	yes bool
+	// all 用户以大写字母作答后对剩余冲突统一使用的处理方式
+	all string
+}
+
+// newPrompter 标准输入和标准错误都是终端时返回 terminalPrompter，否则返回 nil 表示非交互环境
+func newPrompter(yes bool) scaffold.Prompter {
+	if !progress.IsTerminal(os.Stdin) || !progress.IsTerminal(os.Stderr) {
+		return nil
+	}
+	return &terminalPrompter{in: bufio.NewReader(os.Stdin), out: os.Stderr, yes: yes}
+}
+
+// Confirm 询问 question，只有回答 y 或 yes 时同意，直接回车视为拒绝
+func (p *terminalPrompter) Confirm(question string) (bool, error) {
+	if p.yes {
+		return true, nil
+	}
+	color.New(color.FgHiYellow).Fprintf(p.out, "⚠️  %s %s ", question, i18n.T("new.prompt.yes_no"))
+	answer, err := p.readLine()
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// ResolveConflict 询问冲突的处理方式，直到输入 s、o、r 之一；大写字母对剩余冲突都生效
+func (p *terminalPrompter) ResolveConflict(path string) (string, error) {
+	if p.all != "" {
+		return p.all, nil
+	}
+	actions := map[string]string{"s": scaffold.ConflictSkip, "o": scaffold.ConflictOverwrite, "r": scaffold.ConflictRename}
+	for {
+		color.New(color.FgHiYellow).Fprintf(p.out, "⚠️  %s: ", i18n.T("new.prompt.conflict", path))
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		action, ok := actions[strings.ToLower(answer)]
+		if !ok {
+			continue
+		}
+		if answer != strings.ToLower(answer) {
+			p.all = action
+		}
+		return action, nil
+	}
+}
+
+// readLine 读取一行并去掉首尾空白；输入结束且没有内容时返回 io.EOF
+func (p *terminalPrompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", i18n.T("new.error.aborted"), err)
+	}
+	return strings.TrimSpace(line), nil
+}
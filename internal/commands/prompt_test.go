@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/pkg/scaffold"
+)
+
+func newTestPrompter(input string) *terminalPrompter {
+	return &terminalPrompter{in: bufio.NewReader(strings.NewReader(input)), out: io.Discard}
+}
+
+func TestTerminalPrompter_Confirm(t *testing.T) {
+	for input, want := range map[string]bool{"y\n": true, " YES \n": true, "\n": false, "n\n": false, "sure\n": false} {
+		got, err := newTestPrompter(input).Confirm("Continue?")
+		if err != nil || got != want {
+			t.Fatalf("Confirm(%q) = %v %v, want %v", input, got, err, want)
+		}
+	}
+	if _, err := newTestPrompter("").Confirm("Continue?"); err == nil {
+		t.Fatalf("expected an error when input ends")
+	}
+	if got, err := (&terminalPrompter{yes: true}).Confirm("Continue?"); err != nil || !got {
+		t.Fatalf("expected --yes to confirm without reading input, got %v %v", got, err)
+	}
+}
+
+func TestTerminalPrompter_ResolveConflict(t *testing.T) {
+	p := newTestPrompter("x\ns\no\nR\n")
+
+	var got []string
+	for _, path := range []string{"main.go", "go.mod", "app.go", "routes.go", "config.go"} {
+		action, err := p.ResolveConflict(path)
+		if err != nil {
+			t.Fatalf("ResolveConflict(%s) failed: %v", path, err)
+		}
+		got = append(got, action)
+	}
+	want := []string{scaffold.ConflictSkip, scaffold.ConflictOverwrite, scaffold.ConflictRename, scaffold.ConflictRename, scaffold.ConflictRename}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected invalid answers to be asked again and uppercase to apply to the rest, got %v", got)
+	}
+}
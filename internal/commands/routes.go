@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/routes"
+	"github.com/urfave/cli/v2"
+)
+
+var RoutesCommand = &cli.Command{
+	Name:   "routes",
+	Usage:  "List routes by statically parsing routes/*.go, without compiling or booting the app",
+	Action: listRoutes,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Alias for the global --output json (stable order, suitable for diffing between commits)",
+		},
+		&cli.StringFlag{
+			Name:  "method",
+			Usage: "Only list routes with this HTTP method",
+		},
+		&cli.StringFlag{
+			Name:  "path",
+			Usage: "Only list routes whose path starts with this prefix",
+		},
+	},
+}
+
+// routesResult routes 命令在 json/ndjson 输出模式下的最终结果
+type routesResult struct {
+	Success bool `json:"success"`
+	*routes.Report
+}
+
+func listRoutes(c *cli.Context) error {
+	if c.Bool("json") {
+		if err := output.SetFormat(output.FormatJSON); err != nil {
+			return err
+		}
+	}
+	report, err := routes.Analyze(c.String("dir"))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("routes.error.analyze"), err)
+	}
+	report.Routes = filterRoutes(report.Routes, c.String("method"), c.String("path"))
+
+	if !output.IsText() {
+		return output.Result(routesResult{Success: true, Report: report})
+	}
+
+	if len(report.Routes) == 0 {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", i18n.T("routes.empty"))
+	} else {
+		w := tabwriter.NewWriter(output.Text(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "METHOD\tPATH\tHANDLER\tMIDDLEWARE")
+		for _, route := range report.Routes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", route.Method, route.Path, route.Handler, strings.Join(route.Middleware, ", "))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		output.Printf("\n")
+		color.New(color.FgHiWhite).Printf("%s\n", i18n.T("routes.summary", len(report.Routes)))
+	}
+	for _, warning := range report.Warnings {
+		color.New(color.FgHiYellow).Printf("⚠️  %s\n", warning)
+	}
+	return nil
+}
+
+// filterRoutes 按 HTTP 方法和路径前缀筛选路由
+func filterRoutes(list []routes.Route, method, prefix string) []routes.Route {
+	if method == "" && prefix == "" {
+		return list
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	filtered := []routes.Route{}
+	for _, route := range list {
+		if method != "" && !strings.EqualFold(route.Method, method) {
+			continue
+		}
+		if !strings.HasPrefix(route.Path, prefix) {
+			continue
+		}
+		filtered = append(filtered, route)
+	}
+	return filtered
+}
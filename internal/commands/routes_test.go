@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/routes"
+)
+
+func TestFilterRoutes(t *testing.T) {
+	list := []routes.Route{
+		{Method: "GET", Path: "/api/admin/user"},
+		{Method: "POST", Path: "/api/admin/user"},
+		{Method: "GET", Path: "/crud/tables"},
+	}
+
+	if got := filterRoutes(list, "", ""); len(got) != 3 {
+		t.Fatalf("expected no filtering, got %+v", got)
+	}
+	if got := filterRoutes(list, "post", ""); len(got) != 1 || got[0].Method != "POST" {
+		t.Fatalf("unexpected method filter result: %+v", got)
+	}
+	if got := filterRoutes(list, "", "api/admin"); len(got) != 2 {
+		t.Fatalf("unexpected path filter result: %+v", got)
+	}
+	if got := filterRoutes(list, "DELETE", ""); got == nil || len(got) != 0 {
+		t.Fatalf("expected an empty, non-nil result, got %#v", got)
+	}
+}
+
+func TestListRoutes_JSONAliasUsesOutputResult(t *testing.T) {
+	dir := t.TempDir()
+	web := `package routes
+
+import "goravel/app/facades"
+
+func Api() {
+	facades.Route().Get("/api/ping", nil)
+}
+`
+	if err := os.MkdirAll(filepath.Join(dir, "routes"), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "routes", "api.go"), []byte(web), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	output.SetWriter(&buf)
+	t.Cleanup(func() {
+		output.SetFormat(output.FormatText)
+		output.SetWriter(os.Stdout)
+	})
+	app := &cli.App{Commands: []*cli.Command{RoutesCommand}}
+	if err := app.Run([]string{"goravel-kit-cli", "routes", "--dir", dir, "--json"}); err != nil {
+		t.Fatalf("routes --json failed: %v", err)
+	}
+
+	var decoded routesResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	if !decoded.Success || decoded.Report == nil || len(decoded.Routes) != 1 || decoded.Routes[0].Path != "/api/ping" {
+		t.Fatalf("unexpected routes result: %s", buf.String())
+	}
+}
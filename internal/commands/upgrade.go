@@ -0,0 +1,488 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/diff"
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+	"github.com/urfave/cli/v2"
+)
+
+// 升级时单个文件的处理方式
+const (
+	changeAdd      = "add"
+	changeUpdate   = "update"
+	changeDelete   = "delete"
+	changeMerge    = "merge"
+	changeConflict = "conflict"
+)
+
+// 冲突写入方式
+const (
+	conflictMarkers = "markers"
+	conflictReject  = "rej"
+)
+
+// rejectSuffix 无法合并的模板改动写入的文件后缀
+const rejectSuffix = ".rej"
+
+var UpgradeCommand = &cli.Command{
+	Name:   "upgrade",
+	Usage:  "Merge template updates into an existing project",
+	Action: upgradeProject,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Project directory",
+			Value: ".",
+		},
+		&cli.StringFlag{
+			Name:  "ref",
+			Usage: "Template branch, tag or commit to upgrade to (defaults to the recorded ref)",
+		},
+		&cli.StringFlag{
+			Name:  "base",
+			Usage: "Template commit the project was created from (defaults to the recorded commit)",
+		},
+		&cli.StringFlag{
+			Name:  "repo",
+			Usage: "Template repository URL (defaults to the recorded repository)",
+		},
+		&cli.StringFlag{
+			Name:  "conflict",
+			Usage: "How to write conflicts: markers (in place) or rej (<file>.rej)",
+			Value: conflictMarkers,
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show the changes as a diff without writing anything",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Timeout for each template download",
+			Value: 3 * time.Minute,
+		},
+	},
+}
+
+// fileChange 升级计划中的一个文件改动
+type fileChange struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	// Conflicts 冲突块数量，二进制文件或删除冲突为 1
+	Conflicts int `json:"conflicts,omitempty"`
+	// Reject 写入 .rej 文件的路径（相对项目目录）
+	Reject string `json:"reject,omitempty"`
+
+	current []byte
+	content []byte
+	reject  []byte
+	mode    fs.FileMode
+}
+
+// upgradeResult upgrade 命令在 json/ndjson 输出模式下的最终结果
+type upgradeResult struct {
+	Success   bool         `json:"success"`
+	DryRun    bool         `json:"dry_run"`
+	Project   string       `json:"project"`
+	FromSHA   string       `json:"from_sha"`
+	ToSHA     string       `json:"to_sha"`
+	Ref       string       `json:"ref"`
+	Changes   []fileChange `json:"changes"`
+	Conflicts int          `json:"conflicts"`
+}
+
+func upgradeProject(c *cli.Context) error {
+	projectDir := c.String("dir")
+	conflictStyle := c.String("conflict")
+	dryRun := c.Bool("dry-run")
+	if conflictStyle != conflictMarkers && conflictStyle != conflictReject {
+		return fmt.Errorf("❌ %s", i18n.T("upgrade.error.conflict_style", conflictStyle))
+	}
+
+	meta, err := project.LoadMetadata(projectDir, c.String("repo"))
+	if err != nil {
+		return err
+	}
+	if base := c.String("base"); base != "" {
+		meta.SHA = base
+	}
+	if meta.SHA == "" {
+		return fmt.Errorf("❌ %s", i18n.T("upgrade.error.no_base", project.MetadataFile))
+	}
+	ref := c.String("ref")
+	if ref == "" {
+		ref = meta.Ref
+	}
+	if ref == "" {
+		ref = "master"
+	}
+
+	color.New(color.FgHiCyan).Printf("⬆️  %s\n", i18n.T("upgrade.fetching", meta.Repository, shortSHA(meta.SHA), ref))
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+	defer cancel()
+	baseDir, baseSHA, err := project.Prepare(ctx, meta.SHA, meta, output.Default(false))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("upgrade.error.fetch", shortSHA(meta.SHA)), err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	ctx, cancel = context.WithTimeout(context.Background(), c.Duration("timeout"))
+	defer cancel()
+	newDir, newSHA, err := project.Prepare(ctx, ref, meta, output.Default(false))
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("upgrade.error.fetch", ref), err)
+	}
+	defer os.RemoveAll(newDir)
+
+	result := upgradeResult{DryRun: dryRun, Project: meta.Project, FromSHA: baseSHA, ToSHA: newSHA, Ref: ref}
+	if baseSHA == newSHA {
+		color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("upgrade.up_to_date", shortSHA(newSHA)))
+		result.Success = true
+		result.Changes = []fileChange{}
+		return output.Result(result)
+	}
+
+	changes, err := planUpgrade(baseDir, newDir, projectDir, conflictStyle)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("upgrade.error.plan"), err)
+	}
+	for _, change := range changes {
+		result.Conflicts += change.Conflicts
+		output.Emit(output.EventFileChange, map[string]any{"path": change.Path, "action": change.Action, "conflicts": change.Conflicts})
+	}
+	result.Changes = changes
+	printUpgradePlan(changes, dryRun)
+
+	if dryRun {
+		result.Success = true
+		return output.Result(result)
+	}
+
+	if err := applyUpgrade(projectDir, changes); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("upgrade.error.apply"), err)
+	}
+
+	meta.Ref = ref
+	meta.SHA = newSHA
+	meta.UpdatedAt = time.Now().UTC()
+	if err := project.WriteMetadata(fsys.OS, projectDir, meta); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("upgrade.error.write_metadata"), err)
+	}
+	slog.Info("project upgraded", "dir", projectDir, "from", baseSHA, "to", newSHA, "changes", len(changes), "conflicts", result.Conflicts)
+
+	if result.Conflicts > 0 {
+		color.New(color.FgHiYellow).Printf("\n⚠️  %s\n", i18n.T("upgrade.done_conflicts", shortSHA(newSHA), result.Conflicts))
+	} else {
+		color.New(color.FgHiGreen).Printf("\n🎉 %s\n", i18n.T("upgrade.done", shortSHA(newSHA)))
+	}
+	result.Success = true
+	return output.Result(result)
+}
+
+// planUpgrade 对比旧模板 baseDir、新模板 newDir 和当前项目 projectDir，计算需要应用的改动。
+// 只处理模板中出现过的文件，项目自有的文件不受影响
+func planUpgrade(baseDir, newDir, projectDir, conflictStyle string) ([]fileChange, error) {
+	paths := map[string]bool{}
+	for _, dir := range []string{baseDir, newDir} {
+		files, err := templateFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			paths[file] = true
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	changes := []fileChange{}
+	for _, path := range sorted {
+		change, err := planFile(baseDir, newDir, projectDir, path, conflictStyle)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// planFile 计算单个文件的三方合并结果，无需改动时返回 nil
+func planFile(baseDir, newDir, projectDir, path, conflictStyle string) (*fileChange, error) {
+	native := filepath.FromSlash(path)
+	base, hasBase, err := readOptional(filepath.Join(baseDir, native))
+	if err != nil {
+		return nil, err
+	}
+	theirs, hasTheirs, err := readOptional(filepath.Join(newDir, native))
+	if err != nil {
+		return nil, err
+	}
+	current, hasCurrent, err := readOptional(filepath.Join(projectDir, native))
+	if err != nil {
+		return nil, err
+	}
+
+	// 模板未改动，或项目已与新模板一致
+	if hasBase == hasTheirs && bytes.Equal(base, theirs) {
+		return nil, nil
+	}
+	if hasCurrent == hasTheirs && bytes.Equal(current, theirs) {
+		return nil, nil
+	}
+
+	change := &fileChange{Path: path, current: current, mode: 0644}
+	if info, err := os.Stat(filepath.Join(newDir, native)); err == nil {
+		change.mode = info.Mode().Perm()
+	}
+
+	switch {
+	case !hasTheirs:
+		// 模板删除了文件：项目未修改时直接删除，否则保留并记录冲突
+		if !hasCurrent {
+			return nil, nil
+		}
+		if bytes.Equal(current, base) {
+			change.Action = changeDelete
+			return change, nil
+		}
+		change.Action = changeConflict
+		change.Conflicts = 1
+		change.reject = rejectContent(path, base, nil)
+		change.Reject = path + rejectSuffix
+		return change, nil
+
+	case !hasCurrent:
+		// 项目中不存在：模板新增的文件直接添加，项目主动删除的文件只记录模板改动
+		if !hasBase {
+			change.Action = changeAdd
+			change.content = theirs
+			return change, nil
+		}
+		change.Action = changeConflict
+		change.Conflicts = 1
+		change.reject = rejectContent(path, base, theirs)
+		change.Reject = path + rejectSuffix
+		return change, nil
+
+	case hasBase && bytes.Equal(current, base):
+		change.Action = changeUpdate
+		change.content = theirs
+		return change, nil
+	}
+
+	// 双方都修改了文件，需要三方合并
+	if diff.IsBinary(base) || diff.IsBinary(theirs) || diff.IsBinary(current) {
+		change.Action = changeConflict
+		change.Conflicts = 1
+		change.reject = theirs
+		change.Reject = path + rejectSuffix
+		return change, nil
+	}
+
+	merged, conflicts, err := mergeContents(filepath.Join(projectDir, native), filepath.Join(baseDir, native), filepath.Join(newDir, native), hasBase, path)
+	if err != nil {
+		return nil, err
+	}
+	if conflicts == 0 {
+		change.Action = changeMerge
+		change.content = merged
+		return change, nil
+	}
+
+	change.Action = changeConflict
+	change.Conflicts = conflicts
+	if conflictStyle == conflictMarkers {
+		change.content = merged
+	} else {
+		change.reject = rejectContent(path, base, theirs)
+		change.Reject = path + rejectSuffix
+	}
+	return change, nil
+}
+
+// mergeContents 调用 git merge-file 合并文件，base 不存在（双方都新增了该文件）时以空文件为基准
+func mergeContents(current, base, theirs string, hasBase bool, path string) ([]byte, int, error) {
+	if !hasBase {
+		empty, err := os.CreateTemp("", "goravel-kit-base-*")
+		if err != nil {
+			return nil, 0, err
+		}
+		empty.Close()
+		defer os.Remove(empty.Name())
+		base = empty.Name()
+	}
+	merged, conflicts, err := utils.MergeFile(current, base, theirs, [3]string{
+		"project/" + path,
+		"template-base/" + path,
+		"template/" + path,
+	})
+	if err == nil && merged == nil {
+		merged = []byte{}
+	}
+	return merged, conflicts, err
+}
+
+// rejectContent 生成记录模板改动的 .rej 内容
+func rejectContent(path string, base, theirs []byte) []byte {
+	bName := "b/" + path
+	if theirs == nil {
+		bName = "/dev/null"
+	}
+	return []byte(diff.Unified("a/"+path, bName, string(base), string(theirs), diff.DefaultContext))
+}
+
+// applyUpgrade 将升级计划写入项目目录
+func applyUpgrade(projectDir string, changes []fileChange) error {
+	for _, change := range changes {
+		target := filepath.Join(projectDir, filepath.FromSlash(change.Path))
+		switch {
+		case change.Action == changeDelete:
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		case change.content != nil:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			mode := change.mode
+			if info, err := os.Stat(target); err == nil {
+				mode = info.Mode().Perm()
+			}
+			if err := os.WriteFile(target, change.content, mode); err != nil {
+				return err
+			}
+		}
+		if change.reject != nil {
+			rejectPath := filepath.Join(projectDir, filepath.FromSlash(change.Reject))
+			if err := os.MkdirAll(filepath.Dir(rejectPath), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(rejectPath, change.reject, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// printUpgradePlan 输出升级计划，dry-run 时附带当前项目到升级结果的差异
+func printUpgradePlan(changes []fileChange, dryRun bool) {
+	if len(changes) == 0 {
+		color.New(color.FgHiGreen).Printf("✅ %s\n", i18n.T("upgrade.no_changes"))
+		return
+	}
+
+	color.New(color.FgHiWhite, color.Bold).Printf("\n📋 %s\n", i18n.T("upgrade.plan", len(changes)))
+	for _, change := range changes {
+		line := fmt.Sprintf("   %-8s %s", change.Action, change.Path)
+		if change.Reject != "" {
+			line += " → " + change.Reject
+		}
+		switch change.Action {
+		case changeConflict:
+			color.New(color.FgHiRed).Println(line)
+		case changeDelete:
+			color.New(color.FgHiYellow).Println(line)
+		default:
+			color.New(color.FgHiGreen).Println(line)
+		}
+	}
+
+	if !dryRun {
+		return
+	}
+	output.Printf("\n")
+	for _, change := range changes {
+		if diff.IsBinary(change.current) || diff.IsBinary(change.content) {
+			output.Printf("%s\n", i18n.T("upgrade.binary", change.Path))
+			continue
+		}
+		if change.Action == changeDelete {
+			output.Printf("%s", diff.Unified("a/"+change.Path, "/dev/null", string(change.current), "", diff.DefaultContext))
+		} else if change.content != nil {
+			aName := "a/" + change.Path
+			if change.current == nil {
+				aName = "/dev/null"
+			}
+			output.Printf("%s", diff.Unified(aName, "b/"+change.Path, string(change.current), string(change.content), diff.DefaultContext))
+		}
+		if change.reject != nil && !diff.IsBinary(change.reject) {
+			output.Printf("%s\n", i18n.T("upgrade.reject_preview", change.Reject))
+			output.Printf("%s", change.reject)
+		}
+	}
+}
+
+// templateFiles 列出目录中参与升级比较的文件（斜杠分隔的相对路径）
+func templateFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if templateIgnored[rel] || !d.Type().IsRegular() {
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// templateIgnored 不参与升级和差异比较的文件：.env 含有生成的密钥，元信息文件由 CLI 维护
+var templateIgnored = map[string]bool{
+	".env":               true,
+	project.MetadataFile: true,
+}
+
+// readOptional 读取文件，文件不存在时返回 exists=false
+func readOptional(path string) ([]byte, bool, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return content, true, nil
+}
+
+// shortSHA 截取提交 SHA 的前 7 位用于显示
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,274 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/config"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+)
+
+// writeTree 在 dir 下按相对路径写入文件
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func readTreeFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+// upgradeFixture 构造旧模板、新模板和当前项目三个目录
+func upgradeFixture(t *testing.T) (string, string, string) {
+	t.Helper()
+	root := t.TempDir()
+	baseDir := filepath.Join(root, "base")
+	newDir := filepath.Join(root, "new")
+	projectDir := filepath.Join(root, "project")
+
+	writeTree(t, baseDir, map[string]string{
+		"main.go":         "package main\n\nfunc main() {}\n",
+		"config/app.go":   "line1\nline2\nline3\nline4\nline5\nline6\nline7\nline8\n",
+		"routes/web.go":   "web\n",
+		"obsolete.go":     "old\n",
+		"customized.go":   "a\n",
+		"locally_gone.go": "x\n",
+		".env":            "APP_KEY=\n",
+	})
+	writeTree(t, newDir, map[string]string{
+		"main.go":         "package main\n\nfunc main() { run() }\n",
+		"config/app.go":   "line1 updated\nline2\nline3\nline4\nline5\nline6\nline7\nline8\n",
+		"routes/web.go":   "web v2\n",
+		"customized.go":   "b\n",
+		"locally_gone.go": "y\n",
+		"added.go":        "new file\n",
+		".env":            "APP_KEY=\nNEW=1\n",
+	})
+	writeTree(t, projectDir, map[string]string{
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"config/app.go": "line1\nline2\nline3\nline4\nline5\nline6\nline7\nline8 local\n",
+		"routes/web.go": "web local\n",
+		"obsolete.go":   "old\n",
+		"customized.go": "a\n",
+		"own.go":        "mine\n",
+		".env":          "APP_KEY=secret\n",
+	})
+	return baseDir, newDir, projectDir
+}
+
+func TestPlanUpgrade_ClassifiesChanges(t *testing.T) {
+	baseDir, newDir, projectDir := upgradeFixture(t)
+
+	changes, err := planUpgrade(baseDir, newDir, projectDir, conflictMarkers)
+	if err != nil {
+		t.Fatalf("planUpgrade failed: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, change := range changes {
+		got[change.Path] = change.Action
+	}
+	want := map[string]string{
+		"added.go":        changeAdd,
+		"config/app.go":   changeMerge,
+		"customized.go":   changeUpdate,
+		"locally_gone.go": changeConflict,
+		"main.go":         changeUpdate,
+		"obsolete.go":     changeDelete,
+		"routes/web.go":   changeConflict,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d changes, got %v", len(want), got)
+	}
+	for path, action := range want {
+		if got[path] != action {
+			t.Fatalf("expected %s to be %s, got %q", path, action, got[path])
+		}
+	}
+}
+
+func TestApplyUpgrade_Markers(t *testing.T) {
+	baseDir, newDir, projectDir := upgradeFixture(t)
+
+	changes, err := planUpgrade(baseDir, newDir, projectDir, conflictMarkers)
+	if err != nil {
+		t.Fatalf("planUpgrade failed: %v", err)
+	}
+	if err := applyUpgrade(projectDir, changes); err != nil {
+		t.Fatalf("applyUpgrade failed: %v", err)
+	}
+
+	if got := readTreeFile(t, projectDir, "config/app.go"); !strings.HasPrefix(got, "line1 updated\n") || !strings.Contains(got, "line8 local\n") {
+		t.Fatalf("expected both changes merged, got: %q", got)
+	}
+	if got := readTreeFile(t, projectDir, "routes/web.go"); !strings.Contains(got, "<<<<<<< project/routes/web.go") || !strings.Contains(got, "web v2") {
+		t.Fatalf("expected conflict markers, got: %q", got)
+	}
+	if got := readTreeFile(t, projectDir, "added.go"); got != "new file\n" {
+		t.Fatalf("expected added file, got: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "obsolete.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected obsolete.go removed, got: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "locally_gone.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected locally deleted file not to be recreated, got: %v", err)
+	}
+	if got := readTreeFile(t, projectDir, "locally_gone.go.rej"); !strings.Contains(got, "+y") {
+		t.Fatalf("expected .rej with template change, got: %q", got)
+	}
+	if got := readTreeFile(t, projectDir, ".env"); got != "APP_KEY=secret\n" {
+		t.Fatalf("expected .env untouched, got: %q", got)
+	}
+	if got := readTreeFile(t, projectDir, "own.go"); got != "mine\n" {
+		t.Fatalf("expected project file untouched, got: %q", got)
+	}
+}
+
+func TestApplyUpgrade_Reject(t *testing.T) {
+	baseDir, newDir, projectDir := upgradeFixture(t)
+
+	changes, err := planUpgrade(baseDir, newDir, projectDir, conflictReject)
+	if err != nil {
+		t.Fatalf("planUpgrade failed: %v", err)
+	}
+	if err := applyUpgrade(projectDir, changes); err != nil {
+		t.Fatalf("applyUpgrade failed: %v", err)
+	}
+
+	if got := readTreeFile(t, projectDir, "routes/web.go"); got != "web local\n" {
+		t.Fatalf("expected conflicting file kept, got: %q", got)
+	}
+	rej := readTreeFile(t, projectDir, "routes/web.go.rej")
+	if !strings.Contains(rej, "-web\n") || !strings.Contains(rej, "+web v2\n") {
+		t.Fatalf("expected template diff in .rej, got: %q", rej)
+	}
+}
+
+// newDriverTemplate 创建使用 goravel 独立驱动包的模板裸仓库，驱动包通过 replace 指向本地的空模块，
+// go mod tidy 不需要网络。第二个提交只修改 main.go，返回裸仓库路径
+func newDriverTemplate(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	stubs := filepath.Join(root, "stubs")
+	writeTree(t, stubs, map[string]string{
+		"framework/go.mod":                      "module github.com/goravel/framework\n\ngo 1.21\n",
+		"framework/facades/facades.go":          "package facades\n",
+		"framework/contracts/foundation/app.go": "package foundation\n",
+		"mysql/go.mod":                          "module github.com/goravel/mysql\n\ngo 1.21\n",
+		"mysql/mysql.go":                        "package mysql\n",
+		"postgres/go.mod":                       "module github.com/goravel/postgres\n\ngo 1.21\n",
+		"postgres/postgres.go":                  "package postgres\n",
+	})
+
+	work := filepath.Join(root, "work")
+	writeTree(t, work, map[string]string{
+		"go.mod": "module goravel\n\ngo 1.21\n\nrequire (\n\tgithub.com/goravel/framework v1.15.2\n\tgithub.com/goravel/mysql v1.3.1\n)\n\nreplace (\n" +
+			"\tgithub.com/goravel/framework => " + filepath.Join(stubs, "framework") + "\n" +
+			"\tgithub.com/goravel/mysql => " + filepath.Join(stubs, "mysql") + "\n" +
+			"\tgithub.com/goravel/postgres => " + filepath.Join(stubs, "postgres") + "\n)\n",
+		"main.go":      "package main\n\nfunc main() {}\n",
+		".env.example": "APP_NAME=Goravel\nDB_CONNECTION=mysql\nDB_HOST=127.0.0.1\nDB_PORT=3306\n",
+		"config/app.go": `package config
+
+import (
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/mysql"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("app", map[string]any{
+		"providers": []foundation.ServiceProvider{
+			&mysql.ServiceProvider{},
+		},
+	})
+}
+`,
+	})
+	runGit(t, work, "init", "-q", "-b", "master")
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "template")
+
+	bare := filepath.Join(root, "goravel-kit.git")
+	runGit(t, root, "clone", "-q", "--bare", work, bare)
+	return bare
+}
+
+func TestPlanUpgrade_DriverProjectKeepsGoMod(t *testing.T) {
+	requireGit(t)
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOWORK", "off")
+	t.Setenv("GOTOOLCHAIN", "local")
+	dir := t.TempDir()
+	shop := filepath.Join(dir, "shop")
+	bare := newDriverTemplate(t)
+
+	out, err := runNew(t, []config.Mirror{{Name: "local", URL: bare}}, "new", "shop", "--dir", dir, "--no-banner", "--db", "postgres")
+	if err != nil {
+		t.Fatalf("new failed: %v\n%s", err, out)
+	}
+	meta, err := project.ReadMetadata(shop)
+	if err != nil {
+		t.Fatalf("ReadMetadata failed: %v", err)
+	}
+	if meta.Database != "postgres" {
+		t.Fatalf("expected database in metadata, got %+v", meta)
+	}
+	if goMod := readTreeFile(t, shop, "go.mod"); !strings.Contains(goMod, "github.com/goravel/postgres v1.3.1") {
+		t.Fatalf("expected postgres driver in go.mod, got:\n%s", goMod)
+	}
+
+	// 模板只修改了与驱动无关的 main.go
+	work := filepath.Join(t.TempDir(), "work")
+	runGit(t, filepath.Dir(work), "clone", "-q", bare, work)
+	writeTree(t, work, map[string]string{"main.go": "package main\n\nfunc main() { println() }\n"})
+	runGit(t, work, "commit", "-q", "-am", "main")
+	runGit(t, work, "push", "-q", "origin", "master")
+
+	baseDir, _, err := project.Prepare(context.Background(), meta.SHA, meta, output.Default(false))
+	if err != nil {
+		t.Fatalf("Prepare base failed: %v", err)
+	}
+	defer os.RemoveAll(baseDir)
+	newDir, _, err := project.Prepare(context.Background(), "master", meta, output.Default(false))
+	if err != nil {
+		t.Fatalf("Prepare new failed: %v", err)
+	}
+	defer os.RemoveAll(newDir)
+
+	// 重建的模板与 new 生成的项目经过同样的 go mod tidy
+	for _, name := range []string{"go.mod", "go.sum"} {
+		want, hasWant, _ := readOptional(filepath.Join(shop, name))
+		got, hasGot, _ := readOptional(filepath.Join(baseDir, name))
+		if hasWant != hasGot || string(want) != string(got) {
+			t.Fatalf("expected rebuilt %s to match the generated project, got %q want %q", name, got, want)
+		}
+	}
+
+	changes, err := planUpgrade(baseDir, newDir, shop, conflictMarkers)
+	if err != nil {
+		t.Fatalf("planUpgrade failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != "main.go" || changes[0].Action != changeUpdate {
+		t.Fatalf("expected only main.go to be updated, got %+v", changes)
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/hooks"
+)
+
+// EnvConfigPath 指定配置文件路径的环境变量
+const EnvConfigPath = "GORAVEL_KIT_CONFIG"
+
+// EnvPluginsDir 指定插件目录的环境变量
+const EnvPluginsDir = "GORAVEL_KIT_PLUGINS_DIR"
+
+// Config goravel-kit-cli 的用户配置
+type Config struct {
+	// Lang 界面语言，例如 en、zh-CN
+	Lang string `json:"lang,omitempty"`
+	// PluginsDir 插件目录，为空时使用配置文件所在目录下的 plugins
+	PluginsDir string `json:"plugins_dir,omitempty"`
+	// Hooks new 命令各生成阶段的钩子，在模板清单中的钩子之前执行
+	Hooks hooks.Hooks `json:"hooks,omitempty"`
+	// Mirrors new 命令依次尝试的模板镜像源，配置后不再探测网络，也不使用内置的 GitHub/Gitee 镜像源
+	Mirrors []Mirror `json:"mirrors,omitempty"`
+	// CopyConcurrency 跨磁盘分区移动项目时同时复制的文件数，为 0 时使用默认值
+	CopyConcurrency int `json:"copy_concurrency,omitempty"`
+}
+
+// Mirror 模板仓库的一个镜像源，URL 可以是 git 支持的任意地址，包括本地路径和 file:// 地址
+type Mirror struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// SSHURL 使用 SSH 协议时的地址，为空时始终使用 URL
+	SSHURL string `json:"ssh_url,omitempty"`
+}
+
+// Path 返回配置文件路径，优先使用 GORAVEL_KIT_CONFIG 环境变量，
+// 否则为用户配置目录下的 goravel-kit-cli/config.json
+func Path() string {
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "goravel-kit-cli", "config.json")
+}
+
+// PluginsDir 返回插件目录，优先级：GORAVEL_KIT_PLUGINS_DIR 环境变量、配置中的 plugins_dir、配置文件所在目录下的 plugins
+func PluginsDir(cfg *Config) string {
+	if dir := os.Getenv(EnvPluginsDir); dir != "" {
+		return dir
+	}
+	if cfg != nil && cfg.PluginsDir != "" {
+		return cfg.PluginsDir
+	}
+	if path := Path(); path != "" {
+		return filepath.Join(filepath.Dir(path), "plugins")
+	}
+	return ""
+}
+
+// Load 读取配置文件，文件不存在时返回空配置
+func Load() (*Config, error) {
+	return LoadFile(Path())
+}
+
+// LoadFile 从指定路径读取配置文件，文件不存在时返回空配置
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+var current = &Config{}
+
+// Current 返回启动时加载的配置
+func Current() *Config {
+	return current
+}
+
+// Set 设置当前生效的配置
+func Set(cfg *Config) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	current = cfg
+}
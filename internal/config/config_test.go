@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile_Missing(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected no error for missing config, got: %v", err)
+	}
+	if cfg.Lang != "" {
+		t.Fatalf("expected empty config, got: %+v", cfg)
+	}
+}
+
+func TestLoadFile_ParsesAndRejectsInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(valid, []byte(`{"lang": "en"}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	cfg, err := LoadFile(valid)
+	if err != nil || cfg.Lang != "en" {
+		t.Fatalf("expected lang=en, got cfg=%+v err=%v", cfg, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{lang`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := LoadFile(invalid); err == nil {
+		t.Fatalf("expected error for invalid config")
+	}
+}
+
+func TestPath_EnvOverride(t *testing.T) {
+	t.Setenv(EnvConfigPath, "/tmp/custom.json")
+	if got := Path(); got != "/tmp/custom.json" {
+		t.Fatalf("expected env override, got %q", got)
+	}
+}
+
+func TestPluginsDir_Precedence(t *testing.T) {
+	t.Setenv(EnvConfigPath, filepath.Join("home", "goravel-kit-cli", "config.json"))
+	t.Setenv(EnvPluginsDir, "")
+
+	if got := PluginsDir(&Config{}); got != filepath.Join("home", "goravel-kit-cli", "plugins") {
+		t.Fatalf("expected plugins dir next to the config file, got %q", got)
+	}
+	if got := PluginsDir(&Config{PluginsDir: "custom"}); got != "custom" {
+		t.Fatalf("expected configured plugins dir, got %q", got)
+	}
+	t.Setenv(EnvPluginsDir, "from-env")
+	if got := PluginsDir(&Config{PluginsDir: "custom"}); got != "from-env" {
+		t.Fatalf("expected environment to take precedence, got %q", got)
+	}
+}
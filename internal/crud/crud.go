@@ -0,0 +1,222 @@
+package crud
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// DefaultRoutePrefix 模板中后台接口的路由前缀
+const DefaultRoutePrefix = "api/admin"
+
+// Options 生成 CRUD 代码的选项
+type Options struct {
+	Resource string
+	Fields   []Field
+	// RoutePrefix 前端请求接口使用的前缀，为空时使用 DefaultRoutePrefix
+	RoutePrefix string
+	// SkipFrontend 不生成前端接口和页面；项目没有 frontend 目录时自动跳过
+	SkipFrontend bool
+	// Force 覆盖已存在的文件
+	Force bool
+	// Now 迁移文件的时间戳，为零值时使用当前时间
+	Now time.Time
+}
+
+// Report 生成结果
+type Report struct {
+	Resource string   `json:"resource"`
+	Files    []string `json:"files"`
+	// Registered 自动注册了路由或迁移的文件
+	Registered []string `json:"registered,omitempty"`
+	// Manual 无法自动注册、需要手动完成的步骤
+	Manual []string `json:"manual,omitempty"`
+}
+
+// data 渲染模板使用的数据
+type data struct {
+	Module             string
+	Names              Names
+	Fields             []Field
+	Migration          string
+	MigrationSignature string
+	Carbon             bool
+	LabelField         string
+	LabelType          string
+	RoutePrefix        string
+	TypeScript         bool
+	RequestImport      string
+}
+
+// output 一个待生成的文件
+type output struct {
+	path     string
+	template string
+	goSource bool
+}
+
+// Generate 按模板 CRUD 生成器的约定，在项目中生成模型、迁移、表单验证、控制器、路由以及前端接口和页面，
+// 并尽量在 routes/api.go 和迁移列表中自动注册
+func Generate(dir string, opts Options) (*Report, error) {
+	names, err := NewNames(opts.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+	mod, err := utils.ReadGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	stamp := now.Format("20060102150405")
+	d := data{
+		Module:             mod.Module,
+		Names:              names,
+		Fields:             opts.Fields,
+		Migration:          "M" + stamp + "Create" + pascal(names.Table) + "Table",
+		MigrationSignature: stamp + "_create_" + names.Table + "_table",
+		LabelField:         "id",
+		LabelType:          "uint",
+		RoutePrefix:        strings.Trim(opts.RoutePrefix, "/"),
+	}
+	if d.RoutePrefix == "" {
+		d.RoutePrefix = DefaultRoutePrefix
+	}
+	for _, field := range opts.Fields {
+		d.Carbon = d.Carbon || strings.HasPrefix(field.GoType, "carbon.")
+		if d.LabelField == "id" && field.GoType == "string" && field.Type != "json" {
+			d.LabelField, d.LabelType = field.Name, "string"
+		}
+	}
+
+	outputs := []output{
+		{path: "app/models/" + names.Snake + ".go", template: "model.go.tmpl", goSource: true},
+		{path: "database/migrations/" + d.MigrationSignature + ".go", template: "migration.go.tmpl", goSource: true},
+		{path: "app/http/requests/" + names.Snake + "_request.go", template: "request.go.tmpl", goSource: true},
+		{path: "app/http/controllers/" + names.Snake + "_controller.go", template: "controller.go.tmpl", goSource: true},
+		{path: "routes/" + names.Snake + ".go", template: "routes.go.tmpl", goSource: true},
+	}
+	frontend := filepath.Join(dir, "frontend")
+	if !opts.SkipFrontend && utils.DirectoryExists(frontend) {
+		d.TypeScript = utils.FileExists(filepath.Join(frontend, "tsconfig.json"))
+		d.RequestImport = RequestImport(frontend)
+		ext := ".js"
+		if d.TypeScript {
+			ext = ".ts"
+		}
+		outputs = append(outputs,
+			output{path: "frontend/src/api/" + names.Snake + ext, template: "api.ts.tmpl"},
+			output{path: "frontend/src/views/" + names.Kebab + "/index.vue", template: "view.vue.tmpl"},
+		)
+	}
+
+	// 先检查冲突再写入，避免生成一半的资源
+	if !opts.Force {
+		for _, out := range outputs {
+			if utils.FileExists(filepath.Join(dir, filepath.FromSlash(out.path))) {
+				return nil, fmt.Errorf("%s already exists (use --force to overwrite)", out.path)
+			}
+		}
+		if existing := existingMigration(dir, names.Table); existing != "" {
+			return nil, fmt.Errorf("migration %s already creates table %s (use --force to generate another)", existing, names.Table)
+		}
+	}
+
+	report := &Report{Resource: names.Model}
+	for _, out := range outputs {
+		content, err := render(out.template, d)
+		if err != nil {
+			return nil, err
+		}
+		if out.goSource {
+			if content, err = format.Source(content); err != nil {
+				return nil, fmt.Errorf("%s: %w", out.path, err)
+			}
+		}
+		target := filepath.Join(dir, filepath.FromSlash(out.path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, err
+		}
+		report.Files = append(report.Files, out.path)
+	}
+
+	registered, err := registerRoutes(dir, names.Model)
+	if err != nil {
+		return nil, err
+	}
+	if registered != "" {
+		report.Registered = append(report.Registered, registered)
+	} else {
+		report.Manual = append(report.Manual, fmt.Sprintf("register routes.%s(router) in your API route group", names.Model))
+	}
+	registered, err = registerList(dir, migrationFiles, "Migration", fmt.Sprintf("&migrations.%s{}", d.Migration), mod.Module+"/database/migrations")
+	if err != nil {
+		return nil, err
+	}
+	if registered != "" {
+		report.Registered = append(report.Registered, registered)
+	} else {
+		report.Manual = append(report.Manual, fmt.Sprintf("add &migrations.%s{} to your migration list", d.Migration))
+	}
+	return report, nil
+}
+
+// render 渲染内置模板
+func render(name string, d data) ([]byte, error) {
+	tmpl, err := parseTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTemplate 解析内置模板，使用 [[ ]] 作为分隔符以保留 Vue 模板中的 {{ }}
+func parseTemplate(name string) (*template.Template, error) {
+	return template.New(name).Delims("[[", "]]").ParseFS(templates, path.Join("templates", name))
+}
+
+// RequestImport 返回前端项目中封装的请求实例，找不到时直接使用 axios
+func RequestImport(frontend string) string {
+	for _, candidate := range []string{"utils/request", "api/request", "utils/http", "api/http"} {
+		for _, ext := range []string{".ts", ".js"} {
+			if utils.FileExists(filepath.Join(frontend, "src", filepath.FromSlash(candidate)+ext)) {
+				return "@/" + candidate
+			}
+		}
+	}
+	return "axios"
+}
+
+// existingMigration 返回已创建同名数据表的迁移文件
+func existingMigration(dir, table string) string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "database", "migrations", "*_create_"+table+"_table.go"))
+	if len(matches) == 0 {
+		return ""
+	}
+	return filepath.Base(matches[0])
+}
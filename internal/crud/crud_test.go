@@ -0,0 +1,383 @@
+package crud
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// frameworkStub 生成代码用到的 goravel 接口的最小实现，用于验证生成的代码可以编译
+var frameworkStub = map[string]string{
+	"fw/go.mod": "module github.com/goravel/framework\n\ngo 1.21\n",
+	"fw/database/orm/orm.go": `package orm
+
+type Model struct{ ID uint }
+`,
+	"fw/support/carbon/carbon.go": `package carbon
+
+type Date struct{}
+
+type DateTime struct{}
+`,
+	"fw/contracts/validation/validation.go": `package validation
+
+type Data interface{}
+
+type Errors interface{ All() map[string]map[string]string }
+`,
+	"fw/contracts/http/http.go": `package http
+
+import "github.com/goravel/framework/contracts/validation"
+
+const (
+	StatusBadRequest          = 400
+	StatusNotFound            = 404
+	StatusUnprocessableEntity = 422
+	StatusInternalServerError = 500
+)
+
+type Json map[string]any
+
+type Response interface{}
+
+type ResponseStatus interface{ Json(obj any) Response }
+
+type ContextResponse interface {
+	Json(code int, obj any) Response
+	Success() ResponseStatus
+	NoContent(code ...int) Response
+}
+
+type FormRequest interface {
+	Authorize(ctx Context) error
+	Rules(ctx Context) map[string]string
+}
+
+type ContextRequest interface {
+	QueryInt(key string, defaultValue ...int) int
+	RouteInt(key string) int
+	ValidateRequest(request FormRequest) (validation.Errors, error)
+}
+
+type Context interface {
+	Request() ContextRequest
+	Response() ContextResponse
+}
+
+type HandlerFunc func(Context) Response
+`,
+	"fw/contracts/route/route.go": `package route
+
+import "github.com/goravel/framework/contracts/http"
+
+type ResourceController interface {
+	Index(http.Context) http.Response
+	Show(http.Context) http.Response
+	Store(http.Context) http.Response
+	Update(http.Context) http.Response
+	Destroy(http.Context) http.Response
+}
+
+type GroupFunc func(Router)
+
+type Router interface {
+	Get(path string, handler http.HandlerFunc)
+	Resource(path string, controller ResourceController)
+	Prefix(prefix string) Router
+	Group(handler GroupFunc)
+}
+`,
+	"fw/contracts/database/schema/schema.go": `package schema
+
+type ColumnDefinition interface{ Nullable() ColumnDefinition }
+
+type Blueprint interface {
+	ID()
+	TimestampsTz()
+	BigInteger(column string) ColumnDefinition
+	Boolean(column string) ColumnDefinition
+	Date(column string) ColumnDefinition
+	DateTimeTz(column string) ColumnDefinition
+	Decimal(column string) ColumnDefinition
+	Double(column string) ColumnDefinition
+	Integer(column string) ColumnDefinition
+	Json(column string) ColumnDefinition
+	String(column string) ColumnDefinition
+	Text(column string) ColumnDefinition
+	UnsignedBigInteger(column string) ColumnDefinition
+}
+
+type Schema interface {
+	HasTable(name string) bool
+	Create(table string, callback func(table Blueprint)) error
+	DropIfExists(table string) error
+}
+
+type Migration interface {
+	Signature() string
+	Up() error
+	Down() error
+}
+`,
+	"fw/contracts/database/orm/orm.go": `package orm
+
+type Result struct{ RowsAffected int64 }
+
+type Query interface {
+	Create(value any) error
+	Delete(value any, conds ...any) (*Result, error)
+	Find(dest any, conds ...any) error
+	FindOrFail(dest any, conds ...any) error
+	FirstOrCreate(dest any, conds ...any) error
+	FirstOrFail(dest any, conds ...any) error
+	Model(value any) Query
+	Order(value any) Query
+	Paginate(page, limit int, dest any, total *int64) error
+	Save(value any) error
+	Scan(dest any) error
+	Select(query any, args ...any) Query
+	Where(query any, args ...any) Query
+}
+
+type Orm interface{ Query() Query }
+`,
+	"fw/contracts/database/seeder/seeder.go": `package seeder
+
+type Seeder interface {
+	Signature() string
+	Run() error
+}
+`,
+	"fw/facades/facades.go": `package facades
+
+import (
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/contracts/route"
+)
+
+func Orm() orm.Orm { return nil }
+
+func Schema() schema.Schema { return nil }
+
+func Route() route.Router { return nil }
+`,
+}
+
+// fakeProject 使用 goravel 接口桩构造的最小项目
+func fakeProject(t *testing.T, extra map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module goravel\n\ngo 1.21\n\nrequire github.com/goravel/framework v0.0.0\n\nreplace github.com/goravel/framework => ./fw\n",
+		"routes/api.go": `package routes
+
+import (
+	"github.com/goravel/framework/contracts/route"
+	"github.com/goravel/framework/facades"
+)
+
+func Api() {
+	facades.Route().Prefix("api").Group(func(api route.Router) {
+		api.Prefix("admin").Group(func(admin route.Router) {
+			admin.Resource("user", nil)
+		})
+	})
+}
+`,
+		"database/kernel.go": `package database
+
+import "github.com/goravel/framework/contracts/database/schema"
+
+type Kernel struct{}
+
+func (kernel Kernel) Migrations() []schema.Migration {
+	return []schema.Migration{}
+}
+`,
+	}
+	for name, content := range frameworkStub {
+		files[name] = content
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func goBuild(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not installed")
+	}
+	cmd := exec.Command("go", "vet", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, out)
+	}
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+var testTime = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+func TestGenerate_CompilesAndRegisters(t *testing.T) {
+	dir := fakeProject(t, map[string]string{
+		"frontend/package.json":         "{}\n",
+		"frontend/tsconfig.json":        "{}\n",
+		"frontend/src/utils/request.ts": "export default {}\n",
+	})
+	fields, err := ParseFields([]string{"title:string,views:int,price:decimal?", "published:bool,published_at:datetime?,body:text"})
+	if err != nil {
+		t.Fatalf("ParseFields failed: %v", err)
+	}
+
+	report, err := Generate(dir, Options{Resource: "BlogPost", Fields: fields, Now: testTime})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	want := []string{
+		"app/models/blog_post.go",
+		"database/migrations/20240506070809_create_blog_posts_table.go",
+		"app/http/requests/blog_post_request.go",
+		"app/http/controllers/blog_post_controller.go",
+		"routes/blog_post.go",
+		"frontend/src/api/blog_post.ts",
+		"frontend/src/views/blog-post/index.vue",
+	}
+	if strings.Join(report.Files, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected files: %v", report.Files)
+	}
+	if strings.Join(report.Registered, ",") != "routes/api.go,database/kernel.go" || len(report.Manual) != 0 {
+		t.Fatalf("unexpected registration: %+v", report)
+	}
+
+	if api := read(t, dir, "routes/api.go"); !strings.Contains(api, "admin.Resource(\"user\", nil)\n\t\t\tBlogPost(admin)\n") {
+		t.Fatalf("expected routes registered in admin group:\n%s", api)
+	}
+	kernel := read(t, dir, "database/kernel.go")
+	if !strings.Contains(kernel, "&migrations.M20240506070809CreateBlogPostsTable{},") || !strings.Contains(kernel, `"goravel/database/migrations"`) {
+		t.Fatalf("expected migration registered:\n%s", kernel)
+	}
+	migration := read(t, dir, "database/migrations/20240506070809_create_blog_posts_table.go")
+	for _, want := range []string{`table.Decimal("price").Nullable()`, `table.DateTimeTz("published_at").Nullable()`, `return "20240506070809_create_blog_posts_table"`} {
+		if !strings.Contains(migration, want) {
+			t.Fatalf("expected %q in migration:\n%s", want, migration)
+		}
+	}
+	if request := read(t, dir, "app/http/requests/blog_post_request.go"); !strings.Contains(request, `"title":        "required|string"`) || !strings.Contains(request, `"price":        "float"`) {
+		t.Fatalf("unexpected rules:\n%s", request)
+	}
+	if controller := read(t, dir, "app/http/controllers/blog_post_controller.go"); !strings.Contains(controller, `Select("title as label", "id as value")`) {
+		t.Fatalf("expected title used as option label:\n%s", controller)
+	}
+
+	client := read(t, dir, "frontend/src/api/blog_post.ts")
+	for _, want := range []string{"import request from '@/utils/request'", "const base = '/api/admin/blog_post'", "price?: number", "export function updateBlogPost(id: number, data: BlogPostForm)"} {
+		if !strings.Contains(client, want) {
+			t.Fatalf("expected %q in API client:\n%s", want, client)
+		}
+	}
+	view := read(t, dir, "frontend/src/views/blog-post/index.vue")
+	for _, want := range []string{`<script setup lang="ts">`, `<a-switch v-model:checked="form.published" />`, `from '@/api/blog_post'`, "{ column, record }"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	// 再次生成时拒绝覆盖，且不修改任何文件
+	if _, err := Generate(dir, Options{Resource: "blog_post", Fields: fields, Now: testTime.Add(time.Hour)}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected conflict error, got: %v", err)
+	}
+}
+
+func TestGenerate_BackendOnlyWithoutRouteGroup(t *testing.T) {
+	dir := fakeProject(t, map[string]string{
+		"routes/api.go":      "package routes\n\nfunc Api() {\n}\n",
+		"database/kernel.go": "package database\n",
+	})
+	fields, err := ParseFields([]string{"name"})
+	if err != nil {
+		t.Fatalf("ParseFields failed: %v", err)
+	}
+	report, err := Generate(dir, Options{Resource: "category", Fields: fields, Now: testTime})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	if len(report.Files) != 5 {
+		t.Fatalf("expected backend files only, got %v", report.Files)
+	}
+	if api := read(t, dir, "routes/api.go"); !strings.Contains(api, `facades.Route().Prefix("api/admin").Group(Category)`) {
+		t.Fatalf("expected new route group:\n%s", api)
+	}
+	if len(report.Manual) != 1 || !strings.Contains(report.Manual[0], "M20240506070809CreateCategoriesTable") {
+		t.Fatalf("expected manual migration step, got %v", report.Manual)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields([]string{"Name:string, age:integer ,avatarURL:text?"})
+	if err != nil {
+		t.Fatalf("ParseFields failed: %v", err)
+	}
+	if len(fields) != 3 || fields[1].Type != "int" || fields[2].Name != "avatar_url" || !fields[2].Nullable || fields[2].GoName() != "AvatarURL" {
+		t.Fatalf("unexpected fields: %+v", fields)
+	}
+
+	for spec, want := range map[string]string{
+		"":            "at least one field",
+		"id:int":      "generated automatically",
+		"a:int,a:int": "duplicate",
+		"a:money":     "unsupported type",
+		"1a:int":      "invalid field name",
+	} {
+		if _, err := ParseFields([]string{spec}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("ParseFields(%q): expected %q error, got %v", spec, want, err)
+		}
+	}
+}
+
+func TestNewNames(t *testing.T) {
+	for resource, want := range map[string]Names{
+		"BlogPost":  {Model: "BlogPost", Var: "blogPost", Snake: "blog_post", Table: "blog_posts", Kebab: "blog-post"},
+		"file-cate": {Model: "FileCate", Var: "fileCate", Snake: "file_cate", Table: "file_cates", Kebab: "file-cate"},
+		"category":  {Model: "Category", Var: "category", Snake: "category", Table: "categories", Kebab: "category"},
+		"HTTPLog":   {Model: "HTTPLog", Var: "hTTPLog", Snake: "http_log", Table: "http_logs", Kebab: "http-log"},
+		"box":       {Model: "Box", Var: "box", Snake: "box", Table: "boxes", Kebab: "box"},
+	} {
+		got, err := NewNames(resource)
+		if err != nil {
+			t.Fatalf("NewNames(%q) failed: %v", resource, err)
+		}
+		if got != want {
+			t.Fatalf("NewNames(%q) = %+v, want %+v", resource, got, want)
+		}
+	}
+	if _, err := NewNames("../x"); err == nil {
+		t.Fatalf("expected invalid resource name error")
+	}
+}
@@ -0,0 +1,149 @@
+package crud
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// fieldType 字段类型在模型、迁移、表单验证和前端表单中的对应写法
+type fieldType struct {
+	GoType string
+	// Column schema.Blueprint 中创建列的方法
+	Column string
+	// Rule goravel 表单验证规则
+	Rule string
+	// TSType 前端接口中的类型
+	TSType string
+	// Input 前端表单控件
+	Input string
+}
+
+// fieldTypes 支持的字段类型，别名指向同一种写法
+var fieldTypes = map[string]fieldType{
+	"string":   {GoType: "string", Column: "String", Rule: "string", TSType: "string", Input: "input"},
+	"text":     {GoType: "string", Column: "Text", Rule: "string", TSType: "string", Input: "textarea"},
+	"int":      {GoType: "int", Column: "Integer", Rule: "int", TSType: "number", Input: "number"},
+	"bigint":   {GoType: "int64", Column: "BigInteger", Rule: "int", TSType: "number", Input: "number"},
+	"uint":     {GoType: "uint", Column: "UnsignedBigInteger", Rule: "uint", TSType: "number", Input: "number"},
+	"float":    {GoType: "float64", Column: "Double", Rule: "float", TSType: "number", Input: "number"},
+	"decimal":  {GoType: "float64", Column: "Decimal", Rule: "float", TSType: "number", Input: "number"},
+	"bool":     {GoType: "bool", Column: "Boolean", Rule: "bool", TSType: "boolean", Input: "switch"},
+	"date":     {GoType: "carbon.Date", Column: "Date", Rule: "date", TSType: "string", Input: "date"},
+	"datetime": {GoType: "carbon.DateTime", Column: "DateTimeTz", Rule: "date", TSType: "string", Input: "datetime"},
+	"json":     {GoType: "string", Column: "Json", Rule: "json", TSType: "string", Input: "textarea"},
+}
+
+var fieldAliases = map[string]string{
+	"integer":   "int",
+	"boolean":   "bool",
+	"double":    "float",
+	"timestamp": "datetime",
+	"time":      "datetime",
+	"uint64":    "uint",
+	"int64":     "bigint",
+}
+
+// Field 资源的一个字段，由 name:type 描述，类型后加 ? 表示可为空且非必填
+type Field struct {
+	Name     string
+	Type     string
+	Nullable bool
+	fieldType
+}
+
+var fieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// reservedFields 由 orm.Model 和迁移自动生成的字段
+var reservedFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// ParseFields 解析 --fields 参数，如 name:string,age:int,remark:text?
+func ParseFields(specs []string) ([]Field, error) {
+	var fields []Field
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		for _, item := range strings.Split(spec, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			name, typ, found := strings.Cut(item, ":")
+			if !found {
+				typ = "string"
+			}
+			name = snake(strings.TrimSpace(name))
+			typ = strings.ToLower(strings.TrimSpace(typ))
+			nullable := strings.HasSuffix(typ, "?")
+			typ = strings.TrimSuffix(typ, "?")
+			if alias, ok := fieldAliases[typ]; ok {
+				typ = alias
+			}
+
+			ft, ok := fieldTypes[typ]
+			switch {
+			case !fieldName.MatchString(name):
+				return nil, fmt.Errorf("invalid field name %q", item)
+			case reservedFields[name]:
+				return nil, fmt.Errorf("field %q is generated automatically", name)
+			case seen[name]:
+				return nil, fmt.Errorf("duplicate field %q", name)
+			case !ok:
+				return nil, fmt.Errorf("unsupported type %q for field %q (%s)", typ, name, strings.Join(FieldTypes(), ", "))
+			}
+			seen[name] = true
+			fields = append(fields, Field{Name: name, Type: typ, Nullable: nullable, fieldType: ft})
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required, e.g. --fields name:string,age:int")
+	}
+	return fields, nil
+}
+
+// FieldTypes 返回支持的字段类型
+func FieldTypes() []string {
+	types := make([]string, 0, len(fieldTypes))
+	for typ := range fieldTypes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// GoName 字段在 Go 结构体中的名称
+func (f Field) GoName() string {
+	return pascal(f.Name)
+}
+
+// GoFieldType 字段在 Go 结构体中的类型，可为空的字段使用指针
+func (f Field) GoFieldType() string {
+	if f.Nullable {
+		return "*" + f.GoType
+	}
+	return f.GoType
+}
+
+// Required 字段是否必填；布尔字段的 false 会被 required 规则视为空值，因此不设为必填
+func (f Field) Required() bool {
+	return !f.Nullable && f.Type != "bool"
+}
+
+// Rules 字段的表单验证规则
+func (f Field) Rules() string {
+	if !f.Required() {
+		return f.Rule
+	}
+	return "required|" + f.Rule
+}
+
+// Label 前端表单中显示的字段名
+func (f Field) Label() string {
+	var words []string
+	for _, word := range strings.Split(f.Name, "_") {
+		if word != "" {
+			words = append(words, strings.ToUpper(word[:1])+word[1:])
+		}
+	}
+	return strings.Join(words, " ")
+}
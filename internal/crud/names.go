@@ -0,0 +1,95 @@
+package crud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Names 资源在各处使用的名称，以 BlogPost 为例
+type Names struct {
+	// Model 模型和控制器前缀：BlogPost
+	Model string
+	// Var 变量名：blogPost
+	Var string
+	// Snake 文件名和路由：blog_post
+	Snake string
+	// Table 数据表：blog_posts
+	Table string
+	// Kebab 前端视图目录：blog-post
+	Kebab string
+}
+
+var resourceName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// NewNames 根据资源名称（Article、blog_post、blog-post 等）生成各处使用的名称
+func NewNames(resource string) (Names, error) {
+	if !resourceName.MatchString(resource) {
+		return Names{}, fmt.Errorf("invalid resource name %q", resource)
+	}
+	s := snake(resource)
+	model := pascal(s)
+	return Names{
+		Model: model,
+		Var:   strings.ToLower(model[:1]) + model[1:],
+		Snake: s,
+		Table: plural(s),
+		Kebab: strings.ReplaceAll(s, "_", "-"),
+	}, nil
+}
+
+// snake 将 BlogPost、blog-post 转换为 blog_post
+func snake(name string) string {
+	var b strings.Builder
+	runes := []rune(strings.ReplaceAll(name, "-", "_"))
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// 连续大写（如 HTTPServer）只在单词边界处分隔
+			if i > 0 && runes[i-1] != '_' && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+// pascal 将 blog_post 转换为 BlogPost，常见缩写全部大写
+func pascal(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// initialisms 按 Go 命名习惯全部大写的单词
+var initialisms = map[string]bool{"id": true, "url": true, "api": true, "ip": true, "uuid": true, "html": true, "json": true, "http": true}
+
+// plural 英文名词复数，覆盖数据表名的常见情况
+func plural(name string) string {
+	prefix, word := "", name
+	if i := strings.LastIndex(name, "_"); i >= 0 {
+		prefix, word = name[:i+1], name[i+1:]
+	}
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		word += "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		word = word[:len(word)-1] + "ies"
+	default:
+		word += "s"
+	}
+	return prefix + word
+}
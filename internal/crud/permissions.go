@@ -0,0 +1,396 @@
+package crud
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// PermissionActions 默认生成的权限动作，与 make:crud 生成的资源路由对应
+var PermissionActions = []string{"index", "show", "store", "update", "destroy"}
+
+// DefaultPermissionCode 权限标识的默认格式
+const DefaultPermissionCode = "{resource}.{action}"
+
+// actionRoutes 动作对应的接口方法和路径后缀，与 make:crud 生成的路由保持一致
+var actionRoutes = map[string]struct{ method, suffix string }{
+	"index":   {"GET", ""},
+	"list":    {"GET", "/list"},
+	"option":  {"GET", "/option"},
+	"show":    {"GET", "/{id}"},
+	"store":   {"POST", ""},
+	"update":  {"PUT", "/{id}"},
+	"destroy": {"DELETE", "/{id}"},
+}
+
+// PermissionOptions 生成权限和菜单种子数据的选项
+type PermissionOptions struct {
+	Resource string
+	// Title 菜单和权限名称中显示的资源名称，为空时使用模型名
+	Title string
+	// Actions 生成权限的动作，为空时使用 PermissionActions
+	Actions []string
+	// Code 权限标识格式，支持 {resource}（蛇形资源名）和 {action}，为空时使用 DefaultPermissionCode
+	Code string
+	// Icon 菜单图标
+	Icon string
+	// Parent 上级菜单的路由路径，为空时创建顶级菜单
+	Parent string
+	// RoutePrefix 权限对应接口的前缀，为空时使用 DefaultRoutePrefix
+	RoutePrefix string
+	// Label 返回权限名称，为空时使用 "资源名 动作"
+	Label func(title, action string) string
+	// Force 覆盖已存在的种子文件
+	Force bool
+}
+
+// PermissionReport 生成结果
+type PermissionReport struct {
+	Resource    string   `json:"resource"`
+	Seeder      string   `json:"seeder"`
+	File        string   `json:"file"`
+	Menu        string   `json:"menu,omitempty"`
+	Permissions []string `json:"permissions"`
+	// Registered 自动注册了种子的文件
+	Registered []string `json:"registered,omitempty"`
+	// Manual 无法自动完成、需要手动处理的步骤
+	Manual []string `json:"manual,omitempty"`
+}
+
+// seederFiles 不同 goravel 版本登记种子列表的文件
+var seederFiles = []string{"bootstrap/seeders.go", "database/kernel.go"}
+
+var actionName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// model 项目中模型结构体的字段，键为字段名
+type model map[string]modelField
+
+type modelField struct {
+	Type   string
+	Column string
+}
+
+// pick 返回候选字段中模型实际拥有的第一个
+func (m model) pick(candidates ...string) string {
+	for _, name := range candidates {
+		if _, ok := m[name]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// 种子数据各项对应的候选字段名，按顺序匹配项目中模型的字段
+var (
+	menuTitleFields      = []string{"Name", "Title", "Label", "MenuName"}
+	menuPathFields       = []string{"Path", "Route", "Url", "URL"}
+	menuComponentFields  = []string{"Component"}
+	menuIconFields       = []string{"Icon"}
+	menuParentFields     = []string{"ParentID", "ParentId", "Pid"}
+	permissionCodeFields = []string{"Code", "Slug", "Key", "Identifier", "Permission"}
+	permissionNameFields = []string{"Name", "Title", "Label", "DisplayName"}
+	permissionMethods    = []string{"Method", "HttpMethod"}
+	permissionPaths      = []string{"Path", "Api", "Url", "URL", "Route"}
+	permissionMenuFields = []string{"MenuID", "MenuId"}
+)
+
+// permissionData 渲染种子模板使用的数据
+type permissionData struct {
+	Module      string
+	Seeder      string
+	Title       string
+	Menu        bool
+	Parent      string
+	ParentCond  string
+	ParentField string
+	MenuValues  string
+	MenuCond    string
+	Permissions []string
+	PermCond    string
+}
+
+// GeneratePermissions 根据资源推导增删改查权限标识和菜单，按项目中 Permission/Menu 模型的字段生成
+// database/seeders 下可重复执行的种子，并尽量注册到种子列表
+func GeneratePermissions(dir string, opts PermissionOptions) (*PermissionReport, error) {
+	names, err := NewNames(opts.Resource)
+	if err != nil {
+		return nil, err
+	}
+	mod, err := utils.ReadGoMod(dir)
+	if err != nil {
+		return nil, err
+	}
+	actions := opts.Actions
+	if len(actions) == 0 {
+		actions = PermissionActions
+	}
+	seen := map[string]bool{}
+	for _, action := range actions {
+		if !actionName.MatchString(action) {
+			return nil, fmt.Errorf("invalid action %q", action)
+		}
+		if seen[action] {
+			return nil, fmt.Errorf("duplicate action %q", action)
+		}
+		seen[action] = true
+	}
+	code := opts.Code
+	if code == "" {
+		code = DefaultPermissionCode
+	}
+	if !strings.Contains(code, "{action}") {
+		return nil, fmt.Errorf("permission code format %q must contain {action}", code)
+	}
+	title := opts.Title
+	if title == "" {
+		title = names.Model
+	}
+	label := opts.Label
+	if label == nil {
+		label = func(title, action string) string { return title + " " + action }
+	}
+	prefix := strings.Trim(opts.RoutePrefix, "/")
+	if prefix == "" {
+		prefix = DefaultRoutePrefix
+	}
+
+	seeder := names.Model + "PermissionSeeder"
+	rel := "database/seeders/" + names.Snake + "_permission_seeder.go"
+	target := filepath.Join(dir, filepath.FromSlash(rel))
+	if utils.FileExists(target) && !opts.Force {
+		return nil, fmt.Errorf("%s already exists (use --force to overwrite)", rel)
+	}
+
+	report := &PermissionReport{Resource: names.Model, Seeder: seeder, File: rel}
+	permission, err := findModel(dir, "Permission")
+	if err != nil {
+		return nil, err
+	}
+	if permission == nil {
+		return nil, fmt.Errorf("model Permission not found in app/models")
+	}
+	menu, err := findModel(dir, "Menu")
+	if err != nil {
+		return nil, err
+	}
+
+	d := permissionData{Module: mod.Module, Seeder: seeder, Title: title, Parent: strings.TrimSpace(opts.Parent)}
+	if menu != nil {
+		menuPath := "/" + names.Kebab
+		values := []string{}
+		set := func(candidates []string, value string) string {
+			field := menu.pick(candidates...)
+			if field != "" && value != "" && menu[field].Type == "string" {
+				values = append(values, fmt.Sprintf("%s: %s", field, strconv.Quote(value)))
+			}
+			return field
+		}
+		set(menuTitleFields, title)
+		pathField := set(menuPathFields, menuPath)
+		set(menuComponentFields, names.Kebab+"/index")
+		set(menuIconFields, opts.Icon)
+		d.Menu = true
+		d.MenuValues = strings.Join(values, ", ")
+
+		key, value := pathField, menuPath
+		if key == "" {
+			key, value = menu.pick(menuTitleFields...), title
+		}
+		if key == "" {
+			return nil, fmt.Errorf("model Menu has none of the fields %s", strings.Join(append(menuPathFields, menuTitleFields...), ", "))
+		}
+		d.MenuCond = fmt.Sprintf("Where(%q, %s)", menu[key].Column, strconv.Quote(value))
+		report.Menu = value
+
+		if d.Parent != "" {
+			parentField := menu.pick(menuParentFields...)
+			switch {
+			case pathField == "":
+				report.Manual = append(report.Manual, "model Menu has no path field; set the parent menu manually")
+				d.Parent = ""
+			case parentField == "":
+				report.Manual = append(report.Manual, "model Menu has no parent field; set the parent menu manually")
+				d.Parent = ""
+			default:
+				id, ok := convertID(menu[parentField].Type, "parent.ID")
+				if !ok {
+					report.Manual = append(report.Manual, fmt.Sprintf("set Menu.%s to the parent menu manually", parentField))
+					d.Parent = ""
+					break
+				}
+				d.ParentCond = fmt.Sprintf("Where(%q, %s)", menu[pathField].Column, strconv.Quote("/"+strings.Trim(d.Parent, "/")))
+				d.ParentField = fmt.Sprintf("menu.%s = %s", parentField, id)
+			}
+		}
+	} else {
+		report.Manual = append(report.Manual, "model Menu not found in app/models; add the menu entry manually")
+	}
+
+	codeField := permission.pick(permissionCodeFields...)
+	nameField := permission.pick(permissionNameFields...)
+	if codeField == "" {
+		// 没有单独的标识字段时，标识写入名称字段
+		codeField, nameField = nameField, ""
+	}
+	if codeField == "" {
+		return nil, fmt.Errorf("model Permission has none of the fields %s", strings.Join(append(permissionCodeFields, permissionNameFields...), ", "))
+	}
+	methodField := permission.pick(permissionMethods...)
+	pathField := permission.pick(permissionPaths...)
+	menuField := ""
+	if d.Menu {
+		menuField = permission.pick(permissionMenuFields...)
+	}
+	d.PermCond = fmt.Sprintf("Where(%q, permission.%s)", permission[codeField].Column, codeField)
+
+	for _, action := range actions {
+		value := strings.NewReplacer("{resource}", names.Snake, "{action}", action).Replace(code)
+		report.Permissions = append(report.Permissions, value)
+		values := []string{fmt.Sprintf("%s: %s", codeField, strconv.Quote(value))}
+		if nameField != "" {
+			values = append(values, fmt.Sprintf("%s: %s", nameField, strconv.Quote(label(title, action))))
+		}
+		if route, ok := actionRoutes[action]; ok {
+			if methodField != "" && permission[methodField].Type == "string" {
+				values = append(values, fmt.Sprintf("%s: %q", methodField, route.method))
+			}
+			if pathField != "" && permission[pathField].Type == "string" {
+				values = append(values, fmt.Sprintf("%s: %q", pathField, "/"+prefix+"/"+names.Snake+route.suffix))
+			}
+		}
+		if id, ok := convertID(permission[menuField].Type, "menu.ID"); menuField != "" && ok {
+			values = append(values, fmt.Sprintf("%s: %s", menuField, id))
+		}
+		d.Permissions = append(d.Permissions, "{"+strings.Join(values, ", ")+"}")
+	}
+
+	content, err := renderPermissions(d)
+	if err != nil {
+		return nil, err
+	}
+	if content, err = format.Source(content); err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return nil, err
+	}
+
+	registered, err := registerList(dir, seederFiles, "Seeder", fmt.Sprintf("&seeders.%s{}", seeder), mod.Module+"/database/seeders")
+	if err != nil {
+		return nil, err
+	}
+	if registered != "" {
+		report.Registered = append(report.Registered, registered)
+	} else {
+		report.Manual = append(report.Manual, fmt.Sprintf("add &seeders.%s{} to your seeder list", seeder))
+	}
+	return report, nil
+}
+
+func renderPermissions(d permissionData) ([]byte, error) {
+	tmpl, err := parseTemplate("seeder.go.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// convertID 将 uint 类型的主键转换为关联字段的类型，无法转换时返回 false
+func convertID(typ, id string) (string, bool) {
+	switch typ {
+	case "uint":
+		return id, true
+	case "*uint":
+		return "&" + id, true
+	case "int", "int32", "int64", "uint32", "uint64":
+		return typ + "(" + id + ")", true
+	}
+	return "", false
+}
+
+// findModel 在 app/models 中查找指定名称的结构体，返回其字段（含嵌入结构体以外的全部具名字段）
+func findModel(dir, name string) (model, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "app", "models", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Name.Name != name {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				fields := model{}
+				for _, field := range st.Fields.List {
+					typ := typeString(field.Type)
+					for _, ident := range field.Names {
+						fields[ident.Name] = modelField{Type: typ, Column: column(ident.Name, field.Tag)}
+					}
+				}
+				return fields, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// column 返回字段对应的数据库列名，优先使用 gorm 标签中的 column
+func column(name string, tag *ast.BasicLit) string {
+	if tag != nil {
+		if value, err := strconv.Unquote(tag.Value); err == nil {
+			for _, part := range strings.Split(reflect.StructTag(value).Get("gorm"), ";") {
+				if col, ok := strings.CutPrefix(strings.TrimSpace(part), "column:"); ok {
+					return col
+				}
+			}
+		}
+	}
+	return snake(name)
+}
+
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	}
+	return ""
+}
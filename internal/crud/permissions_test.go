@@ -0,0 +1,150 @@
+package crud
+
+import (
+	"strings"
+	"testing"
+)
+
+const permissionModels = `package models
+
+import "github.com/goravel/framework/database/orm"
+
+type Permission struct {
+	orm.Model
+	Name   string
+	Code   string ` + "`gorm:\"column:code;uniqueIndex\"`" + `
+	Method string
+	Path   string
+	MenuID int64
+}
+
+type Menu struct {
+	orm.Model
+	Title     string
+	Path      string
+	Component string
+	Icon      string
+	ParentID  uint
+}
+`
+
+const seederKernel = `package database
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/contracts/database/seeder"
+)
+
+type Kernel struct{}
+
+func (kernel Kernel) Migrations() []schema.Migration {
+	return []schema.Migration{}
+}
+
+func (kernel Kernel) Seeders() []seeder.Seeder {
+	return []seeder.Seeder{}
+}
+`
+
+func TestGeneratePermissions_CompilesAndRegisters(t *testing.T) {
+	dir := fakeProject(t, map[string]string{
+		"app/models/rbac.go": permissionModels,
+		"database/kernel.go": seederKernel,
+	})
+
+	report, err := GeneratePermissions(dir, PermissionOptions{
+		Resource: "BlogPost",
+		Title:    "文章",
+		Actions:  []string{"index", "store", "export"},
+		Code:     "{resource}:{action}",
+		Icon:     "FileTextOutlined",
+		Parent:   "content",
+		Label:    func(title, action string) string { return title + "-" + action },
+	})
+	if err != nil {
+		t.Fatalf("GeneratePermissions failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	if report.File != "database/seeders/blog_post_permission_seeder.go" || report.Seeder != "BlogPostPermissionSeeder" || report.Menu != "/blog-post" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if strings.Join(report.Permissions, ",") != "blog_post:index,blog_post:store,blog_post:export" {
+		t.Fatalf("unexpected permissions: %v", report.Permissions)
+	}
+	if strings.Join(report.Registered, ",") != "database/kernel.go" || len(report.Manual) != 0 {
+		t.Fatalf("unexpected registration: %+v", report)
+	}
+
+	seeder := read(t, dir, report.File)
+	for _, want := range []string{
+		`menu := models.Menu{Title: "文章", Path: "/blog-post", Component: "blog-post/index", Icon: "FileTextOutlined"}`,
+		`facades.Orm().Query().Where("path", "/content").FirstOrFail(&parent)`,
+		"menu.ParentID = parent.ID",
+		`facades.Orm().Query().Where("path", "/blog-post").FirstOrCreate(&menu)`,
+		`{Code: "blog_post:index", Name: "文章-index", Method: "GET", Path: "/api/admin/blog_post", MenuID: int64(menu.ID)},`,
+		`{Code: "blog_post:store", Name: "文章-store", Method: "POST", Path: "/api/admin/blog_post", MenuID: int64(menu.ID)},`,
+		// 没有对应路由的自定义动作只写入标识和名称
+		`{Code: "blog_post:export", Name: "文章-export", MenuID: int64(menu.ID)},`,
+		`facades.Orm().Query().Where("code", permission.Code).FirstOrCreate(&permission)`,
+		`return "BlogPostPermissionSeeder"`,
+	} {
+		if !strings.Contains(seeder, want) {
+			t.Fatalf("expected %q in seeder:\n%s", want, seeder)
+		}
+	}
+	kernel := read(t, dir, "database/kernel.go")
+	if !strings.Contains(kernel, "&seeders.BlogPostPermissionSeeder{},") || !strings.Contains(kernel, `"goravel/database/seeders"`) {
+		t.Fatalf("expected seeder registered:\n%s", kernel)
+	}
+
+	if _, err := GeneratePermissions(dir, PermissionOptions{Resource: "BlogPost"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func TestGeneratePermissions_MinimalModels(t *testing.T) {
+	dir := fakeProject(t, map[string]string{
+		"app/models/permission.go": `package models
+
+import "github.com/goravel/framework/database/orm"
+
+type Permission struct {
+	orm.Model
+	Name string
+}
+`,
+	})
+
+	report, err := GeneratePermissions(dir, PermissionOptions{Resource: "tag", Parent: "system"})
+	if err != nil {
+		t.Fatalf("GeneratePermissions failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	seeder := read(t, dir, report.File)
+	if !strings.Contains(seeder, `{Name: "tag.destroy"},`) || strings.Contains(seeder, "models.Menu") {
+		t.Fatalf("expected permissions keyed by name without menu:\n%s", seeder)
+	}
+	if len(report.Registered) != 0 || len(report.Manual) != 2 || report.Menu != "" {
+		t.Fatalf("expected manual menu and seeder registration steps: %+v", report)
+	}
+}
+
+func TestGeneratePermissions_Errors(t *testing.T) {
+	dir := fakeProject(t, nil)
+	if _, err := GeneratePermissions(dir, PermissionOptions{Resource: "Tag"}); err == nil || !strings.Contains(err.Error(), "model Permission not found") {
+		t.Fatalf("expected missing model error, got %v", err)
+	}
+
+	dir = fakeProject(t, map[string]string{"app/models/rbac.go": permissionModels})
+	for _, opts := range []PermissionOptions{
+		{Resource: "Tag", Actions: []string{"Index"}},
+		{Resource: "Tag", Actions: []string{"index", "index"}},
+		{Resource: "Tag", Code: "tag"},
+	} {
+		if _, err := GeneratePermissions(dir, opts); err == nil {
+			t.Fatalf("expected error for %+v", opts)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package crud
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/goedit"
+)
+
+// routeFiles 可能包含后台接口路由的文件
+var routeFiles = []string{"routes/api.go", "routes/admin.go"}
+
+// migrationFiles 不同 goravel 版本登记迁移列表的文件
+var migrationFiles = []string{"bootstrap/migrations.go", "database/kernel.go"}
+
+// registerRoutes 在路由文件中调用生成的路由注册函数，返回修改的文件。
+// 优先加入已注册资源路由的 route.Router 分组，没有分组时在 Api 函数中新建 DefaultRoutePrefix 分组
+func registerRoutes(dir, name string) (string, error) {
+	for _, rel := range routeFiles {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		content, fset, file, err := goedit.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		if file == nil {
+			continue
+		}
+		if calls(file, name) {
+			return rel, nil
+		}
+
+		var edits []goedit.Edit
+		if group, param := routeGroup(file); group != nil {
+			edits = append(edits, insertStatement(fset, content, group.Body, fmt.Sprintf("%s(%s)", name, param)))
+		} else if fn := funcDecl(file, "Api"); fn != nil {
+			edits = append(edits, insertStatement(fset, content, fn.Body, fmt.Sprintf("facades.Route().Prefix(%q).Group(%s)", DefaultRoutePrefix, name)))
+			if !goedit.HasImport(file, "github.com/goravel/framework/facades") {
+				edits = append(edits, goedit.ImportEdit(fset, file, "", "github.com/goravel/framework/facades"))
+			}
+		} else {
+			continue
+		}
+		if _, err := goedit.Write(path, content, edits); err != nil {
+			return "", err
+		}
+		return rel, nil
+	}
+	return "", nil
+}
+
+// routeGroup 查找参数类型为 route.Router 且注册了资源路由的分组函数，取最内层的一个
+func routeGroup(file *ast.File) (*ast.FuncLit, string) {
+	var group, fallback *ast.FuncLit
+	var groupParam, fallbackParam string
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.FuncLit)
+		if !ok || len(lit.Type.Params.List) != 1 || len(lit.Type.Params.List[0].Names) != 1 {
+			return true
+		}
+		param := lit.Type.Params.List[0]
+		sel, ok := param.Type.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Router" {
+			return true
+		}
+		if fallback == nil {
+			fallback, fallbackParam = lit, param.Names[0].Name
+		}
+		if registersResource(lit.Body) {
+			group, groupParam = lit, param.Names[0].Name
+		}
+		return true
+	})
+	if group != nil {
+		return group, groupParam
+	}
+	return fallback, fallbackParam
+}
+
+// registersResource 判断函数体中是否直接调用了 Resource 注册路由
+func registersResource(body *ast.BlockStmt) bool {
+	for _, stmt := range body.List {
+		expr, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		if call, ok := expr.X.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Resource" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// registerList 将元素加入 []schema.Migration、[]seeder.Seeder 等列表并导入所在的包，返回修改的文件
+func registerList(dir string, files []string, elementType, element, importPath string) (string, error) {
+	for _, rel := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		content, fset, file, err := goedit.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		if file == nil {
+			continue
+		}
+
+		var list *ast.CompositeLit
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok || list != nil {
+				return true
+			}
+			if array, ok := lit.Type.(*ast.ArrayType); ok {
+				if sel, ok := array.Elt.(*ast.SelectorExpr); ok && sel.Sel.Name == elementType {
+					list = lit
+				}
+			}
+			return true
+		})
+		if list == nil {
+			continue
+		}
+
+		edits := []goedit.Edit{appendElement(fset, content, list, element)}
+		if !goedit.HasImport(file, importPath) {
+			edits = append(edits, goedit.ImportEdit(fset, file, "", importPath))
+		}
+		if _, err := goedit.Write(path, content, edits); err != nil {
+			return "", err
+		}
+		return rel, nil
+	}
+	return "", nil
+}
+
+// insertStatement 在代码块末尾追加一条语句，右花括号与上一条语句同行时先换行
+func insertStatement(fset *token.FileSet, content []byte, body *ast.BlockStmt, stmt string) goedit.Edit {
+	offset := fset.Position(body.Rbrace).Offset
+	last := body.Lbrace + 1
+	if n := len(body.List); n > 0 {
+		last = body.List[n-1].End()
+	}
+	text := stmt + "\n"
+	if !strings.Contains(string(content[fset.Position(last).Offset:offset]), "\n") {
+		text = "\n" + text
+	}
+	return goedit.Edit{Offset: offset, End: offset, Text: text}
+}
+
+// appendElement 在复合字面量末尾追加一个元素，单行字面量没有尾随逗号时补上
+func appendElement(fset *token.FileSet, content []byte, lit *ast.CompositeLit, element string) goedit.Edit {
+	offset := fset.Position(lit.Rbrace).Offset
+	text := element + ",\n"
+	if n := len(lit.Elts); n > 0 {
+		between := string(content[fset.Position(lit.Elts[n-1].End()).Offset:offset])
+		if !strings.Contains(between, ",") {
+			text = ",\n" + text
+		}
+	} else {
+		text = "\n" + text
+	}
+	return goedit.Edit{Offset: offset, End: offset, Text: text}
+}
+
+// calls 判断文件中是否已调用指定的函数
+func calls(file *ast.File, name string) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == name {
+				found = true
+			}
+			for _, arg := range node.Args {
+				if ident, ok := arg.(*ast.Ident); ok && ident.Name == name {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func funcDecl(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
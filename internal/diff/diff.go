@@ -0,0 +1,246 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DefaultContext 统一格式差异中每个变更块前后保留的上下文行数
+const DefaultContext = 3
+
+// opKind 编辑操作类型
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// edit 一行的编辑操作，aLine/bLine 为该行在两侧的行号（从 0 开始）
+type edit struct {
+	kind  opKind
+	aLine int
+	bLine int
+}
+
+// Stat 两个文本之间新增和删除的行数
+type Stat struct {
+	Added   int
+	Deleted int
+}
+
+// IsBinary 判断内容是否为二进制（前 8000 字节中包含 NUL）
+func IsBinary(content []byte) bool {
+	if len(content) > 8000 {
+		content = content[:8000]
+	}
+	return bytes.IndexByte(content, 0) >= 0
+}
+
+// Lines 将文本按行切分，每行保留换行符
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Compute 统计 a 到 b 的新增和删除行数
+func Compute(a, b string) Stat {
+	var stat Stat
+	for _, e := range myers(Lines(a), Lines(b)) {
+		switch e.kind {
+		case opInsert:
+			stat.Added++
+		case opDelete:
+			stat.Deleted++
+		}
+	}
+	return stat
+}
+
+// Unified 生成 a 到 b 的统一格式差异，内容相同时返回空字符串
+func Unified(aName, bName, a, b string, context int) string {
+	if a == b {
+		return ""
+	}
+	aLines, bLines := Lines(a), Lines(b)
+	edits := myers(aLines, bLines)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+
+	for start := 0; start < len(edits); {
+		// 找到下一个变更
+		for start < len(edits) && edits[start].kind == opEqual {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// 向后扩展，直到两个变更之间的相同行超过 2*context
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].kind != opEqual {
+				end = i
+				continue
+			}
+			if i-end > 2*context {
+				break
+			}
+		}
+
+		first := max(start-context, 0)
+		last := min(end+context, len(edits)-1)
+		writeHunk(&out, edits[first:last+1], aLines, bLines)
+		start = last + 1
+	}
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, edits []edit, aLines, bLines []string) {
+	aStart, bStart := -1, -1
+	aCount, bCount := 0, 0
+	for _, e := range edits {
+		if e.kind != opInsert {
+			if aStart < 0 {
+				aStart = e.aLine
+			}
+			aCount++
+		}
+		if e.kind != opDelete {
+			if bStart < 0 {
+				bStart = e.bLine
+			}
+			bCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount, edits[0].aLine), hunkRange(bStart, bCount, edits[0].bLine))
+
+	for _, e := range edits {
+		var prefix, line string
+		switch e.kind {
+		case opEqual:
+			prefix, line = " ", aLines[e.aLine]
+		case opDelete:
+			prefix, line = "-", aLines[e.aLine]
+		case opInsert:
+			prefix, line = "+", bLines[e.bLine]
+		}
+		out.WriteString(prefix)
+		out.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange 格式化变更块的行号范围，count 为 0 时按惯例使用前一行的行号
+func hunkRange(start, count, fallback int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", fallback)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// maxEditDistance 超过该编辑距离时不再求最短编辑序列，直接视为整体替换，避免占用过多内存
+const maxEditDistance = 2000
+
+// myers 使用 Myers 差分算法计算 a 到 b 的最短编辑序列
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	maxD := min(n+m, maxEditDistance)
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] 保存第 d 轮开始前 v[-d-1..d+1] 的快照
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, d)
+			}
+		}
+	}
+	return replaceAll(a, b)
+}
+
+func backtrack(trace [][]int, a, b []string, d int) []edit {
+	x, y := len(a), len(b)
+	var edits []edit
+
+	for ; d > 0; d-- {
+		snapshot := trace[d]
+		at := func(k int) int { return snapshot[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: opEqual, aLine: x, bLine: y})
+		}
+		if prevK == k+1 {
+			y--
+			edits = append(edits, edit{kind: opInsert, aLine: x, bLine: y})
+		} else {
+			x--
+			edits = append(edits, edit{kind: opDelete, aLine: x, bLine: y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{kind: opEqual, aLine: x, bLine: y})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// replaceAll 返回删除 a 的全部行再插入 b 的全部行的编辑序列
+func replaceAll(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	for i := range a {
+		edits = append(edits, edit{kind: opDelete, aLine: i, bLine: 0})
+	}
+	for j := range b {
+		edits = append(edits, edit{kind: opInsert, aLine: len(a), bLine: j})
+	}
+	return edits
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified_SingleChange(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\n"
+	b := "one\ntwo\nTHREE\nfour\nfive\n"
+
+	got := Unified("a/file.txt", "b/file.txt", a, b, DefaultContext)
+	want := `--- a/file.txt
++++ b/file.txt
+@@ -1,5 +1,5 @@
+ one
+ two
+-three
++THREE
+ four
+ five
+`
+	if got != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnified_SplitsDistantHunks(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 30; i++ {
+		line := strings.Repeat("x", i+1)
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[2] = "changed-early"
+	b[27] = "changed-late"
+
+	got := Unified("a", "b", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n", DefaultContext)
+	if strings.Count(got, "@@ -") != 2 {
+		t.Fatalf("expected two hunks, got:\n%s", got)
+	}
+	if !strings.Contains(got, "@@ -1,6 +1,6 @@") || !strings.Contains(got, "@@ -25,6 +25,6 @@") {
+		t.Fatalf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestUnified_AddedFileAndNoNewline(t *testing.T) {
+	got := Unified("/dev/null", "b/new.txt", "", "hello", DefaultContext)
+	want := "--- /dev/null\n+++ b/new.txt\n@@ -0,0 +1 @@\n+hello\n\\ No newline at end of file\n"
+	if got != want {
+		t.Fatalf("unexpected diff:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestUnified_Identical(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n", DefaultContext); got != "" {
+		t.Fatalf("expected empty diff, got %q", got)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	stat := Compute("a\nb\nc\n", "a\nc\nd\ne\n")
+	if stat.Added != 2 || stat.Deleted != 1 {
+		t.Fatalf("unexpected stat: %+v", stat)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	if IsBinary([]byte("plain text\n")) {
+		t.Fatalf("text detected as binary")
+	}
+	if !IsBinary([]byte{0x89, 'P', 'N', 'G', 0x00, 0x01}) {
+		t.Fatalf("binary not detected")
+	}
+}
+
+func TestUnified_LargeRewriteFallsBack(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < maxEditDistance; i++ {
+		a.WriteString("old line\n")
+		b.WriteString("new line\n")
+	}
+	stat := Compute(a.String(), b.String())
+	if stat.Added != maxEditDistance || stat.Deleted != maxEditDistance {
+		t.Fatalf("unexpected stat for full rewrite: %+v", stat)
+	}
+}
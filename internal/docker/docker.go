@@ -0,0 +1,306 @@
+package docker
+
+import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// 生成的文件及对应的模板
+var files = []struct {
+	name     string
+	template string
+}{
+	{"Dockerfile", "templates/Dockerfile.tmpl"},
+	{"docker-compose.yml", "templates/docker-compose.yml.tmpl"},
+	{".dockerignore", "templates/dockerignore.tmpl"},
+}
+
+// 依赖服务在 docker-compose.yml 中的名称，同时写入 .env 的 DB_HOST/REDIS_HOST
+const (
+	DatabaseService = "db"
+	RedisService    = "redis"
+)
+
+// 未从 .env 中读取到时使用的默认值
+const (
+	defaultGoVersion = "1.22"
+	defaultPort      = "3000"
+)
+
+// assetDirs 运行时需要随二进制一起复制到镜像中的目录，项目中存在时才复制
+var assetDirs = []string{"public", "resources", "storage", "lang"}
+
+// Options 生成 Docker 配置的选项，Database/Cache 为空时从项目 .env 中读取
+type Options struct {
+	Project  string
+	Database string
+	Cache    string
+	// Force 覆盖已存在的文件
+	Force bool
+	// KeepEnv 不修改 .env，需要用户自行设置的配置项记录在 Report.EnvValues 中
+	KeepEnv bool
+}
+
+// Report 生成结果
+type Report struct {
+	CreatedFiles []string `json:"created_files"`
+	// SkippedFiles 已存在且未指定 Force 而跳过的文件
+	SkippedFiles  []string `json:"skipped_files,omitempty"`
+	ModifiedFiles []string `json:"modified_files,omitempty"`
+	Database      string   `json:"database"`
+	Services      []string `json:"services"`
+	// EnvValues KeepEnv 时未写入 .env、需要用户自行设置的配置项
+	EnvValues map[string]string `json:"env_values,omitempty"`
+}
+
+// service docker-compose.yml 中的依赖服务
+type service struct {
+	Name        string
+	Image       string
+	Command     string
+	Environment []string
+	Port        string
+	Volume      string
+	Healthcheck string
+	StartPeriod string
+}
+
+// data 渲染模板使用的数据
+type data struct {
+	Image     string
+	GoVersion string
+	Port      string
+	Frontend  bool
+	Lockfile  bool
+	CGO       bool
+	SQLite    bool
+	Assets    []string
+	Services  []service
+	Volumes   []string
+}
+
+// Generate 在项目目录中生成多阶段构建的 Dockerfile、包含所选数据库和 Redis 的 docker-compose.yml
+// 以及 .dockerignore，并将 .env 中的数据库和 Redis 地址指向 compose 服务名；KeepEnv 时只返回需要设置的配置项
+func Generate(dir string, opts Options) (*Report, error) {
+	envPath := filepath.Join(dir, ".env")
+	env, err := driver.ReadEnv(envPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(env) == 0 {
+		if env, err = driver.ReadEnv(filepath.Join(dir, ".env.example")); err != nil {
+			return nil, err
+		}
+	}
+
+	database := firstNonEmpty(opts.Database, env["DB_CONNECTION"], "mysql")
+	db, ok := driver.LookupDatabase(database)
+	if !ok {
+		return nil, fmt.Errorf("unsupported database %q", database)
+	}
+	cache := firstNonEmpty(opts.Cache, env["CACHE_STORE"], "redis")
+	project := firstNonEmpty(opts.Project, filepath.Base(absPath(dir)))
+
+	d := data{
+		Image:     imageName(project),
+		GoVersion: goVersion(dir),
+		Port:      firstNonEmpty(env["APP_PORT"], defaultPort),
+		Frontend:  exists(filepath.Join(dir, "frontend", "package.json")),
+		Lockfile:  exists(filepath.Join(dir, "frontend", "pnpm-lock.yaml")),
+		// 基于 cgo 的 sqlite 驱动需要在构建阶段启用 cgo
+		CGO:    db.Name == "sqlite",
+		SQLite: db.Name == "sqlite",
+	}
+	for _, asset := range assetDirs {
+		if exists(filepath.Join(dir, asset)) {
+			d.Assets = append(d.Assets, asset)
+		}
+	}
+
+	// 将 .env 中的连接地址指向 compose 服务，并为数据库补充密码
+	values := map[string]string{}
+	if db.Name != "sqlite" {
+		user := firstNonEmpty(env["DB_USERNAME"], db.Username)
+		password := env["DB_PASSWORD"]
+		if password == "" {
+			if password, err = generatePassword(db.Name); err != nil {
+				return nil, err
+			}
+		}
+		values["DB_HOST"] = DatabaseService
+		values["DB_PORT"] = db.Port
+		values["DB_USERNAME"] = user
+		values["DB_PASSWORD"] = password
+		d.Services = append(d.Services, databaseService(db, user))
+		d.Volumes = append(d.Volumes, "db-data")
+	}
+	if cache == "redis" {
+		values["REDIS_HOST"] = RedisService
+		values["REDIS_PORT"] = "6379"
+		d.Services = append(d.Services, redisService(env["REDIS_PASSWORD"] != ""))
+	}
+
+	report := &Report{Database: db.Name}
+	for _, s := range d.Services {
+		report.Services = append(report.Services, s.Name)
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if exists(path) && !opts.Force {
+			report.SkippedFiles = append(report.SkippedFiles, file.name)
+			continue
+		}
+		content, err := render(file.template, d)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return nil, err
+		}
+		report.CreatedFiles = append(report.CreatedFiles, file.name)
+	}
+
+	if opts.KeepEnv {
+		for key, value := range values {
+			if env[key] != value {
+				if report.EnvValues == nil {
+					report.EnvValues = map[string]string{}
+				}
+				report.EnvValues[key] = value
+			}
+		}
+		return report, nil
+	}
+
+	changed, err := driver.SetEnv(envPath, values)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		report.ModifiedFiles = append(report.ModifiedFiles, ".env")
+	}
+	return report, nil
+}
+
+// databaseService 返回数据库驱动对应的 compose 服务，账号密码取自 .env
+func databaseService(db driver.Database, user string) service {
+	s := service{Name: DatabaseService, Port: db.Port}
+	switch db.Name {
+	case "mysql":
+		s.Image = "mysql:8.0"
+		s.Environment = []string{"MYSQL_DATABASE: ${DB_DATABASE}", "MYSQL_ROOT_PASSWORD: ${DB_PASSWORD}"}
+		if user != "root" {
+			s.Environment = append(s.Environment, "MYSQL_USER: ${DB_USERNAME}", "MYSQL_PASSWORD: ${DB_PASSWORD}")
+		}
+		s.Volume = "db-data:/var/lib/mysql"
+		s.Healthcheck = `["CMD-SHELL", "mysqladmin ping -h 127.0.0.1 -uroot -p$$MYSQL_ROOT_PASSWORD --silent"]`
+	case "postgres":
+		s.Image = "postgres:16-alpine"
+		s.Environment = []string{"POSTGRES_DB: ${DB_DATABASE}", "POSTGRES_USER: ${DB_USERNAME}", "POSTGRES_PASSWORD: ${DB_PASSWORD}"}
+		s.Volume = "db-data:/var/lib/postgresql/data"
+		s.Healthcheck = `["CMD-SHELL", "pg_isready -U $$POSTGRES_USER -d $$POSTGRES_DB"]`
+	case "sqlserver":
+		s.Image = "mcr.microsoft.com/mssql/server:2022-latest"
+		s.Environment = []string{`ACCEPT_EULA: "Y"`, "MSSQL_SA_PASSWORD: ${DB_PASSWORD}"}
+		s.Volume = "db-data:/var/opt/mssql"
+		s.Healthcheck = `["CMD-SHELL", "/opt/mssql-tools18/bin/sqlcmd -C -S localhost -U sa -P \"$$MSSQL_SA_PASSWORD\" -Q \"SELECT 1\" || exit 1"]`
+		s.StartPeriod = "30s"
+	}
+	return s
+}
+
+// redisService 返回 Redis 的 compose 服务，.env 中设置了 REDIS_PASSWORD 时启用密码
+func redisService(password bool) service {
+	s := service{
+		Name:        RedisService,
+		Image:       "redis:7-alpine",
+		Port:        "6379",
+		Healthcheck: `["CMD", "redis-cli", "ping"]`,
+	}
+	if password {
+		s.Command = `["redis-server", "--requirepass", "${REDIS_PASSWORD}"]`
+		s.Environment = []string{"REDISCLI_AUTH: ${REDIS_PASSWORD}"}
+	}
+	return s
+}
+
+// render 渲染内置模板
+func render(name string, d data) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, name)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// goVersion 返回 go.mod 中声明的 Go 版本（主版本.次版本），用作构建镜像的标签
+func goVersion(dir string) string {
+	mod, err := utils.ReadGoMod(dir)
+	if err != nil || mod.Go == "" {
+		return defaultGoVersion
+	}
+	return mod.MinorVersion()
+}
+
+var invalidImageChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// imageName 将项目名称转换为合法的镜像名称
+func imageName(project string) string {
+	name := strings.Trim(invalidImageChars.ReplaceAllString(strings.ToLower(project), "-"), "-._")
+	if name == "" {
+		name = "goravel"
+	}
+	return name + ":latest"
+}
+
+// generatePassword 生成随机数据库密码，sqlserver 要求密码同时包含大小写字母、数字和符号
+func generatePassword(database string) (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	password := hex.EncodeToString(buf)
+	if database == "sqlserver" {
+		password = "Gk-" + password
+	}
+	return password, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func absPath(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
@@ -0,0 +1,195 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+)
+
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "My App")
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func assertContains(t *testing.T, name, content string, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in %s, got:\n%s", want, name, content)
+		}
+	}
+}
+
+func TestGenerate_MySQLWithFrontend(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"go.mod":                "module goravel\n\ngo 1.22.3\n",
+		".env":                  "APP_NAME=demo\nAPP_PORT=3000\nDB_CONNECTION=mysql\nDB_HOST=127.0.0.1\nDB_PORT=3306\nDB_DATABASE=goravel\nDB_USERNAME=root\nDB_PASSWORD=\nREDIS_HOST=127.0.0.1\nREDIS_PASSWORD=\n",
+		"frontend/package.json": "{}\n",
+		"public/favicon.ico":    "",
+	})
+
+	report, err := Generate(dir, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Join(report.CreatedFiles, ",") != "Dockerfile,docker-compose.yml,.dockerignore" {
+		t.Fatalf("unexpected created files: %v", report.CreatedFiles)
+	}
+	if strings.Join(report.Services, ",") != "db,redis" {
+		t.Fatalf("unexpected services: %v", report.Services)
+	}
+
+	assertContains(t, "Dockerfile", read(t, dir, "Dockerfile"),
+		"FROM node:20-alpine AS frontend",
+		"RUN pnpm install\n",
+		"FROM golang:1.22-alpine AS backend",
+		"CGO_ENABLED=0",
+		"COPY --from=backend /src/public ./public",
+		"COPY --from=frontend /src/frontend/dist ./frontend/dist",
+		"EXPOSE 3000",
+		"HEALTHCHECK",
+	)
+	compose := read(t, dir, "docker-compose.yml")
+	assertContains(t, "docker-compose.yml", compose,
+		"image: my-app:latest",
+		"      db:\n        condition: service_healthy",
+		"      redis:\n        condition: service_healthy",
+		"image: mysql:8.0",
+		"MYSQL_ROOT_PASSWORD: ${DB_PASSWORD}",
+		"mysqladmin ping",
+		"db-data:/var/lib/mysql",
+		`test: ["CMD", "redis-cli", "ping"]`,
+	)
+	if strings.Contains(compose, "MYSQL_USER") || strings.Contains(compose, "requirepass") {
+		t.Fatalf("unexpected root user or redis password settings:\n%s", compose)
+	}
+
+	env, err := driver.ReadEnv(filepath.Join(dir, ".env"))
+	if err != nil {
+		t.Fatalf("ReadEnv failed: %v", err)
+	}
+	if env["DB_HOST"] != DatabaseService || env["REDIS_HOST"] != RedisService || env["APP_NAME"] != "demo" {
+		t.Fatalf("expected .env wired to compose services, got %v", env)
+	}
+	if len(env["DB_PASSWORD"]) != 24 {
+		t.Fatalf("expected generated database password, got %q", env["DB_PASSWORD"])
+	}
+
+	// 再次生成时保留已有文件和密码
+	report, err = Generate(dir, Options{})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(report.CreatedFiles) != 0 || len(report.SkippedFiles) != 3 || len(report.ModifiedFiles) != 0 {
+		t.Fatalf("expected existing files skipped, got %+v", report)
+	}
+}
+
+func TestGenerate_SQLiteMemory(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		"go.mod": "module goravel\n\ngo 1.21\n",
+		".env":   "DB_CONNECTION=sqlite\nDB_DATABASE=database/database.sqlite\nCACHE_STORE=memory\n",
+	})
+	report, err := Generate(dir, Options{Project: "demo"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(report.Services) != 0 || report.Database != "sqlite" {
+		t.Fatalf("expected no external services, got %+v", report)
+	}
+
+	dockerfile := read(t, dir, "Dockerfile")
+	assertContains(t, "Dockerfile", dockerfile, "FROM golang:1.21-alpine", "build-base", "CGO_ENABLED=1")
+	if strings.Contains(dockerfile, "frontend") {
+		t.Fatalf("unexpected frontend stage:\n%s", dockerfile)
+	}
+	compose := read(t, dir, "docker-compose.yml")
+	assertContains(t, "docker-compose.yml", compose, "image: demo:latest", "./database:/www/database")
+	for _, unwanted := range []string{"depends_on", "volumes:\n  db-data", "redis"} {
+		if strings.Contains(compose, unwanted) {
+			t.Fatalf("unexpected %q in docker-compose.yml:\n%s", unwanted, compose)
+		}
+	}
+	if report.ModifiedFiles != nil {
+		t.Fatalf("expected .env untouched, got %v", report.ModifiedFiles)
+	}
+}
+
+func TestGenerate_PostgresOptions(t *testing.T) {
+	dir := writeProject(t, map[string]string{
+		".env":       "DB_CONNECTION=mysql\nDB_USERNAME=app\nDB_PASSWORD=secret\nREDIS_PASSWORD=pass\n",
+		"Dockerfile": "FROM scratch\n",
+	})
+	report, err := Generate(dir, Options{Database: "postgres", Force: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(report.SkippedFiles) != 0 {
+		t.Fatalf("expected Dockerfile overwritten, got %+v", report)
+	}
+	assertContains(t, "Dockerfile", read(t, dir, "Dockerfile"), "FROM golang:"+defaultGoVersion+"-alpine")
+	assertContains(t, "docker-compose.yml", read(t, dir, "docker-compose.yml"),
+		"image: postgres:16-alpine",
+		"POSTGRES_USER: ${DB_USERNAME}",
+		"pg_isready",
+		`command: ["redis-server", "--requirepass", "${REDIS_PASSWORD}"]`,
+	)
+	assertContains(t, ".env", read(t, dir, ".env"), "DB_PORT=5432\n", "DB_PASSWORD=secret\n", "DB_HOST=db\n")
+}
+
+func TestImageName(t *testing.T) {
+	for project, want := range map[string]string{"My App": "my-app:latest", "goravel_kit": "goravel_kit:latest", "___": "goravel:latest"} {
+		if got := imageName(project); got != want {
+			t.Fatalf("imageName(%q) = %q, want %q", project, got, want)
+		}
+	}
+}
+
+func TestGenerate_KeepEnv(t *testing.T) {
+	existing := "APP_NAME=demo\nDB_CONNECTION=mysql\nDB_HOST=127.0.0.1\nDB_PASSWORD=secret\nCACHE_STORE=redis\n"
+	dir := writeProject(t, map[string]string{
+		"go.mod": "module goravel\n\ngo 1.21\n",
+		".env":   existing,
+	})
+	report, err := Generate(dir, Options{Project: "demo", KeepEnv: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if got := read(t, dir, ".env"); got != existing {
+		t.Fatalf("expected .env untouched, got %q", got)
+	}
+	if len(report.ModifiedFiles) != 0 {
+		t.Fatalf("expected no modified files, got %v", report.ModifiedFiles)
+	}
+	want := map[string]string{"DB_HOST": DatabaseService, "REDIS_HOST": RedisService, "REDIS_PORT": "6379", "DB_PORT": "3306", "DB_USERNAME": "root"}
+	for key, value := range want {
+		if report.EnvValues[key] != value {
+			t.Fatalf("expected %s=%s in env values, got %v", key, value, report.EnvValues)
+		}
+	}
+	if _, ok := report.EnvValues["DB_PASSWORD"]; ok {
+		t.Fatalf("expected the existing password not to be reported, got %v", report.EnvValues)
+	}
+}
@@ -0,0 +1,497 @@
+package driver
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/goedit"
+)
+
+// Database 数据库驱动的默认配置
+type Database struct {
+	Name     string
+	Host     string
+	Port     string
+	Username string
+	// Facade goravel 独立驱动包中创建连接的函数名，如 postgresfacades.Postgres
+	Facade string
+	// Extra 驱动特有的连接配置，按源码原样插入 config/database.go
+	Extra string
+}
+
+// Package goravel 独立驱动包的模块路径
+func (d Database) Package() string {
+	return "github.com/goravel/" + d.Name
+}
+
+// Databases 支持的数据库驱动
+var Databases = []Database{
+	{Name: "mysql", Host: "127.0.0.1", Port: "3306", Username: "root", Facade: "Mysql",
+		Extra: `"charset": "utf8mb4",
+"loc": "UTC",`},
+	{Name: "postgres", Host: "127.0.0.1", Port: "5432", Username: "postgres", Facade: "Postgres",
+		Extra: `"sslmode": "disable",
+"schema": config.Env("DB_SCHEMA", "public"),`},
+	{Name: "sqlite", Facade: "Sqlite"},
+	{Name: "sqlserver", Host: "127.0.0.1", Port: "1433", Username: "sa", Facade: "Sqlserver",
+		Extra: `"charset": "utf8mb4",`},
+}
+
+// Caches 支持的缓存驱动
+var Caches = []string{"redis", "memory"}
+
+// SQLiteDatabase sqlite 数据库文件的默认路径（相对项目根目录）
+const SQLiteDatabase = "database/database.sqlite"
+
+// Options 驱动选择，为空表示沿用模板默认值
+type Options struct {
+	Database string
+	Cache    string
+}
+
+// Report 应用驱动选择后的改动摘要
+type Report struct {
+	ModifiedFiles []string `json:"modified_files"`
+	CreatedFiles  []string `json:"created_files,omitempty"`
+	// Requires 新增到 go.mod 的驱动依赖
+	Requires []string `json:"requires,omitempty"`
+}
+
+// LookupDatabase 按名称查找数据库驱动
+func LookupDatabase(name string) (Database, bool) {
+	for _, db := range Databases {
+		if db.Name == name {
+			return db, true
+		}
+	}
+	return Database{}, false
+}
+
+// Validate 校验驱动名称
+func (o Options) Validate() error {
+	if o.Database != "" {
+		if _, ok := LookupDatabase(o.Database); !ok {
+			names := make([]string, 0, len(Databases))
+			for _, db := range Databases {
+				names = append(names, db.Name)
+			}
+			return fmt.Errorf("unsupported database %q (%s)", o.Database, strings.Join(names, ", "))
+		}
+	}
+	if o.Cache != "" {
+		supported := false
+		for _, cache := range Caches {
+			supported = supported || cache == o.Cache
+		}
+		if !supported {
+			return fmt.Errorf("unsupported cache %q (%s)", o.Cache, strings.Join(Caches, ", "))
+		}
+	}
+	return nil
+}
+
+// Apply 在模板目录中应用数据库和缓存驱动：更新 .env 配置、config 中的默认驱动，
+// 模板使用 goravel 独立驱动包时补充驱动依赖、服务提供者和连接配置，sqlite 时创建数据库文件
+func Apply(dir string, opts Options) (*Report, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	modified := map[string]bool{}
+
+	env := map[string]string{}
+	var defaults []envDefault
+	if opts.Database != "" {
+		db, _ := LookupDatabase(opts.Database)
+		env["DB_CONNECTION"] = db.Name
+		env["DB_HOST"] = db.Host
+		env["DB_PORT"] = db.Port
+		env["DB_USERNAME"] = db.Username
+		if db.Name == "sqlite" {
+			env["DB_DATABASE"] = SQLiteDatabase
+			env["DB_PASSWORD"] = ""
+		}
+		defaults = append(defaults, envDefault{"DB_CONNECTION", db.Name})
+	}
+	if opts.Cache != "" {
+		env["CACHE_STORE"] = opts.Cache
+		defaults = append(defaults, envDefault{"CACHE_STORE", opts.Cache}, envDefault{"CACHE_DRIVER", opts.Cache})
+	}
+
+	for _, name := range []string{".env.example", ".env"} {
+		changed, err := setEnv(filepath.Join(dir, name), env, envAliases)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			modified[name] = true
+		}
+	}
+
+	configFiles, _ := filepath.Glob(filepath.Join(dir, "config", "*.go"))
+	for _, file := range configFiles {
+		changed, err := setEnvDefaults(file, defaults)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			rel, _ := filepath.Rel(dir, file)
+			modified[filepath.ToSlash(rel)] = true
+		}
+	}
+
+	if opts.Database != "" {
+		db, _ := LookupDatabase(opts.Database)
+		files, require, err := addDriverPackage(dir, db)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			modified[file] = true
+		}
+		if require != "" {
+			report.Requires = append(report.Requires, require)
+		}
+
+		if db.Name == "sqlite" {
+			path := filepath.Join(dir, filepath.FromSlash(SQLiteDatabase))
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return nil, err
+				}
+				// 空文件即为合法的 sqlite 数据库，应用无需外部服务即可启动
+				if err := os.WriteFile(path, nil, 0644); err != nil {
+					return nil, err
+				}
+				report.CreatedFiles = append(report.CreatedFiles, SQLiteDatabase)
+			}
+		}
+	}
+
+	for file := range modified {
+		report.ModifiedFiles = append(report.ModifiedFiles, file)
+	}
+	sort.Strings(report.ModifiedFiles)
+	return report, nil
+}
+
+// SetEnv 更新环境变量文件中的配置项，兼容旧版本 goravel 的配置项名称
+func SetEnv(path string, values map[string]string) (bool, error) {
+	return setEnv(path, values, envAliases)
+}
+
+// ReadEnv 读取环境变量文件中的配置项，旧名称按新名称返回；文件不存在时返回空结果
+func ReadEnv(path string) (map[string]string, error) {
+	values := map[string]string{}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if alias, ok := envAliases[key]; ok {
+			key = alias
+		}
+		values[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return values, nil
+}
+
+// envAliases 旧版本 goravel 使用的配置项名称，存在时代替新名称更新
+var envAliases = map[string]string{"CACHE_DRIVER": "CACHE_STORE"}
+
+// setEnv 更新环境变量文件中已有的配置项，不存在的配置项追加到末尾；文件不存在时忽略。
+// aliases 中的旧名称存在时按对应新名称的值更新，且不再追加新名称
+func setEnv(path string, values, aliases map[string]string) (bool, error) {
+	if len(values) == 0 {
+		return false, nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	seen := map[string]bool{}
+	for i, line := range lines {
+		key, _, found := strings.Cut(strings.TrimSpace(line), "=")
+		key = strings.TrimSpace(key)
+		canonical := key
+		if alias, ok := aliases[key]; ok {
+			canonical = alias
+		}
+		value, ok := values[canonical]
+		if !found || !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		seen[canonical] = true
+		lines[i] = key + "=" + value
+		if strings.HasSuffix(line, "\n") {
+			lines[i] += "\n"
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !seen[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := strings.Join(lines, "")
+	if len(keys) > 0 && result != "" && !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	for _, key := range keys {
+		// 模板中没有的可选配置项不必追加空值
+		if values[key] == "" {
+			continue
+		}
+		result += key + "=" + values[key] + "\n"
+	}
+	if result == string(content) {
+		return false, nil
+	}
+	return true, os.WriteFile(path, []byte(result), 0644)
+}
+
+// envDefault config 文件中 Env("KEY", default) 调用的默认值
+type envDefault struct {
+	key   string
+	value string
+}
+
+// setEnvDefaults 将 config 文件中 X.Env("KEY", "default") 的字符串默认值替换为新的驱动，
+// 只替换字面量本身，保留文件其余格式
+func setEnvDefaults(path string, defaults []envDefault) (bool, error) {
+	if len(defaults) == 0 {
+		return false, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, 0)
+	if err != nil {
+		return false, err
+	}
+
+	var edits []goedit.Edit
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Env" {
+			return true
+		}
+		key, ok := goedit.StringLiteral(call.Args[0])
+		if !ok {
+			return true
+		}
+		lit, ok := call.Args[1].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		for _, d := range defaults {
+			if d.key == key && lit.Value != strconv.Quote(d.value) {
+				edits = append(edits, goedit.Edit{
+					Offset: fset.Position(lit.Pos()).Offset,
+					End:    fset.Position(lit.End()).Offset,
+					Text:   strconv.Quote(d.value),
+				})
+			}
+		}
+		return true
+	})
+	if len(edits) == 0 {
+		return false, nil
+	}
+	return true, os.WriteFile(path, goedit.Apply(content, edits), 0644)
+}
+
+var requirePattern = regexp.MustCompile(`(?m)^\s*(?:require\s+)?github\.com/goravel/(mysql|postgres|sqlite|sqlserver)\s+(v\S+)`)
+
+// addDriverPackage 模板使用 goravel 独立驱动包（github.com/goravel/mysql 等）时，
+// 为所选数据库补充 go.mod 依赖、服务提供者和连接配置；框架内置驱动时无需改动
+func addDriverPackage(dir string, db Database) ([]string, string, error) {
+	goModPath := filepath.Join(dir, "go.mod")
+	goMod, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, "", nil
+	}
+	matches := requirePattern.FindAllSubmatch(goMod, -1)
+	if len(matches) == 0 {
+		return nil, "", nil
+	}
+	version := string(matches[0][2])
+	for _, match := range matches {
+		if string(match[1]) == db.Name {
+			return nil, "", nil
+		}
+	}
+
+	var modified []string
+	require := db.Package() + " " + version
+	goMod = addRequire(goMod, require)
+	if err := os.WriteFile(goModPath, goMod, 0644); err != nil {
+		return nil, "", err
+	}
+	modified = append(modified, "go.mod")
+
+	appPath := filepath.Join(dir, "config", "app.go")
+	if changed, err := addProvider(appPath, db); err != nil {
+		return nil, "", err
+	} else if changed {
+		modified = append(modified, "config/app.go")
+	}
+
+	databasePath := filepath.Join(dir, "config", "database.go")
+	if changed, err := addConnection(databasePath, db); err != nil {
+		return nil, "", err
+	} else if changed {
+		modified = append(modified, "config/database.go")
+	}
+	return modified, require, nil
+}
+
+// addRequire 在 go.mod 的第一个 require 块中加入依赖
+func addRequire(goMod []byte, require string) []byte {
+	index := bytes.Index(goMod, []byte("require ("))
+	if index < 0 {
+		return append(goMod, []byte("\nrequire "+require+"\n")...)
+	}
+	lineEnd := index + bytes.IndexByte(goMod[index:], '\n') + 1
+	var out []byte
+	out = append(out, goMod[:lineEnd]...)
+	out = append(out, []byte("\t"+require+"\n")...)
+	return append(out, goMod[lineEnd:]...)
+}
+
+// addProvider 在 config/app.go 的 providers 列表末尾注册驱动的服务提供者
+func addProvider(path string, db Database) (bool, error) {
+	content, fset, file, err := goedit.Parse(path)
+	if err != nil || file == nil {
+		return false, err
+	}
+
+	var target *ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || target != nil {
+			return true
+		}
+		if array, ok := lit.Type.(*ast.ArrayType); ok {
+			if sel, ok := array.Elt.(*ast.SelectorExpr); ok && sel.Sel.Name == "ServiceProvider" {
+				target = lit
+			}
+		}
+		return true
+	})
+	if target == nil {
+		return false, nil
+	}
+
+	edits := []goedit.Edit{{
+		Offset: fset.Position(target.Rbrace).Offset,
+		End:    fset.Position(target.Rbrace).Offset,
+		Text:   fmt.Sprintf("&%s.ServiceProvider{},\n", db.Name),
+	}}
+	edits = append(edits, goedit.ImportEdit(fset, file, db.Name, db.Package()))
+	return goedit.Write(path, content, edits)
+}
+
+// addConnection 在 config/database.go 的 connections 中加入所选数据库的连接配置
+func addConnection(path string, db Database) (bool, error) {
+	content, fset, file, err := goedit.Parse(path)
+	if err != nil || file == nil {
+		return false, err
+	}
+
+	var connections *ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok || connections != nil {
+			return true
+		}
+		if key, ok := goedit.StringLiteral(kv.Key); ok && key == "connections" {
+			connections, _ = kv.Value.(*ast.CompositeLit)
+		}
+		return true
+	})
+	if connections == nil {
+		return false, nil
+	}
+	for _, elt := range connections.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := goedit.StringLiteral(kv.Key); ok && key == db.Name {
+				return false, nil
+			}
+		}
+	}
+
+	// 复用已有连接配置中读取环境变量的接收者，通常为 config
+	receiver := "config"
+	ast.Inspect(connections, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "Env" {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				receiver = ident.Name
+				return false
+			}
+		}
+		return true
+	})
+
+	var entry strings.Builder
+	fmt.Fprintf(&entry, "%q: map[string]any{\n", db.Name)
+	if db.Name == "sqlite" {
+		fmt.Fprintf(&entry, "\"database\": config.Env(\"DB_DATABASE\", %q),\n", SQLiteDatabase)
+	} else {
+		fmt.Fprintf(&entry, "\"host\": config.Env(\"DB_HOST\", %q),\n", db.Host)
+		fmt.Fprintf(&entry, "\"port\": config.Env(\"DB_PORT\", %s),\n", db.Port)
+		entry.WriteString("\"database\": config.Env(\"DB_DATABASE\", \"forge\"),\n")
+		entry.WriteString("\"username\": config.Env(\"DB_USERNAME\", \"\"),\n")
+		entry.WriteString("\"password\": config.Env(\"DB_PASSWORD\", \"\"),\n")
+	}
+	if db.Extra != "" {
+		entry.WriteString(db.Extra + "\n")
+	}
+	entry.WriteString("\"prefix\": \"\",\n\"singular\": false,\n")
+	fmt.Fprintf(&entry, "\"via\": func() (driver.Driver, error) {\nreturn %sfacades.%s(%q)\n},\n},\n", db.Name, db.Facade, db.Name)
+	text := strings.ReplaceAll(entry.String(), "config.Env(", receiver+".Env(")
+
+	edits := []goedit.Edit{
+		{Offset: fset.Position(connections.Rbrace).Offset, End: fset.Position(connections.Rbrace).Offset, Text: text},
+		goedit.ImportEdit(fset, file, db.Name+"facades", db.Package()+"/facades"),
+	}
+	if !goedit.HasImport(file, "github.com/goravel/framework/contracts/database/driver") {
+		edits = append(edits, goedit.ImportEdit(fset, file, "", "github.com/goravel/framework/contracts/database/driver"))
+	}
+	return goedit.Write(path, content, edits)
+}
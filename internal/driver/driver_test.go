@@ -0,0 +1,203 @@
+package driver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDatabaseConfig = `package config
+
+import (
+	"github.com/goravel/framework/contracts/database/driver"
+	"github.com/goravel/framework/facades"
+	mysqlfacades "github.com/goravel/mysql/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("database", map[string]any{
+		"default": config.Env("DB_CONNECTION", "mysql"),
+		"connections": map[string]any{
+			"mysql": map[string]any{
+				"host": config.Env("DB_HOST", "127.0.0.1"),
+				"via": func() (driver.Driver, error) {
+					return mysqlfacades.Mysql("mysql")
+				},
+			},
+		},
+	})
+}
+`
+
+const testAppConfig = `package config
+
+import (
+	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/mysql"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("app", map[string]any{
+		"providers": []foundation.ServiceProvider{
+			&mysql.ServiceProvider{},
+		},
+	})
+}
+`
+
+const testCacheConfig = `package config
+
+import "github.com/goravel/framework/facades"
+
+func init() {
+	config := facades.Config()
+	config.Add("cache", map[string]any{
+		"default": config.Env("CACHE_STORE", "redis"),
+	})
+}
+`
+
+func writeTemplate(t *testing.T, goMod string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":             goMod,
+		"config/database.go": testDatabaseConfig,
+		"config/app.go":      testAppConfig,
+		"config/cache.go":    testCacheConfig,
+		".env.example":       "APP_NAME=Goravel\nDB_CONNECTION=mysql\nDB_HOST=127.0.0.1\nDB_PORT=3306\nDB_DATABASE=goravel\nDB_USERNAME=root\nDB_PASSWORD=\nCACHE_STORE=redis\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+const driverGoMod = "module goravel\n\ngo 1.22\n\nrequire (\n\tgithub.com/goravel/framework v1.15.2\n\tgithub.com/goravel/mysql v1.3.1\n)\n"
+
+func TestApply_PostgresWithDriverPackages(t *testing.T) {
+	dir := writeTemplate(t, driverGoMod)
+
+	report, err := Apply(dir, Options{Database: "postgres", Cache: "memory"})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(report.Requires) != 1 || report.Requires[0] != "github.com/goravel/postgres v1.3.1" {
+		t.Fatalf("unexpected requires: %v", report.Requires)
+	}
+
+	env := read(t, dir, ".env.example")
+	for _, want := range []string{"DB_CONNECTION=postgres\n", "DB_PORT=5432\n", "DB_USERNAME=postgres\n", "CACHE_STORE=memory\n", "DB_DATABASE=goravel\n"} {
+		if !strings.Contains(env, want) {
+			t.Fatalf("expected %q in .env.example, got:\n%s", want, env)
+		}
+	}
+	if !strings.Contains(read(t, dir, "go.mod"), "github.com/goravel/postgres v1.3.1") {
+		t.Fatalf("expected postgres driver in go.mod")
+	}
+
+	app := read(t, dir, "config/app.go")
+	if !strings.Contains(app, "&postgres.ServiceProvider{}") || !strings.Contains(app, `"github.com/goravel/postgres"`) {
+		t.Fatalf("expected postgres provider registered:\n%s", app)
+	}
+
+	database := read(t, dir, "config/database.go")
+	for _, want := range []string{
+		`"default": config.Env("DB_CONNECTION", "postgres")`,
+		`"postgres": map[string]any{`,
+		`"port":     config.Env("DB_PORT", 5432)`,
+		`return postgresfacades.Postgres("postgres")`,
+		`postgresfacades "github.com/goravel/postgres/facades"`,
+	} {
+		if !strings.Contains(database, want) {
+			t.Fatalf("expected %q in config/database.go, got:\n%s", want, database)
+		}
+	}
+
+	if cache := read(t, dir, "config/cache.go"); !strings.Contains(cache, `config.Env("CACHE_STORE", "memory")`) {
+		t.Fatalf("expected memory cache default:\n%s", cache)
+	}
+}
+
+func TestApply_SQLiteBuiltinDrivers(t *testing.T) {
+	dir := writeTemplate(t, "module goravel\n\ngo 1.22\n\nrequire github.com/goravel/framework v1.13.0\n")
+
+	report, err := Apply(dir, Options{Database: "sqlite"})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(report.Requires) != 0 {
+		t.Fatalf("expected no driver packages for builtin drivers, got %v", report.Requires)
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(SQLiteDatabase))); err != nil {
+		t.Fatalf("expected sqlite database file: %v", err)
+	}
+
+	env := read(t, dir, ".env.example")
+	for _, want := range []string{"DB_CONNECTION=sqlite\n", "DB_DATABASE=" + SQLiteDatabase + "\n", "DB_HOST=\n", "CACHE_STORE=redis\n"} {
+		if !strings.Contains(env, want) {
+			t.Fatalf("expected %q in .env.example, got:\n%s", want, env)
+		}
+	}
+	if strings.Contains(read(t, dir, "config/app.go"), "sqlite") {
+		t.Fatalf("expected config/app.go untouched")
+	}
+}
+
+func TestApply_ExistingDriverPackage(t *testing.T) {
+	dir := writeTemplate(t, driverGoMod)
+	report, err := Apply(dir, Options{Database: "mysql"})
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(report.Requires) != 0 {
+		t.Fatalf("expected no new requires, got %v", report.Requires)
+	}
+	if got := read(t, dir, "config/database.go"); got != testDatabaseConfig {
+		t.Fatalf("expected config/database.go unchanged, got:\n%s", got)
+	}
+}
+
+func TestSetEnv_Alias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("CACHE_DRIVER=redis\nAPP_NAME=demo"), 0644); err != nil {
+		t.Fatalf("failed to write .env: %v", err)
+	}
+	if _, err := setEnv(path, map[string]string{"CACHE_STORE": "memory", "DB_PORT": "5432"}, envAliases); err != nil {
+		t.Fatalf("setEnv failed: %v", err)
+	}
+	if got := read(t, filepath.Dir(path), ".env"); got != "CACHE_DRIVER=memory\nAPP_NAME=demo\nDB_PORT=5432\n" {
+		t.Fatalf("unexpected .env: %q", got)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	if err := (Options{Database: "oracle"}).Validate(); err == nil || !strings.Contains(err.Error(), "postgres") {
+		t.Fatalf("expected unsupported database error, got: %v", err)
+	}
+	if err := (Options{Cache: "memcached"}).Validate(); err == nil {
+		t.Fatalf("expected unsupported cache error")
+	}
+	if err := (Options{Database: "sqlserver", Cache: "redis"}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
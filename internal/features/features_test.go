@@ -0,0 +1,388 @@
+package features
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTemplate 构造一个可编译的最小模板：路由、服务提供者和配置中引用了 websocket 与 pdf 功能包
+func fakeTemplate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module goravel\n\ngo 1.21\n",
+		"main.go": `package main
+
+import (
+	"fmt"
+
+	"goravel/config"
+	"goravel/routes"
+)
+
+func main() {
+	fmt.Println(len(config.Providers()))
+	routes.Web()
+}
+`,
+		"framework/route.go": `package framework
+
+type Router struct{ routes []string }
+
+func NewRouter() *Router { return &Router{} }
+
+func (r *Router) Get(path string, handler func()) { r.routes = append(r.routes, path) }
+
+func (r *Router) Static(path, root string) { r.routes = append(r.routes, path) }
+
+type ServiceProvider interface{ Register() }
+`,
+		"config/app.go": `package config
+
+import (
+	"goravel/framework"
+	socket "goravel/packages/goravel-socket"
+	"goravel/packages/goravel_pdf_gen/providers"
+)
+
+// Providers 注册的服务提供者
+func Providers() []framework.ServiceProvider {
+	return []framework.ServiceProvider{
+		&socket.ServiceProvider{},
+		// PDF 生成器
+		&providers.ServiceProvider{},
+	}
+}
+
+var settings = map[string]any{
+	"name":  "goravel",
+	"ws":    map[string]any{"port": socket.DefaultPort},
+	"debug": true,
+}
+`,
+		"routes/web.go": `package routes
+
+import (
+	"goravel/framework"
+	socket "goravel/packages/goravel-socket"
+	pdfroutes "goravel/packages/goravel_pdf_gen/routes"
+)
+
+type handlers struct {
+	name string
+	hub  *socket.Hub
+}
+
+func Web() {
+	router := framework.NewRouter()
+	hub := socket.NewHub()
+	// 注册 websocket 路由
+	router.Get("ws", hub.Serve)
+	pdfroutes.Pdf(router)
+	router.Get("/", func() {
+		server := socket.NewHub()
+		server.Serve()
+	})
+	router.Static("dist", "./frontend/dist")
+	router.Static("assets", "./frontend/dist/assets")
+	router.Static("uploads", "./storage/uploads")
+}
+
+func wsHandler(hub *socket.Hub) {
+	hub.Serve()
+}
+`,
+		"packages/goravel-socket/socket.go": `package socket
+
+const DefaultPort = 8081
+
+type Hub struct{}
+
+func NewHub() *Hub { return &Hub{} }
+
+func (h *Hub) Serve() {}
+
+type ServiceProvider struct{}
+
+func (p *ServiceProvider) Register() {}
+`,
+		"packages/goravel_pdf_gen/providers/provider.go": `package providers
+
+type ServiceProvider struct{}
+
+func (p *ServiceProvider) Register() {}
+`,
+		"packages/goravel_pdf_gen/routes/routes.go": `package routes
+
+import "goravel/framework"
+
+func Pdf(router *framework.Router) { router.Get("pdf_design", func() {}) }
+`,
+		"frontend/package.json": "{}\n",
+		".env.example":          "APP_NAME=goravel\nWS_PORT=8081\nPDF_DIR=storage\nVITE_API_URL=http://localhost:3000\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func goBuild(t *testing.T, dir string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not installed")
+	}
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("template does not compile: %v\n%s", err, out)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func TestFakeTemplateCompiles(t *testing.T) {
+	goBuild(t, fakeTemplate(t))
+}
+
+func TestRemove_WebsocketAndPdf(t *testing.T) {
+	dir := fakeTemplate(t)
+	removed, err := Defaults().Select(nil, []string{"websocket,pdf"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+
+	report, err := Remove(dir, removed)
+	if err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	for _, path := range []string{"packages/goravel-socket", "packages/goravel_pdf_gen"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s removed, got: %v", path, err)
+		}
+	}
+	if len(report.RemovedPaths) != 2 {
+		t.Fatalf("unexpected removed paths: %v", report.RemovedPaths)
+	}
+
+	app := readFile(t, dir, "config/app.go")
+	for _, unwanted := range []string{"socket", "providers.", "PDF 生成器", `"ws"`} {
+		if strings.Contains(app, unwanted) {
+			t.Fatalf("config/app.go still references %q:\n%s", unwanted, app)
+		}
+	}
+	if !strings.Contains(app, `"debug": true`) {
+		t.Fatalf("expected unrelated config kept:\n%s", app)
+	}
+
+	web := readFile(t, dir, "routes/web.go")
+	for _, unwanted := range []string{"socket", "hub", "pdfroutes", "注册 websocket 路由", "wsHandler"} {
+		if strings.Contains(web, unwanted) {
+			t.Fatalf("routes/web.go still references %q:\n%s", unwanted, web)
+		}
+	}
+	if !strings.Contains(web, `router.Static("dist"`) {
+		t.Fatalf("expected unrelated routes kept:\n%s", web)
+	}
+
+	env := readFile(t, dir, ".env.example")
+	if env != "APP_NAME=goravel\nVITE_API_URL=http://localhost:3000\n" {
+		t.Fatalf("unexpected .env.example: %q", env)
+	}
+}
+
+func TestRemove_APIOnly(t *testing.T) {
+	dir := fakeTemplate(t)
+	manifest := Defaults()
+	removed, err := manifest.Select(nil, manifest.FrontendFeatures())
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if _, err := Remove(dir, removed); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	// 生成的项目中不应再有任何前端相关的引用
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if strings.Contains(rel, "frontend") {
+			t.Errorf("unexpected frontend path: %s", rel)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, ref := range []string{"frontend", `"dist"`, `"assets"`} {
+			if strings.Contains(string(content), ref) {
+				t.Errorf("%s still references %s:\n%s", rel, ref, content)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+
+	web := readFile(t, dir, "routes/web.go")
+	if !strings.Contains(web, `router.Get("ws", hub.Serve)`) || !strings.Contains(web, `router.Static("uploads"`) {
+		t.Fatalf("expected backend routes kept:\n%s", web)
+	}
+}
+
+func TestRemove_LiteralsOnlyInRoutesAndConfig(t *testing.T) {
+	dir := fakeTemplate(t)
+	controller := `package controllers
+
+func Assets() []string {
+	names := []string{"crud", "uploads"}
+	names = append(names, "dist", "index.html")
+	return names
+}
+`
+	path := filepath.Join(dir, "app", "http", "controllers", "assets.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(controller), 0644); err != nil {
+		t.Fatalf("failed to write controller: %v", err)
+	}
+
+	manifest := Defaults()
+	removed, err := manifest.Select(nil, []string{"crud,frontend"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	report, err := Remove(dir, removed)
+	if err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	goBuild(t, dir)
+
+	if got := readFile(t, dir, "app/http/controllers/assets.go"); got != controller {
+		t.Fatalf("expected business code untouched, got:\n%s", got)
+	}
+	for _, file := range report.ModifiedFiles {
+		if strings.HasPrefix(file, "app/") {
+			t.Fatalf("unexpected modified file %s", file)
+		}
+	}
+	if web := readFile(t, dir, "routes/web.go"); strings.Contains(web, `"dist"`) {
+		t.Fatalf("expected dist route removed:\n%s", web)
+	}
+}
+
+func TestRemove_NoFeatures(t *testing.T) {
+	dir := fakeTemplate(t)
+	report, err := Remove(dir, nil)
+	if err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if len(report.RemovedPaths) != 0 || len(report.ModifiedFiles) != 0 {
+		t.Fatalf("expected no changes, got %+v", report)
+	}
+}
+
+func TestManifestSelect(t *testing.T) {
+	manifest := Defaults()
+
+	removed, err := manifest.Select([]string{"crud"}, nil)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if got := strings.Join(FeatureNames(removed), ","); got != "websocket,pdf,frontend" {
+		t.Fatalf("unexpected removed features: %s", got)
+	}
+	if got := manifest.FrontendFeatures(); len(got) != 1 || got[0] != "frontend" {
+		t.Fatalf("unexpected frontend features: %v", got)
+	}
+
+	removed, err = manifest.Select([]string{"crud,pdf"}, []string{"PDF"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if got := strings.Join(FeatureNames(removed), ","); got != "websocket,pdf,frontend" {
+		t.Fatalf("unexpected removed features: %s", got)
+	}
+
+	if _, err := manifest.Select(nil, []string{"graphql"}); err == nil || !strings.Contains(err.Error(), "available") {
+		t.Fatalf("expected unknown feature error, got: %v", err)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if len(manifest.Features) != len(Defaults().Features) {
+		t.Fatalf("expected defaults when manifest is missing")
+	}
+
+	content := `{"features":[{"name":"queue","paths":["app/jobs"],"imports":["app/jobs"]}]}`
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+	manifest, err = LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if names := manifest.Names(); len(names) != 1 || names[0] != "queue" {
+		t.Fatalf("unexpected features: %v", names)
+	}
+}
+
+func TestLoadManifest_Hooks(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+	}
+
+	write(`{"features":[],"hooks":{"pre-move":[{"run":"go run ./scripts/inject"}],"post-env":[{"plugin":"secrets","optional":true}]}}`)
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if len(manifest.Hooks["pre-move"]) != 1 || manifest.Hooks["post-env"][0].Plugin != "secrets" {
+		t.Fatalf("unexpected hooks: %+v", manifest.Hooks)
+	}
+
+	for _, invalid := range []string{
+		`{"hooks":{"pre-clone":[{"run":"true"}]}}`,
+		`{"hooks":{"after-move":[{"run":"true"}]}}`,
+		`{"hooks":{"post-env":[{"run":"true","plugin":"x"}]}}`,
+	} {
+		write(invalid)
+		if _, err := LoadManifest(dir); err == nil {
+			t.Fatalf("expected error for %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/hooks"
+)
+
+// ManifestFile 模板仓库根目录下描述可选功能的清单文件
+const ManifestFile = "goravel-kit.manifest.json"
+
+// Feature 描述一个可以从模板中移除的功能模块。
+// 移除时删除 Paths，剥离对 Imports 的引用，并删除引用了 Symbols 或 Literals 的语句和元素
+type Feature struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Paths 需要删除的文件或目录（相对模板根目录）
+	Paths []string `json:"paths,omitempty"`
+	// Imports 功能包的导入路径（相对 go.mod 中的模块路径），匹配该路径及其子包
+	Imports []string `json:"imports,omitempty"`
+	// Symbols 需要移除调用的限定标识符，如 routes.Ws
+	Symbols []string `json:"symbols,omitempty"`
+	// Literals 引用了这些字符串字面量的语句会被移除，以 / 结尾时按前缀匹配。
+	// 字面量只在 LiteralPaths 中的文件里匹配，避免误删业务代码中恰好相同的字符串
+	Literals []string `json:"literals,omitempty"`
+	// LiteralPaths 匹配 Literals 的文件或目录（相对模板根目录），为空时使用 DefaultLiteralPaths
+	LiteralPaths []string `json:"literal_paths,omitempty"`
+	// Env 需要从 .env.example 和 .env 中删除的配置项，以 _ 结尾时按前缀匹配
+	Env []string `json:"env,omitempty"`
+	// Frontend 属于前端界面的模块，--api-only 时移除
+	Frontend bool `json:"frontend,omitempty"`
+}
+
+// Manifest 模板的功能清单
+type Manifest struct {
+	Features []Feature `json:"features"`
+	// Hooks 模板提供的生成阶段钩子，克隆完成后生效，因此不能包含 pre-clone
+	Hooks hooks.Hooks `json:"hooks,omitempty"`
+}
+
+// DefaultLiteralPaths 功能未声明 LiteralPaths 时匹配字面量的位置：路由注册和配置文件
+var DefaultLiteralPaths = []string{"routes", "config"}
+
+// Defaults 模板未提供清单时使用的内置功能描述
+func Defaults() *Manifest {
+	return &Manifest{Features: []Feature{
+		{
+			Name:        "websocket",
+			Description: "WebSocket server (goravel-socket)",
+			Paths:       []string{"packages/goravel-socket", "config/websocket.go"},
+			Imports:     []string{"packages/goravel-socket"},
+			Literals:    []string{"ws", "/ws", "api/ws/"},
+			Env:         []string{"WS_", "WEBSOCKET_"},
+		},
+		{
+			Name:        "pdf",
+			Description: "PDF generator (goravel_pdf_gen)",
+			Paths:       []string{"packages/goravel_pdf_gen", "config/pdf.go"},
+			Imports:     []string{"packages/goravel_pdf_gen"},
+			Literals:    []string{"pdf_design", "/pdf_design", "pdf_prefix/"},
+			Env:         []string{"PDF_"},
+		},
+		{
+			Name:        "crud",
+			Description: "CRUD code generator and Swagger UI (goravel_crud)",
+			Paths:       []string{"packages/goravel_crud", "config/crud.go"},
+			Imports:     []string{"packages/goravel_crud"},
+			Literals:    []string{"crud", "/crud", "crud/", "/crud/", "panel", "/panel"},
+			Env:         []string{"CRUD_"},
+		},
+		{
+			Name:        "frontend",
+			Description: "Vue 3 admin frontend, its dist/assets static routes and SPA fallback",
+			Paths:       []string{"frontend", "public/dist", "resources/views/index.tmpl"},
+			Literals: []string{
+				"./frontend", "frontend", "./frontend/", "frontend/",
+				"dist", "/dist", "./dist/", "assets", "/assets", "./assets/",
+				"index.html", "index.tmpl", "./public/dist/", "public/dist/",
+			},
+			Env:      []string{"VITE_", "FRONTEND_"},
+			Frontend: true,
+		},
+	}}
+}
+
+// LoadManifest 读取模板中的功能清单，模板未提供清单时返回内置描述
+func LoadManifest(dir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return Defaults(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	for _, feature := range manifest.Features {
+		if feature.Name == "" {
+			return nil, fmt.Errorf("%s: feature without name", ManifestFile)
+		}
+	}
+	if err := manifest.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if len(manifest.Hooks[hooks.Key(hooks.Pre, hooks.StageClone)]) > 0 {
+		return nil, fmt.Errorf("%s: pre-clone hooks can only be set in the config file", ManifestFile)
+	}
+	return manifest, nil
+}
+
+// Names 返回清单中所有功能的名称
+func (m *Manifest) Names() []string {
+	names := make([]string, 0, len(m.Features))
+	for _, feature := range m.Features {
+		names = append(names, feature.Name)
+	}
+	return names
+}
+
+// Lookup 按名称查找功能
+func (m *Manifest) Lookup(name string) (Feature, bool) {
+	for _, feature := range m.Features {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return Feature{}, false
+}
+
+// FrontendFeatures 返回属于前端界面的模块名称
+func (m *Manifest) FrontendFeatures() []string {
+	var names []string
+	for _, feature := range m.Features {
+		if feature.Frontend {
+			names = append(names, feature.Name)
+		}
+	}
+	return names
+}
+
+// Select 根据 --features（保留的功能）和 --without（移除的功能）计算需要移除的功能。
+// include 为空时保留所有功能；同时指定时先按 include 保留，再从中移除 exclude
+func (m *Manifest) Select(include, exclude []string) ([]Feature, error) {
+	include, exclude = normalize(include), normalize(exclude)
+	for _, name := range append(append([]string{}, include...), exclude...) {
+		if _, ok := m.Lookup(name); !ok {
+			return nil, fmt.Errorf("unknown feature %q (available: %s)", name, strings.Join(m.Names(), ", "))
+		}
+	}
+
+	keep := map[string]bool{}
+	for _, feature := range m.Features {
+		keep[feature.Name] = len(include) == 0
+	}
+	for _, name := range include {
+		keep[name] = true
+	}
+	for _, name := range exclude {
+		keep[name] = false
+	}
+
+	var removed []Feature
+	for _, feature := range m.Features {
+		if !keep[feature.Name] {
+			removed = append(removed, feature)
+		}
+	}
+	return removed, nil
+}
+
+// Resolve 按名称查找需要移除的功能，用于根据项目元信息重建模板
+func (m *Manifest) Resolve(names []string) ([]Feature, error) {
+	var resolved []Feature
+	for _, name := range normalize(names) {
+		feature, ok := m.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown feature %q (available: %s)", name, strings.Join(m.Names(), ", "))
+		}
+		resolved = append(resolved, feature)
+	}
+	return resolved, nil
+}
+
+// FeatureNames 返回功能名称列表
+func FeatureNames(features []Feature) []string {
+	names := make([]string, 0, len(features))
+	for _, feature := range features {
+		names = append(names, feature.Name)
+	}
+	return names
+}
+
+// normalize 拆分逗号分隔的名称，去除空白、空项和重复项
+func normalize(names []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, item := range names {
+		for _, name := range strings.Split(item, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,822 @@
+package features
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Report 移除功能后的改动摘要
+type Report struct {
+	// RemovedPaths 已删除的文件或目录
+	RemovedPaths []string `json:"removed_paths"`
+	// ModifiedFiles 被改写的源文件和配置文件
+	ModifiedFiles []string `json:"modified_files"`
+}
+
+var modulePattern = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+
+// Remove 从 dir 中的模板移除指定功能：删除功能目录，剥离 Go 源码中对功能包的导入、
+// 路由注册、服务提供者和配置项，并删除相关环境变量，使剩余代码仍可编译
+func Remove(dir string, features []Feature) (*Report, error) {
+	report := &Report{}
+	if len(features) == 0 {
+		return report, nil
+	}
+
+	module, err := modulePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &rules{symbols: map[string]bool{}}
+	var envKeys []string
+	for _, feature := range features {
+		for _, imp := range feature.Imports {
+			rules.imports = append(rules.imports, path.Join(module, imp))
+		}
+		for _, symbol := range feature.Symbols {
+			rules.symbols[symbol] = true
+		}
+		literalPaths := feature.LiteralPaths
+		if len(literalPaths) == 0 {
+			literalPaths = DefaultLiteralPaths
+		}
+		for _, literal := range feature.Literals {
+			rules.literals = append(rules.literals, literalRule{value: literal, paths: literalPaths})
+		}
+		envKeys = append(envKeys, feature.Env...)
+	}
+	// 导入的包名可能与目录名不同，在删除目录前解析
+	rules.packageNames = resolvePackageNames(dir, module, rules.imports)
+
+	for _, feature := range features {
+		for _, rel := range feature.Paths {
+			target, err := within(dir, rel)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := os.Lstat(target); os.IsNotExist(err) {
+				continue
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return nil, err
+			}
+			report.RemovedPaths = append(report.RemovedPaths, filepath.ToSlash(rel))
+		}
+	}
+
+	modified, err := pruneTree(dir, rules)
+	if err != nil {
+		return nil, err
+	}
+	report.ModifiedFiles = append(report.ModifiedFiles, modified...)
+
+	for _, name := range []string{".env.example", ".env"} {
+		changed, err := removeEnvKeys(filepath.Join(dir, name), envKeys)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			report.ModifiedFiles = append(report.ModifiedFiles, name)
+		}
+	}
+	sort.Strings(report.ModifiedFiles)
+	return report, nil
+}
+
+// modulePath 读取 go.mod 中的模块路径
+func modulePath(dir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("read go.mod: %w", err)
+	}
+	match := modulePattern.FindSubmatch(content)
+	if match == nil {
+		return "", fmt.Errorf("go.mod: module path not found")
+	}
+	return string(match[1]), nil
+}
+
+// within 将相对路径解析到 dir 内，拒绝跳出 dir 的路径
+func within(dir, rel string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid feature path %q", rel)
+	}
+	return filepath.Join(dir, clean), nil
+}
+
+// skipDir 不需要扫描 Go 源码的目录
+func skipDir(name string) bool {
+	return name == "node_modules" || name == "vendor" || (strings.HasPrefix(name, ".") && name != ".")
+}
+
+// resolvePackageNames 读取模块内被移除包的 package 声明，得到导入时的默认包名
+func resolvePackageNames(dir, module string, imports []string) map[string]string {
+	names := map[string]string{}
+	for _, imp := range imports {
+		root := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(imp, module), "/")))
+		filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, filepath.Dir(p))
+			if err != nil {
+				return nil
+			}
+			importPath := path.Join(module, filepath.ToSlash(rel))
+			if _, ok := names[importPath]; ok {
+				return nil
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), p, nil, parser.PackageClauseOnly)
+			if err == nil {
+				names[importPath] = file.Name.Name
+			}
+			return nil
+		})
+	}
+	return names
+}
+
+// pruneTree 改写 dir 中所有引用了被移除功能的 Go 源文件，返回改写的文件列表
+func pruneTree(dir string, rules *rules) ([]string, error) {
+	packages := map[string][]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if skipDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(p, ".go") {
+			packages[filepath.Dir(p)] = append(packages[filepath.Dir(p)], p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var modified []string
+	for _, files := range packages {
+		changed, err := prunePackage(dir, files, rules)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range changed {
+			rel, _ := filepath.Rel(dir, file)
+			modified = append(modified, filepath.ToSlash(rel))
+		}
+	}
+	return modified, nil
+}
+
+// prunePackage 改写同一目录（同一个包）中的源文件。
+// 被移除的顶层声明在同包的其他文件中也视为需要移除的引用
+func prunePackage(dir string, paths []string, rules *rules) ([]string, error) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(paths))
+	for i, p := range paths {
+		file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = file
+	}
+
+	prunes := make([]*filePruner, len(files))
+	topLevel := map[string]bool{}
+	for i, file := range files {
+		rel, _ := filepath.Rel(dir, paths[i])
+		prunes[i] = newFilePruner(fset, file, rules, filepath.ToSlash(rel))
+	}
+	// 反复移除顶层声明，直到没有新的声明依赖已移除的名称
+	for {
+		progress := false
+		for _, p := range prunes {
+			for _, name := range p.pruneDecls(topLevel) {
+				topLevel[name] = true
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+
+	var changed []string
+	for i, p := range prunes {
+		p.pruneBodies(topLevel)
+		if !p.changed {
+			continue
+		}
+		p.removeUnusedImports()
+		p.removeComments()
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, files[i]); err != nil {
+			return nil, fmt.Errorf("%s: %w", paths[i], err)
+		}
+		info, err := os.Stat(paths[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(paths[i], buf.Bytes(), info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		changed = append(changed, paths[i])
+	}
+	return changed, nil
+}
+
+// removeEnvKeys 删除环境变量文件中的配置项，文件不存在时忽略
+func removeEnvKeys(path string, keys []string) (bool, error) {
+	if len(keys) == 0 {
+		return false, nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var out bytes.Buffer
+	changed := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		key, _, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found && matchEnvKey(strings.TrimSpace(key), keys) {
+			changed = true
+			continue
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, os.WriteFile(path, out.Bytes(), 0644)
+}
+
+func matchEnvKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if key == pattern || (strings.HasSuffix(pattern, "_") && strings.HasPrefix(key, pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rules 移除代码的匹配规则
+type rules struct {
+	imports      []string
+	packageNames map[string]string
+	symbols      map[string]bool
+	literals     []literalRule
+}
+
+// literalRule 一个需要移除的字符串字面量及其生效的文件范围
+type literalRule struct {
+	value string
+	paths []string
+}
+
+// matchImport 判断导入路径是否属于被移除的包
+func (r *rules) matchImport(importPath string) bool {
+	for _, imp := range r.imports {
+		if importPath == imp || strings.HasPrefix(importPath, imp+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLiteral 判断文件 rel（相对模板根目录）中的字符串字面量是否引用了被移除的功能
+func (r *rules) matchLiteral(rel, value string) bool {
+	for _, literal := range r.literals {
+		if !inPaths(rel, literal.paths) {
+			continue
+		}
+		if value == literal.value || (strings.HasSuffix(literal.value, "/") && strings.HasPrefix(value, literal.value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPaths 判断 rel 是否是 paths 中的某个文件或位于其中的某个目录下
+func inPaths(rel string, paths []string) bool {
+	for _, p := range paths {
+		p = strings.TrimSuffix(path.Clean(p), "/")
+		if rel == p || strings.HasPrefix(rel, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// filePruner 移除单个源文件中对被移除功能的引用
+type filePruner struct {
+	fset  *token.FileSet
+	file  *ast.File
+	rules *rules
+	// rel 源文件相对模板根目录的路径，用于判断字面量规则是否生效
+	rel     string
+	pkgs    map[string]bool
+	changed bool
+	// dropped 已移除节点的位置范围，用于同时删除这些节点上的注释
+	dropped []span
+}
+
+// span 源码中的一段位置范围
+type span struct {
+	pos, end token.Pos
+}
+
+func newFilePruner(fset *token.FileSet, file *ast.File, rules *rules, rel string) *filePruner {
+	p := &filePruner{fset: fset, file: file, rules: rules, rel: rel, pkgs: map[string]bool{}}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if !rules.matchImport(importPath) {
+			continue
+		}
+		name := rules.packageNames[importPath]
+		if name == "" {
+			name = path.Base(importPath)
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		p.pkgs[name] = true
+		p.drop(spec)
+	}
+	if p.changed {
+		p.file.Decls = filterImports(p.file.Decls, func(spec *ast.ImportSpec) bool {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			return !rules.matchImport(importPath)
+		})
+	}
+	return p
+}
+
+// pruneDecls 移除签名或定义引用了被移除功能的顶层声明，返回新移除的声明名称
+func (p *filePruner) pruneDecls(removed map[string]bool) []string {
+	var names []string
+	decls := p.file.Decls[:0]
+	for _, decl := range p.file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if p.fieldsRef(decl.Recv, removed) || p.fieldsRef(decl.Type.Params, removed) || p.fieldsRef(decl.Type.Results, removed) {
+				if decl.Recv == nil {
+					names = append(names, decl.Name.Name)
+				}
+				p.drop(decl)
+				continue
+			}
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				break
+			}
+			specs := decl.Specs[:0]
+			for _, spec := range decl.Specs {
+				// 结构体只移除引用了功能的字段，保留类型本身
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						p.pruneFields(structType.Fields, removed)
+						specs = append(specs, spec)
+						continue
+					}
+				}
+				if p.refs(spec, removed) {
+					names = append(names, specNames(spec)...)
+					p.drop(spec)
+					continue
+				}
+				specs = append(specs, spec)
+			}
+			decl.Specs = specs
+			if len(specs) == 0 {
+				p.drop(decl)
+				continue
+			}
+		}
+		decls = append(decls, decl)
+	}
+	p.file.Decls = decls
+	return names
+}
+
+// pruneBodies 移除函数体和变量初始化中引用了被移除功能的语句和复合字面量元素
+func (p *filePruner) pruneBodies(removed map[string]bool) {
+	for _, decl := range p.file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Body != nil {
+				decl.Body.List = p.pruneBlock(decl.Body.List, removed)
+			}
+		case *ast.GenDecl:
+			if decl.Tok != token.IMPORT {
+				p.pruneNested(decl, removed)
+			}
+		}
+	}
+}
+
+// pruneBlock 移除语句列表中引用了被移除功能的语句。
+// 被移除的语句定义的局部变量在后续语句中同样视为需要移除的引用
+func (p *filePruner) pruneBlock(list []ast.Stmt, removed map[string]bool) []ast.Stmt {
+	scope := removed
+	copied := false
+	defined := map[string]bool{}
+	var removedStmts []ast.Stmt
+	kept := list[:0]
+	for _, stmt := range list {
+		if clause, ok := stmt.(*ast.CaseClause); ok {
+			if p.refs(&ast.CompositeLit{Elts: clause.List}, scope) {
+				p.drop(clause)
+				continue
+			}
+			clause.Body = p.pruneBlock(clause.Body, scope)
+			kept = append(kept, stmt)
+			continue
+		}
+		if clause, ok := stmt.(*ast.CommClause); ok {
+			clause.Body = p.pruneBlock(clause.Body, scope)
+			kept = append(kept, stmt)
+			continue
+		}
+
+		if p.refs(stmt, scope) {
+			p.drop(stmt)
+			removedStmts = append(removedStmts, stmt)
+			for _, name := range definedNames(stmt) {
+				if !defined[name] {
+					if !copied {
+						scope, copied = copySet(removed), true
+					}
+					scope[name] = true
+				}
+			}
+			continue
+		}
+		for _, name := range definedNames(stmt) {
+			defined[name] = true
+		}
+		p.pruneNested(stmt, scope)
+		kept = append(kept, stmt)
+	}
+	if len(removedStmts) > 0 {
+		blankUnusedLocals(kept, removedStmts)
+	}
+	return kept
+}
+
+// pruneNested 处理语句内嵌套的代码块和复合字面量
+func (p *filePruner) pruneNested(node ast.Node, removed map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			n.List = p.pruneBlock(n.List, removed)
+			return false
+		case *ast.CompositeLit:
+			elts := n.Elts[:0]
+			for _, elt := range n.Elts {
+				if p.refs(elt, removed) {
+					p.drop(elt)
+					continue
+				}
+				elts = append(elts, elt)
+			}
+			n.Elts = elts
+		}
+		return true
+	})
+}
+
+// refs 判断节点是否引用了被移除的包、标识符或字符串字面量。
+// 不进入嵌套的代码块和复合字面量的元素，它们由 pruneNested 单独处理
+func (p *filePruner) refs(node ast.Node, removed map[string]bool) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			return n == node
+		case *ast.CompositeLit:
+			if n != node {
+				if n.Type != nil && p.refs(n.Type, removed) {
+					found = true
+				}
+				return false
+			}
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok {
+				if p.pkgs[x.Name] || p.rules.symbols[x.Name+"."+n.Sel.Name] || removed[x.Name] {
+					found = true
+				}
+				return false
+			}
+			// 只检查选择器左侧，字段名和方法名不是变量引用
+			found = p.refs(n.X, removed)
+			return false
+		case *ast.KeyValueExpr:
+			// 结构体字面量的字段名不是变量引用
+			if _, ok := n.Key.(*ast.Ident); ok {
+				found = p.refs(n.Value, removed)
+				return false
+			}
+		case *ast.Ident:
+			if removed[n.Name] {
+				found = true
+			}
+		case *ast.BasicLit:
+			if n.Kind == token.STRING {
+				if value, err := strconv.Unquote(n.Value); err == nil && p.rules.matchLiteral(p.rel, value) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// fieldsRef 判断参数、返回值或接收者列表的类型是否引用了被移除的功能
+func (p *filePruner) fieldsRef(fields *ast.FieldList, removed map[string]bool) bool {
+	if fields == nil {
+		return false
+	}
+	for _, field := range fields.List {
+		if p.refs(field.Type, removed) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneFields 移除结构体中类型引用了被移除功能的字段
+func (p *filePruner) pruneFields(fields *ast.FieldList, removed map[string]bool) {
+	if fields == nil {
+		return
+	}
+	kept := fields.List[:0]
+	for _, field := range fields.List {
+		if p.refs(field.Type, removed) {
+			p.drop(field)
+			continue
+		}
+		kept = append(kept, field)
+	}
+	fields.List = kept
+}
+
+// drop 记录被移除的节点
+func (p *filePruner) drop(node ast.Node) {
+	p.changed = true
+	p.dropped = append(p.dropped, span{node.Pos(), node.End()})
+}
+
+// removeComments 删除位于已移除节点内部、同一行末尾或紧邻其上方的注释，
+// 并合并已移除内容所占的行，避免格式化后留下空行
+func (p *filePruner) removeComments() {
+	var orphans []*ast.CommentGroup
+	comments := p.file.Comments[:0]
+	for _, group := range p.file.Comments {
+		if p.orphaned(group) {
+			orphans = append(orphans, group)
+			continue
+		}
+		comments = append(comments, group)
+	}
+	p.file.Comments = comments
+	p.compactLines(orphans)
+}
+
+func (p *filePruner) orphaned(group *ast.CommentGroup) bool {
+	groupStart := p.fset.Position(group.Pos()).Line
+	groupEnd := p.fset.Position(group.End()).Line
+	for _, s := range p.dropped {
+		if group.Pos() >= s.pos && group.End() <= s.end {
+			return true
+		}
+		start := p.fset.Position(s.pos).Line
+		end := p.fset.Position(s.end).Line
+		if groupEnd == start-1 || groupStart == end && group.Pos() >= s.end {
+			return true
+		}
+	}
+	return false
+}
+
+// compactLines 将已移除节点及其上方注释所在的行与上一行合并，
+// 格式化时这些行不再产生空行
+func (p *filePruner) compactLines(orphans []*ast.CommentGroup) {
+	tokenFile := p.fset.File(p.file.Pos())
+	if tokenFile == nil {
+		return
+	}
+	lines := map[int]bool{}
+	for _, s := range p.dropped {
+		start := p.fset.Position(s.pos).Line
+		end := p.fset.Position(s.end).Line
+		for extended := true; extended; {
+			extended = false
+			for _, group := range orphans {
+				if p.fset.Position(group.End()).Line == start-1 {
+					start = p.fset.Position(group.Pos()).Line
+					extended = true
+				}
+			}
+		}
+		for line := start; line <= end; line++ {
+			lines[line] = true
+		}
+	}
+
+	sorted := make([]int, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	for _, line := range sorted {
+		if line > 1 && line <= tokenFile.LineCount() {
+			tokenFile.MergeLine(line - 1)
+		}
+	}
+}
+
+// removeUnusedImports 删除改写后不再使用的导入，避免编译错误
+func (p *filePruner) removeUnusedImports() {
+	used := map[string]bool{}
+	ast.Inspect(p.file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+	p.file.Decls = filterImports(p.file.Decls, func(spec *ast.ImportSpec) bool {
+		if spec.Name != nil && (spec.Name.Name == "_" || spec.Name.Name == ".") {
+			return true
+		}
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if strings.HasPrefix(name, "v") && strings.Trim(name[1:], "0123456789") == "" {
+			// 形如 example.com/pkg/v2 的导入，包名为倒数第二段
+			name = path.Base(path.Dir(importPath))
+		}
+		// 无法确定包名（如 go-xxx）时保守地保留导入
+		return used[name] || strings.ContainsAny(name, "-.")
+	})
+}
+
+// filterImports 按 keep 过滤导入声明，并同步 file.Imports 以外的声明列表
+func filterImports(decls []ast.Decl, keep func(*ast.ImportSpec) bool) []ast.Decl {
+	result := decls[:0]
+	for _, decl := range decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			result = append(result, decl)
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			if keep(spec.(*ast.ImportSpec)) {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) == 0 {
+			continue
+		}
+		if len(specs) == 1 && gen.Lparen.IsValid() {
+			gen.Lparen, gen.Rparen = token.NoPos, token.NoPos
+		}
+		result = append(result, gen)
+	}
+	return result
+}
+
+// specNames 返回顶层声明定义的名称
+func specNames(spec ast.Spec) []string {
+	var names []string
+	switch spec := spec.(type) {
+	case *ast.ValueSpec:
+		for _, name := range spec.Names {
+			names = append(names, name.Name)
+		}
+	case *ast.TypeSpec:
+		names = append(names, spec.Name.Name)
+	}
+	return names
+}
+
+// definedNames 返回语句中用 := 或 var 定义的局部变量名称
+func definedNames(stmt ast.Stmt) []string {
+	var names []string
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok == token.DEFINE {
+			for _, lhs := range stmt.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name != "_" {
+					names = append(names, ident.Name)
+				}
+			}
+		}
+	case *ast.DeclStmt:
+		if gen, ok := stmt.Decl.(*ast.GenDecl); ok {
+			for _, spec := range gen.Specs {
+				names = append(names, specNames(spec)...)
+			}
+		}
+	}
+	return names
+}
+
+// blankUnusedLocals 被移除的语句可能是某个局部变量唯一的使用者，
+// 将这类变量替换为 _，保留右侧表达式的副作用，避免 "declared and not used"
+func blankUnusedLocals(kept, removedStmts []ast.Stmt) {
+	candidates := map[string]bool{}
+	for _, stmt := range removedStmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				candidates[ident.Name] = true
+			}
+			return true
+		})
+	}
+
+	for i, stmt := range kept {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE {
+			continue
+		}
+		blanks := 0
+		for _, lhs := range assign.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if ident.Name != "_" && candidates[ident.Name] && !usesIdent(kept[i+1:], ident.Name) {
+				ident.Name = "_"
+			}
+			if ident.Name == "_" {
+				blanks++
+			}
+		}
+		if blanks == len(assign.Lhs) {
+			assign.Tok = token.ASSIGN
+		}
+	}
+}
+
+// usesIdent 判断语句列表中是否使用了指定名称
+func usesIdent(stmts []ast.Stmt, name string) bool {
+	used := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+				used = true
+			}
+			return !used
+		})
+		if used {
+			return true
+		}
+	}
+	return false
+}
+
+func copySet(set map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(set)+1)
+	for key, value := range set {
+		copied[key] = value
+	}
+	return copied
+}
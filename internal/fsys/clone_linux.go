@@ -0,0 +1,28 @@
+//go:build linux
+
+package fsys
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// copyContents 将 src 的内容写入 dst，返回写入的字节数。
+// 两端都是真实文件时先尝试 FICLONE（btrfs、XFS 等支持 reflink 的文件系统上只共享数据块，不实际复制），
+// 不支持时退回 io.Copy，此时 *os.File.ReadFrom 会使用 copy_file_range 在内核中完成复制
+func copyContents(dst io.Writer, src io.Reader) (int64, error) {
+	if out, ok := dst.(*os.File); ok {
+		if in, ok := src.(*os.File); ok {
+			if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err == nil {
+				info, err := out.Stat()
+				if err != nil {
+					return 0, err
+				}
+				return info.Size(), nil
+			}
+		}
+	}
+	return io.Copy(dst, src)
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package fsys
+
+import "io"
+
+// copyContents 将 src 的内容写入 dst，返回写入的字节数
+func copyContents(dst io.Writer, src io.Reader) (int64, error) {
+	return io.Copy(dst, src)
+}
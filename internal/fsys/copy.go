@@ -0,0 +1,203 @@
+package fsys
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency 默认同时复制的文件数。模板以大量小文件为主，复制耗时主要在系统调用和磁盘等待上，
+// 并发数可以高于 CPU 核数
+const DefaultConcurrency = 8
+
+// CopyOptions 复制目录树的选项
+type CopyOptions struct {
+	// Concurrency 同时复制的文件数，小于等于 0 时使用 DefaultConcurrency，为 1 时按遍历顺序逐个复制
+	Concurrency int
+	// Progress 每复制完一个普通文件后调用，path 为相对源目录的路径。
+	// 并发复制时调用顺序不固定，但不会同时调用，回调中无需加锁
+	Progress func(path string, size int64)
+}
+
+// CopyStats 复制目录树的统计结果
+type CopyStats struct {
+	Files    int64
+	Dirs     int64
+	Symlinks int64
+	// Bytes 普通文件实际写入的字节数
+	Bytes int64
+	// Skipped 未复制的管道、套接字和设备等特殊文件，为相对源目录的路径
+	Skipped []string
+}
+
+// dirAttrs 复制完内容后需要恢复的目录属性
+type dirAttrs struct {
+	path    string
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// copyJob 交给复制协程的一个普通文件
+type copyJob struct {
+	src, dst, rel string
+}
+
+// CopyTree 将 src 目录树复制到 dst：
+//   - 符号链接按原来的链接目标重新创建，不跟随链接复制其内容
+//   - 普通文件保留权限位（包括 setuid、setgid、sticky）和修改时间，
+//     由 opts.Concurrency 个协程并发复制，目录和符号链接在遍历时按顺序创建
+//   - 目录先以 0700 创建以便写入内容，全部内容复制完成后再恢复原来的权限和修改时间
+//   - 管道、套接字和设备等特殊文件不复制，记录在 CopyStats.Skipped 中
+//
+// 任一文件复制失败后不再分发新的文件，等待进行中的复制结束后返回第一个错误
+func CopyTree(fsys FS, src, dst string, opts CopyOptions) (CopyStats, error) {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+
+	// mu 保护 stats、firstErr，同时保证 Progress 不会被并发调用
+	var (
+		mu       sync.Mutex
+		stats    CopyStats
+		firstErr error
+	)
+	failed := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr
+	}
+
+	jobs := make(chan copyJob)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if failed() != nil {
+					continue
+				}
+				n, err := CopyFile(fsys, job.src, job.dst)
+				mu.Lock()
+				stats.Bytes += n
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					stats.Files++
+					if opts.Progress != nil {
+						opts.Progress(job.rel, n)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var dirs []dirAttrs
+	err := Walk(fsys, src, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := failed(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		mode := info.Mode()
+
+		switch {
+		case mode.IsDir():
+			// 目录在分发其中的文件之前创建，复制协程写入时父目录一定已经存在
+			if err := fsys.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirAttrs{path: target, mode: mode, modTime: info.ModTime()})
+			mu.Lock()
+			stats.Dirs++
+			mu.Unlock()
+		case mode&fs.ModeSymlink != 0:
+			link, err := fsys.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := fsys.Symlink(link, target); err != nil {
+				return err
+			}
+			mu.Lock()
+			stats.Symlinks++
+			mu.Unlock()
+		case mode.IsRegular():
+			jobs <- copyJob{src: path, dst: target, rel: rel}
+		default:
+			// 读取管道会一直阻塞，设备文件的内容也不属于模板，只记录不复制
+			mu.Lock()
+			stats.Skipped = append(stats.Skipped, rel)
+			mu.Unlock()
+		}
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+	if err == nil {
+		err = firstErr
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	// 由深到浅恢复目录属性：写入内容会更新目录的修改时间，只读目录也无法再写入子目录
+	for i := len(dirs) - 1; i >= 0; i-- {
+		dir := dirs[i]
+		if err := fsys.Chmod(dir.path, dir.mode); err != nil {
+			return stats, err
+		}
+		if err := fsys.Chtimes(dir.path, time.Time{}, dir.modTime); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// CopyFile 复制单个普通文件，保留权限位和修改时间，返回写入的字节数。
+// 写入的字节数与源文件大小不一致（如源文件在复制过程中被修改）时返回错误
+func CopyFile(fsys FS, src, dst string) (int64, error) {
+	info, err := fsys.Stat(src)
+	if err != nil {
+		return 0, err
+	}
+	in, err := fsys.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	// 先以仅自己可写的权限创建，内容写完后再设置原权限，只读文件也能正常写入
+	out, err := fsys.Create(dst, 0600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := copyContents(out, in)
+	// 关闭时的错误（如延迟写入失败）同样视为复制失败
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, err
+	}
+	if n != info.Size() {
+		return n, fmt.Errorf("%s: copied %d of %d bytes", src, n, info.Size())
+	}
+
+	if err := fsys.Chmod(dst, info.Mode()); err != nil {
+		return n, err
+	}
+	return n, fsys.Chtimes(dst, time.Time{}, info.ModTime())
+}
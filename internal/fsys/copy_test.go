@@ -0,0 +1,471 @@
+package fsys
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	data := []byte("hello world")
+	if err := os.WriteFile(src, data, 0600); err != nil {
+		t.Fatalf("failed to write src: %v", err)
+	}
+	mtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+
+	n, err := CopyFile(OS, src, dst)
+	if err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("expected %d bytes copied, got %d", len(data), n)
+	}
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("failed to read dst: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("copied content mismatch: got=%q want=%q", string(got), string(data))
+	}
+
+	srcInfo, _ := os.Stat(src)
+	dstInfo, _ := os.Stat(dst)
+	if dstInfo.Mode() != srcInfo.Mode() {
+		t.Fatalf("expected file mode copied, got %v want %v", dstInfo.Mode(), srcInfo.Mode())
+	}
+	if !dstInfo.ModTime().Equal(mtime) {
+		t.Fatalf("expected mtime %v, got %v", mtime, dstInfo.ModTime())
+	}
+}
+
+// TestCopyFile_PreservesModes 移植自 commands 包原有的 TestCopyFile：
+// 单个文件和整个目录的复制都保留内容和权限位，包括只读和可执行文件
+func TestCopyFile_PreservesModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows does not support unix permission bits")
+	}
+	for _, mode := range []fs.FileMode{0400, 0600, 0644, 0755} {
+		t.Run(mode.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "src")
+			if err := os.Mkdir(src, 0755); err != nil {
+				t.Fatalf("failed to create src: %v", err)
+			}
+			data := []byte("hello world")
+			if err := os.WriteFile(filepath.Join(src, "file.txt"), data, 0600); err != nil {
+				t.Fatalf("failed to write src: %v", err)
+			}
+			if err := os.Chmod(filepath.Join(src, "file.txt"), mode); err != nil {
+				t.Fatalf("failed to chmod src: %v", err)
+			}
+
+			if _, err := CopyFile(OS, filepath.Join(src, "file.txt"), filepath.Join(dir, "copy.txt")); err != nil {
+				t.Fatalf("CopyFile failed: %v", err)
+			}
+			if _, err := CopyTree(OS, src, filepath.Join(dir, "tree"), CopyOptions{}); err != nil {
+				t.Fatalf("CopyTree failed: %v", err)
+			}
+
+			for _, dst := range []string{filepath.Join(dir, "copy.txt"), filepath.Join(dir, "tree", "file.txt")} {
+				got, err := os.ReadFile(dst)
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", dst, err)
+				}
+				if string(got) != string(data) {
+					t.Fatalf("copied content mismatch in %s: got=%q want=%q", dst, got, data)
+				}
+				info, err := os.Stat(dst)
+				if err != nil {
+					t.Fatalf("failed to stat %s: %v", dst, err)
+				}
+				if info.Mode().Perm() != mode {
+					t.Fatalf("expected %s to keep mode %v, got %v", dst, mode, info.Mode().Perm())
+				}
+			}
+		})
+	}
+}
+
+func TestCopyFile_PartialWrite(t *testing.T) {
+	mem := NewMemFS()
+	if err := mem.WriteFile("/src.go", []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	mem.Capacity = mem.Used() + 4
+
+	n, err := CopyFile(mem, "/src.go", "/dst.go")
+	if !errors.Is(err, syscall.ENOSPC) || n != 4 {
+		t.Fatalf("expected ENOSPC after 4 bytes, got %d %v", n, err)
+	}
+	content, _ := mem.ReadFile("/dst.go")
+	if string(content) != "pack" {
+		t.Fatalf("expected partial content to be visible, got %q", content)
+	}
+}
+
+// truncatingFS 读取时只返回文件的前半部分，模拟复制过程中源文件被截断
+type truncatingFS struct {
+	*MemFS
+}
+
+func (f truncatingFS) Open(name string) (io.ReadCloser, error) {
+	info, err := f.MemFS.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := f.MemFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(io.LimitReader(r, info.Size()/2)), nil
+}
+
+func TestCopyFile_DetectsShortCopy(t *testing.T) {
+	mem := NewMemFS()
+	if err := mem.WriteFile("/src.go", []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := CopyFile(truncatingFS{mem}, "/src.go", "/dst.go")
+	if err == nil || !strings.Contains(err.Error(), "copied 6 of 13 bytes") {
+		t.Fatalf("expected short copy to be detected, got %v", err)
+	}
+}
+
+func TestCopyTree_PreservesSymlinksModesAndTimes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks and permission bits are not portable to Windows")
+	}
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	dst := filepath.Join(root, "dst")
+	mtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for name, content := range map[string]string{
+		"main.go":       "package main\n",
+		"bin/artisan":   "#!/bin/sh\n",
+		"config/app.go": "package config\n",
+	} {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(src, "bin", "artisan"), 0755); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	for link, target := range map[string]string{
+		"entry.go":        "main.go",
+		"settings":        "config",
+		"dangling":        "missing.txt",
+		"config/absolute": filepath.Join(src, "main.go"),
+	} {
+		if err := os.Symlink(target, filepath.Join(src, link)); err != nil {
+			t.Fatalf("Symlink failed: %v", err)
+		}
+	}
+	for _, path := range []string{"main.go", "bin/artisan", "config/app.go", "bin", "config", "."} {
+		if err := os.Chtimes(filepath.Join(src, path), mtime, mtime); err != nil {
+			t.Fatalf("Chtimes failed: %v", err)
+		}
+	}
+	// 只读目录的权限需要在写入内容之后才能应用
+	if err := os.Chmod(filepath.Join(src, "config"), 0555); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	t.Cleanup(func() {
+		os.Chmod(filepath.Join(src, "config"), 0755)
+		os.Chmod(filepath.Join(dst, "config"), 0755)
+	})
+
+	var progressed []string
+	stats, err := CopyTree(OS, src, dst, CopyOptions{
+		Concurrency: 1,
+		Progress:    func(path string, size int64) { progressed = append(progressed, filepath.ToSlash(path)) },
+	})
+	if err != nil {
+		t.Fatalf("CopyTree failed: %v", err)
+	}
+	if stats.Files != 3 || stats.Dirs != 3 || stats.Symlinks != 4 || stats.Bytes != 38 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if strings.Join(progressed, ",") != "bin/artisan,config/app.go,main.go" {
+		t.Fatalf("unexpected progress callbacks: %v", progressed)
+	}
+
+	for link, target := range map[string]string{
+		"entry.go":        "main.go",
+		"settings":        "config",
+		"dangling":        "missing.txt",
+		"config/absolute": filepath.Join(src, "main.go"),
+	} {
+		info, err := os.Lstat(filepath.Join(dst, link))
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+			t.Fatalf("expected %s to be a symlink, got %v %v", link, info, err)
+		}
+		if got, _ := os.Readlink(filepath.Join(dst, link)); got != target {
+			t.Fatalf("expected %s -> %s, got %s", link, target, got)
+		}
+	}
+	if info, _ := os.Stat(filepath.Join(dst, "bin", "artisan")); info.Mode().Perm() != 0755 {
+		t.Fatalf("expected executable bit to be preserved, got %v", info.Mode())
+	}
+	if info, _ := os.Stat(filepath.Join(dst, "config")); info.Mode().Perm() != 0555 {
+		t.Fatalf("expected read-only dir mode to be applied, got %v", info.Mode())
+	}
+	for _, path := range []string{"main.go", "bin/artisan", "config/app.go", "bin", "config", "."} {
+		info, err := os.Stat(filepath.Join(dst, path))
+		if err != nil || !info.ModTime().Equal(mtime) {
+			t.Fatalf("expected %s mtime %v, got %v %v", path, mtime, info.ModTime(), err)
+		}
+	}
+}
+
+func TestCopyTree_SkipsSpecialFiles(t *testing.T) {
+	mem := NewMemFS()
+	if err := mem.MkdirAll("/src/run", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.WriteFile("/src/main.go", []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	for name, mode := range map[string]fs.FileMode{
+		"/src/run/queue":    fs.ModeNamedPipe | 0644,
+		"/src/run/app.sock": fs.ModeSocket | 0755,
+		"/src/run/null":     fs.ModeDevice | fs.ModeCharDevice | 0666,
+	} {
+		if err := mem.Mknod(name, mode); err != nil {
+			t.Fatalf("Mknod failed: %v", err)
+		}
+	}
+
+	stats, err := CopyTree(mem, "/src", "/dst", CopyOptions{})
+	if err != nil {
+		t.Fatalf("CopyTree failed: %v", err)
+	}
+	want := []string{filepath.Join("run", "app.sock"), filepath.Join("run", "null"), filepath.Join("run", "queue")}
+	if strings.Join(stats.Skipped, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected special files to be skipped, got %v", stats.Skipped)
+	}
+	if !FileExists(mem, "/dst/main.go") || !DirExists(mem, "/dst/run") {
+		t.Fatalf("expected regular files and dirs to be copied")
+	}
+	if _, err := mem.Lstat("/dst/run/queue"); !os.IsNotExist(err) {
+		t.Fatalf("expected pipe not to be copied, lstat err=%v", err)
+	}
+}
+
+func TestCopyTree_SetgidDirectory(t *testing.T) {
+	mem := NewMemFS()
+	if err := mem.MkdirAll("/src/storage", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.Chmod("/src/storage", fs.ModeSetgid|0775); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if err := mem.WriteFile("/src/storage/.keep", nil, 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if _, err := CopyTree(mem, "/src", "/dst", CopyOptions{}); err != nil {
+		t.Fatalf("CopyTree failed: %v", err)
+	}
+	info, err := mem.Stat("/dst/storage")
+	if err != nil || info.Mode() != fs.ModeDir|fs.ModeSetgid|0775 {
+		t.Fatalf("expected setgid dir mode to be preserved, got %v %v", info.Mode(), err)
+	}
+}
+
+// writeTree 在 dir 下创建 dirs 个目录，每个目录 files 个 size 字节的文件，返回文件内容总大小
+func writeTree(t testing.TB, fsys FS, dir string, dirs, files, size int) int64 {
+	t.Helper()
+	var total int64
+	for d := 0; d < dirs; d++ {
+		sub := filepath.Join(dir, fmt.Sprintf("d%02d", d))
+		if err := fsys.MkdirAll(sub, 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		for f := 0; f < files; f++ {
+			data := []byte(strings.Repeat(fmt.Sprintf("%02d/%03d;", d, f), size/7+1)[:size])
+			if err := fsys.WriteFile(filepath.Join(sub, fmt.Sprintf("f%03d.go", f)), data, 0644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			total += int64(size)
+		}
+	}
+	return total
+}
+
+func TestCopyTree_Concurrent(t *testing.T) {
+	mem := NewMemFS()
+	total := writeTree(t, mem, "/src", 8, 25, 100)
+
+	var progressed int64
+	stats, err := CopyTree(mem, "/src", "/dst", CopyOptions{
+		Concurrency: 8,
+		Progress:    func(path string, size int64) { progressed++ },
+	})
+	if err != nil {
+		t.Fatalf("CopyTree failed: %v", err)
+	}
+	if stats.Files != 200 || stats.Dirs != 9 || stats.Bytes != total || progressed != 200 {
+		t.Fatalf("unexpected stats: %+v, progress callbacks %d", stats, progressed)
+	}
+	for d := 0; d < 8; d++ {
+		for f := 0; f < 25; f++ {
+			rel := filepath.Join(fmt.Sprintf("d%02d", d), fmt.Sprintf("f%03d.go", f))
+			want, _ := mem.ReadFile(filepath.Join("/src", rel))
+			got, err := mem.ReadFile(filepath.Join("/dst", rel))
+			if err != nil || string(got) != string(want) {
+				t.Fatalf("content mismatch for %s: %v", rel, err)
+			}
+		}
+	}
+}
+
+// gatedFS 记录同时进行中的 Create 数量，每次创建后短暂等待，让并发复制的文件有机会重叠
+type gatedFS struct {
+	*MemFS
+	mu              sync.Mutex
+	active, maxSeen int
+}
+
+func (f *gatedFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	f.mu.Lock()
+	f.active++
+	if f.active > f.maxSeen {
+		f.maxSeen = f.active
+	}
+	f.mu.Unlock()
+	time.Sleep(2 * time.Millisecond)
+	w, err := f.MemFS.Create(name, perm)
+	if err != nil {
+		f.done()
+		return nil, err
+	}
+	return gatedWriter{WriteCloser: w, fs: f}, nil
+}
+
+func (f *gatedFS) done() {
+	f.mu.Lock()
+	f.active--
+	f.mu.Unlock()
+}
+
+type gatedWriter struct {
+	io.WriteCloser
+	fs *gatedFS
+}
+
+func (w gatedWriter) Close() error {
+	defer w.fs.done()
+	return w.WriteCloser.Close()
+}
+
+func TestCopyTree_BoundedConcurrency(t *testing.T) {
+	gated := &gatedFS{MemFS: NewMemFS()}
+	writeTree(t, gated.MemFS, "/src", 4, 16, 10)
+
+	if _, err := CopyTree(gated, "/src", "/dst", CopyOptions{Concurrency: 4}); err != nil {
+		t.Fatalf("CopyTree failed: %v", err)
+	}
+	if gated.maxSeen < 2 || gated.maxSeen > 4 {
+		t.Fatalf("expected between 2 and 4 files in flight, got %d", gated.maxSeen)
+	}
+}
+
+func TestCopyTree_ConcurrentStopsOnFirstError(t *testing.T) {
+	mem := NewMemFS()
+	writeTree(t, mem, "/src", 4, 50, 10)
+	errDenied := errors.New("denied")
+	mem.Fail(OpCreate, "/dst/d01/f010.go", errDenied)
+
+	done := make(chan struct{})
+	var stats CopyStats
+	var err error
+	go func() {
+		defer close(done)
+		stats, err = CopyTree(mem, "/src", "/dst", CopyOptions{Concurrency: 4})
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("CopyTree did not return after a failed file")
+	}
+	if !errors.Is(err, errDenied) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	if stats.Files >= 199 || DirExists(mem, "/dst/d03") {
+		t.Fatalf("expected copying to stop after the failure, got %+v", stats)
+	}
+}
+
+// BenchmarkCopyTree 对比逐个复制和并发复制大量小文件的耗时，
+// 例如 go test -bench CopyTree -benchtime 10x ./internal/fsys
+func BenchmarkCopyTree(b *testing.B) {
+	src := filepath.Join(b.TempDir(), "src")
+	total := writeTree(b, OS, src, 20, 50, 4096)
+
+	for _, workers := range []int{1, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(total)
+			for i := 0; i < b.N; i++ {
+				dst := filepath.Join(b.TempDir(), "dst")
+				if _, err := CopyTree(OS, src, dst, CopyOptions{Concurrency: workers}); err != nil {
+					b.Fatalf("CopyTree failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// slowFS 每次创建文件前等待 delay，模拟网络文件系统或慢速磁盘上的系统调用延迟
+type slowFS struct {
+	*MemFS
+	delay time.Duration
+}
+
+func (f slowFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	time.Sleep(f.delay)
+	return f.MemFS.Create(name, perm)
+}
+
+// BenchmarkCopyTree_SlowDisk 在每个文件有固定延迟时对比不同并发数，不受本机 CPU 核数和磁盘缓存影响
+func BenchmarkCopyTree_SlowDisk(b *testing.B) {
+	mem := NewMemFS()
+	total := writeTree(b, mem, "/src", 10, 20, 1024)
+
+	for _, workers := range []int{1, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(total)
+			for i := 0; i < b.N; i++ {
+				dst := fmt.Sprintf("/dst-%d-%d", workers, i)
+				if _, err := CopyTree(slowFS{mem, 200 * time.Microsecond}, "/src", dst, CopyOptions{Concurrency: workers}); err != nil {
+					b.Fatalf("CopyTree failed: %v", err)
+				}
+				b.StopTimer()
+				mem.RemoveAll(dst)
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package fsys 定义生成项目时使用的文件系统接口。
+// OS 直接操作真实文件系统；MemFS 是内存实现，可以注入 ENOSPC、EXDEV、权限错误等故障，用于单元测试。
+package fsys
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// FS 生成项目时用到的文件系统操作，语义与 os 包中的同名函数一致
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	// Lstat 与 Stat 相同，但不解析最后一个元素上的符号链接
+	Lstat(name string) (fs.FileInfo, error)
+	// ReadDir 返回按名称排序的目录项
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Open(name string) (io.ReadCloser, error)
+	// Create 创建或截断文件，新建文件使用 perm 权限
+	Create(name string, perm fs.FileMode) (io.WriteCloser, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Rename(oldpath, newpath string) error
+	RemoveAll(path string) error
+	Chmod(name string, mode fs.FileMode) error
+	// Chtimes 修改访问和修改时间，零值表示不修改
+	Chtimes(name string, atime, mtime time.Time) error
+	Symlink(oldname, newname string) error
+	Readlink(name string) (string, error)
+}
+
+// OS 真实文件系统
+var OS FS = osFS{}
+
+// Or 在 fsys 为 nil 时返回 OS
+func Or(fsys FS) FS {
+	if fsys == nil {
+		return OS
+	}
+	return fsys
+}
+
+// IsCrossDevice 判断 Rename 返回的错误是否因为源和目标位于不同的磁盘分区
+func IsCrossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV) || errors.Is(err, errNotSameDevice)
+}
+
+// DirExists 判断 path 是否为已存在的目录
+func DirExists(fsys FS, path string) bool {
+	info, err := fsys.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// FileExists 判断 path 是否为已存在的文件（不包括目录）
+func FileExists(fsys FS, path string) bool {
+	info, err := fsys.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// Walk 与 filepath.Walk 相同，按字典序遍历 root 下的所有文件和目录
+func Walk(fsys FS, root string, fn filepath.WalkFunc) error {
+	info, err := fsys.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walk(fsys, root, info, fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walk(fsys FS, path string, info fs.FileInfo, fn filepath.WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+	entries, err := fsys.ReadDir(path)
+	if err := fn(path, info, err); err != nil || entries == nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := filepath.Join(path, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			if err := fn(name, nil, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+		if err := walk(fsys, name, info, fn); err != nil {
+			if !info.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type osFS struct{}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+func (osFS) Open(name string) (io.ReadCloser, error) { return os.Open(name) }
+
+func (osFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (osFS) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+func (osFS) RemoveAll(path string) error { return os.RemoveAll(path) }
+
+func (osFS) Chmod(name string, mode fs.FileMode) error { return os.Chmod(name, mode) }
+
+func (osFS) Lstat(name string) (fs.FileInfo, error) { return os.Lstat(name) }
+
+func (osFS) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+
+func (osFS) Symlink(oldname, newname string) error { return os.Symlink(oldname, newname) }
+
+func (osFS) Readlink(name string) (string, error) { return os.Readlink(name) }
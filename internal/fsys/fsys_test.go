@@ -0,0 +1,177 @@
+package fsys
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// exercise 在 fsys 的 root 下执行一组操作，返回可以在不同实现之间比较的结果
+func exercise(t *testing.T, fsys FS, root string) []string {
+	t.Helper()
+	var log []string
+	record := func(format string, err error) {
+		log = append(log, format+": "+errName(err))
+	}
+
+	record("mkdir", fsys.MkdirAll(filepath.Join(root, "a", "b"), 0755))
+	record("write", fsys.WriteFile(filepath.Join(root, "a", "b", "file.txt"), []byte("hello"), 0644))
+	w, err := fsys.Create(filepath.Join(root, "a", "second.txt"), 0600)
+	record("create", err)
+	if err == nil {
+		_, err = io.WriteString(w, "world")
+		record("write stream", err)
+		record("close", w.Close())
+	}
+	record("mkdir over file", fsys.MkdirAll(filepath.Join(root, "a", "second.txt"), 0755))
+	record("create in missing dir", fsys.WriteFile(filepath.Join(root, "missing", "x"), nil, 0644))
+	_, err = fsys.ReadFile(filepath.Join(root, "a"))
+	record("read dir as file", err)
+	_, err = fsys.Stat(filepath.Join(root, "nope"))
+	record("stat missing", err)
+	record("rename", fsys.Rename(filepath.Join(root, "a"), filepath.Join(root, "c")))
+	record("chmod", fsys.Chmod(filepath.Join(root, "c", "second.txt"), 0640))
+
+	err = Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		entry := filepath.ToSlash(rel) + " " + info.Mode().String()
+		if !info.IsDir() {
+			content, err := fsys.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			entry += " " + string(content)
+		}
+		log = append(log, entry)
+		return nil
+	})
+	record("walk", err)
+	record("remove", fsys.RemoveAll(filepath.Join(root, "c")))
+	record("remove missing", fsys.RemoveAll(filepath.Join(root, "c")))
+	log = append(log, fmt.Sprintf("exists after remove: %v", DirExists(fsys, filepath.Join(root, "c"))))
+	return log
+}
+
+func errName(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, fs.ErrNotExist):
+		return "not exist"
+	case errors.Is(err, syscall.ENOTDIR):
+		return "not a directory"
+	case errors.Is(err, syscall.EISDIR):
+		return "is a directory"
+	case errors.Is(err, fs.ErrExist):
+		return "exists"
+	}
+	return err.Error()
+}
+
+func TestMemFS_MatchesOS(t *testing.T) {
+	osRoot := t.TempDir()
+	if err := os.Chmod(osRoot, 0755); err != nil {
+		t.Fatalf("failed to chmod temp dir: %v", err)
+	}
+	want := exercise(t, OS, osRoot)
+
+	mem := NewMemFS()
+	if err := mem.MkdirAll("/root/test", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	got := exercise(t, mem, "/root/test")
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("MemFS differs from OS\nmem:\n%s\nos:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestMemFS_CrossDeviceRename(t *testing.T) {
+	mem := NewMemFS()
+	mem.Mount("/mnt/usb")
+	if err := mem.MkdirAll("/home/src", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.MkdirAll("/mnt/usb", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+
+	err := mem.Rename("/home/src", "/mnt/usb/dst")
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) || !errors.Is(err, syscall.EXDEV) || !IsCrossDevice(err) {
+		t.Fatalf("expected EXDEV link error, got %v", err)
+	}
+	if IsCrossDevice(&os.LinkError{Op: "rename", Err: syscall.EACCES}) {
+		t.Fatalf("expected EACCES not to be treated as cross-device")
+	}
+	if err := mem.Rename("/home/src", "/home/dst"); err != nil {
+		t.Fatalf("expected rename on the same device to succeed, got %v", err)
+	}
+}
+
+func TestMemFS_Capacity(t *testing.T) {
+	mem := NewMemFS()
+	mem.Capacity = 8
+	if err := mem.WriteFile("/a", []byte("12345"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	w, err := mem.Create("/b", 0644)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	n, err := w.Write([]byte("abcdef"))
+	if n != 3 || !errors.Is(err, syscall.ENOSPC) {
+		t.Fatalf("expected partial write of 3 bytes with ENOSPC, got %d %v", n, err)
+	}
+	if content, _ := mem.ReadFile("/b"); string(content) != "abc" {
+		t.Fatalf("expected partial content, got %q", content)
+	}
+
+	// 删除文件后空间被释放
+	if err := mem.RemoveAll("/a"); err != nil {
+		t.Fatalf("RemoveAll failed: %v", err)
+	}
+	if _, err := w.Write([]byte("de")); err != nil {
+		t.Fatalf("expected write to succeed after freeing space, got %v", err)
+	}
+	if mem.Used() != 5 {
+		t.Fatalf("expected 5 bytes used, got %d", mem.Used())
+	}
+}
+
+func TestMemFS_PermissionsAndFaults(t *testing.T) {
+	mem := NewMemFS()
+	if err := mem.MkdirAll("/readonly", 0555); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.WriteFile("/readonly/x", nil, 0644); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("expected permission error in read-only dir, got %v", err)
+	}
+	if err := mem.WriteFile("/secret", []byte("s"), 0200); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := mem.ReadFile("/secret"); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("expected permission error reading write-only file, got %v", err)
+	}
+
+	mem.Fail(OpWrite, "/data/*.bin", syscall.EIO)
+	if err := mem.MkdirAll("/data", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.WriteFile("/data/blob.bin", []byte("x"), 0644); !errors.Is(err, syscall.EIO) {
+		t.Fatalf("expected injected EIO, got %v", err)
+	}
+	if err := mem.WriteFile("/data/blob.txt", []byte("x"), 0644); err != nil {
+		t.Fatalf("expected unmatched path to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package fsys
+
+import "syscall"
+
+// errNotSameDevice 跨磁盘分区重命名时返回的错误
+const errNotSameDevice = syscall.EXDEV
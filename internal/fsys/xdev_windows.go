@@ -0,0 +1,6 @@
+package fsys
+
+import "syscall"
+
+// errNotSameDevice Windows 跨卷重命名时返回的 ERROR_NOT_SAME_DEVICE
+const errNotSameDevice syscall.Errno = 17
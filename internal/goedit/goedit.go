@@ -0,0 +1,98 @@
+// Package goedit 以最小改动编辑 Go 源文件：按 AST 定位后在原文上插入文本，再统一格式化，保留其余代码和注释
+package goedit
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Edit 对源码的一次替换，Offset 到 End 之间的内容替换为 Text
+type Edit struct {
+	Offset int
+	End    int
+	Text   string
+}
+
+// Apply 按位置从后往前应用替换
+func Apply(content []byte, edits []Edit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Offset > edits[j].Offset })
+	out := append([]byte{}, content...)
+	for _, e := range edits {
+		out = append(out[:e.Offset], append([]byte(e.Text), out[e.End:]...)...)
+	}
+	return out
+}
+
+// ImportEdit 在第一个 import 声明中加入导入
+func ImportEdit(fset *token.FileSet, file *ast.File, name, path string) Edit {
+	spec := strconv.Quote(path)
+	if name != "" {
+		spec = name + " " + spec
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			offset := fset.Position(gen.Rparen).Offset
+			return Edit{Offset: offset, End: offset, Text: "\t" + spec + "\n"}
+		}
+		offset := fset.Position(gen.Pos()).Offset
+		return Edit{Offset: offset, End: offset, Text: "import " + spec + "\n"}
+	}
+	offset := fset.Position(file.Name.End()).Offset
+	return Edit{Offset: offset, End: offset, Text: "\n\nimport " + spec + "\n"}
+}
+
+// HasImport 判断文件是否已导入指定的包
+func HasImport(file *ast.File, path string) bool {
+	for _, spec := range file.Imports {
+		if value, _ := strconv.Unquote(spec.Path.Value); value == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse 读取并解析 Go 源文件，文件不存在时返回 nil
+func Parse(path string) ([]byte, *token.FileSet, *ast.File, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return content, fset, file, nil
+}
+
+// Write 应用替换并格式化后写回文件
+func Write(path string, content []byte, edits []Edit) (bool, error) {
+	formatted, err := format.Source(Apply(content, edits))
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+	return true, os.WriteFile(path, formatted, 0644)
+}
+
+// StringLiteral 返回字符串字面量的值
+func StringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
@@ -0,0 +1,198 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/plugin"
+)
+
+// new 命令的生成阶段，按执行顺序排列
+const (
+	StageClone    = "clone"
+	StageFeatures = "features"
+	StageDrivers  = "drivers"
+	StageStrip    = "strip"
+	StageMove     = "move"
+	StageEnv      = "env"
+	StageDocker   = "docker"
+	StageCI       = "ci"
+	StageCommands = "commands"
+)
+
+// Stages 所有生成阶段
+var Stages = []string{StageClone, StageFeatures, StageDrivers, StageStrip, StageMove, StageEnv, StageDocker, StageCI, StageCommands}
+
+// 钩子相对于阶段的执行时机
+const (
+	Pre  = "pre"
+	Post = "post"
+)
+
+// Hook 一个钩子：执行 shell 命令或插件，二者只能选一个
+type Hook struct {
+	// Run 通过 sh -c（Windows 为 cmd /C）执行的命令
+	Run string `json:"run,omitempty"`
+	// Plugin 插件名称，即 goravel-kit-cli-<name> 中的 name
+	Plugin string `json:"plugin,omitempty"`
+	// Args 传给插件的参数
+	Args []string `json:"args,omitempty"`
+	// Optional 为 true 时钩子失败只输出警告，不中断生成
+	Optional bool `json:"optional,omitempty"`
+}
+
+// String 返回钩子的简要描述
+func (h Hook) String() string {
+	if h.Plugin != "" {
+		return strings.TrimSpace("plugin " + h.Plugin + " " + strings.Join(h.Args, " "))
+	}
+	return h.Run
+}
+
+// Hooks 按 "<pre|post>-<stage>" 组织的钩子，如 post-clone、pre-move
+type Hooks map[string][]Hook
+
+// Key 返回时机和阶段对应的键
+func Key(when, stage string) string {
+	return when + "-" + stage
+}
+
+// Validate 检查键名和钩子定义
+func (h Hooks) Validate() error {
+	keys := make([]string, 0, len(h))
+	for key := range h {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		when, stage, _ := strings.Cut(key, "-")
+		if (when != Pre && when != Post) || !knownStage(stage) {
+			return fmt.Errorf("unknown hook %q, expected pre-<stage> or post-<stage> with stage one of %s", key, strings.Join(Stages, ", "))
+		}
+		for i, hook := range h[key] {
+			if (hook.Run == "") == (hook.Plugin == "") {
+				return fmt.Errorf("hook %s[%d]: exactly one of run and plugin must be set", key, i)
+			}
+		}
+	}
+	return nil
+}
+
+func knownStage(stage string) bool {
+	for _, known := range Stages {
+		if known == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge 合并多组钩子，同一个键下按参数顺序依次执行
+func Merge(sets ...Hooks) Hooks {
+	merged := Hooks{}
+	for _, set := range sets {
+		for key, hooks := range set {
+			merged[key] = append(merged[key], hooks...)
+		}
+	}
+	return merged
+}
+
+// Context 通过标准输入以 JSON 传给钩子的上下文
+type Context struct {
+	Stage string `json:"stage"`
+	When  string `json:"when"`
+	// Dir 当前的工作目录：move 完成前为临时目录，之后为项目目录
+	Dir string `json:"dir"`
+	// ProjectDir 项目的最终目录
+	ProjectDir string   `json:"project_dir"`
+	Project    string   `json:"project"`
+	Repository string   `json:"repository,omitempty"`
+	Mirror     string   `json:"mirror,omitempty"`
+	Ref        string   `json:"ref,omitempty"`
+	SHA        string   `json:"sha,omitempty"`
+	Without    []string `json:"without,omitempty"`
+	Database   string   `json:"database,omitempty"`
+	Cache      string   `json:"cache,omitempty"`
+	CLIVersion string   `json:"cli_version,omitempty"`
+}
+
+// Runner 执行钩子
+type Runner struct {
+	Hooks Hooks
+	// PluginsDir 查找插件钩子时使用的插件目录
+	PluginsDir string
+	Stdout     io.Writer
+	Stderr     io.Writer
+	// Before 在每个钩子执行前调用，用于输出提示
+	Before func(key string, hook Hook)
+}
+
+// Run 依次执行 when-stage 上的钩子，工作目录为 hc.Dir。
+// 非 Optional 的钩子失败时立即返回错误；Optional 钩子的错误收集在 warnings 中
+func (r *Runner) Run(ctx context.Context, when string, hc Context) (warnings []error, err error) {
+	if r == nil {
+		return nil, nil
+	}
+	key := Key(when, hc.Stage)
+	hc.When = when
+	input, err := json.Marshal(hc)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range r.Hooks[key] {
+		if r.Before != nil {
+			r.Before(key, hook)
+		}
+		if err := r.run(ctx, key, hook, hc, input); err != nil {
+			err = fmt.Errorf("%s hook %q: %w", key, hook.String(), err)
+			if !hook.Optional {
+				return warnings, err
+			}
+			warnings = append(warnings, err)
+		}
+	}
+	return warnings, nil
+}
+
+func (r *Runner) run(ctx context.Context, key string, hook Hook, hc Context, input []byte) error {
+	var cmd *exec.Cmd
+	switch {
+	case hook.Plugin != "":
+		p, ok := plugin.Find(r.PluginsDir, hook.Plugin)
+		if !ok {
+			return fmt.Errorf("plugin %q not found", hook.Plugin)
+		}
+		cmd = exec.CommandContext(ctx, p.Path, hook.Args...)
+	case runtime.GOOS == "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Run)
+	default:
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Run)
+	}
+	cmd.Dir = hc.Dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = writerOrDiscard(r.Stdout)
+	cmd.Stderr = writerOrDiscard(r.Stderr)
+	cmd.Env = append(os.Environ(),
+		"GORAVEL_KIT_HOOK="+key,
+		"GORAVEL_KIT_HOOK_STAGE="+hc.Stage,
+		"GORAVEL_KIT_HOOK_DIR="+hc.Dir,
+		"GORAVEL_KIT_PROJECT_DIR="+hc.ProjectDir,
+	)
+	return cmd.Run()
+}
+
+func writerOrDiscard(w io.Writer) io.Writer {
+	if w == nil {
+		return io.Discard
+	}
+	return w
+}
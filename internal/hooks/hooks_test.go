@@ -0,0 +1,143 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/plugin"
+)
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are sh commands in these tests")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Hooks{
+		"pre-clone":     {{Run: "true"}},
+		"post-commands": {{Plugin: "notify", Args: []string{"--done"}}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid hooks, got %v", err)
+	}
+	cases := map[string]Hooks{
+		"unknown stage": {"post-deploy": {{Run: "true"}}},
+		"unknown when":  {"after-move": {{Run: "true"}}},
+		"empty hook":    {"pre-move": {{}}},
+		"both set":      {"pre-move": {{Run: "true", Plugin: "x"}}},
+	}
+	for name, hooks := range cases {
+		if err := hooks.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(
+		Hooks{"pre-move": {{Run: "config"}}},
+		Hooks{"pre-move": {{Run: "manifest"}}, "post-env": {{Run: "env"}}},
+	)
+	if len(merged["pre-move"]) != 2 || merged["pre-move"][0].Run != "config" || merged["pre-move"][1].Run != "manifest" {
+		t.Fatalf("expected config hooks before manifest hooks, got %+v", merged["pre-move"])
+	}
+	if len(merged["post-env"]) != 1 {
+		t.Fatalf("unexpected merged hooks: %+v", merged)
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	var ran []string
+	runner := &Runner{
+		Hooks: Hooks{
+			"post-clone": {
+				{Run: `cat > context.json; echo "$GORAVEL_KIT_HOOK $(pwd)"`},
+				{Run: "exit 2", Optional: true},
+				{Run: "touch second"},
+			},
+		},
+		Stdout: &stdout,
+		Before: func(key string, hook Hook) { ran = append(ran, key+":"+hook.String()) },
+	}
+
+	warnings, err := runner.Run(context.Background(), Post, Context{Stage: StageClone, Dir: dir, ProjectDir: "/work/shop", Project: "shop", SHA: "abc"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), `post-clone hook "exit 2"`) {
+		t.Fatalf("expected optional hook failure as warning, got %v", warnings)
+	}
+	if len(ran) != 3 {
+		t.Fatalf("expected all hooks to run, got %v", ran)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "second")); err != nil {
+		t.Fatalf("expected hook after optional failure to run: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "context.json"))
+	if err != nil {
+		t.Fatalf("expected context on stdin: %v", err)
+	}
+	var hc Context
+	if err := json.Unmarshal(content, &hc); err != nil {
+		t.Fatalf("invalid context JSON %q: %v", content, err)
+	}
+	if hc.Stage != StageClone || hc.When != Post || hc.Dir != dir || hc.Project != "shop" || hc.SHA != "abc" {
+		t.Fatalf("unexpected context: %+v", hc)
+	}
+	if got := strings.TrimSpace(stdout.String()); !strings.HasPrefix(got, "post-clone ") || !strings.HasSuffix(got, filepath.Base(dir)) {
+		t.Fatalf("expected hook to run in the staging dir, got %q", got)
+	}
+
+	// 没有注册钩子的时机不执行任何命令
+	if warnings, err := runner.Run(context.Background(), Pre, Context{Stage: StageClone, Dir: dir}); err != nil || warnings != nil {
+		t.Fatalf("expected no-op, got %v %v", warnings, err)
+	}
+}
+
+func TestRunner_RunStopsOnFailure(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	runner := &Runner{Hooks: Hooks{"pre-move": {{Run: "exit 1"}, {Run: "touch never"}}}}
+	_, err := runner.Run(context.Background(), Pre, Context{Stage: StageMove, Dir: dir})
+	if err == nil || !strings.Contains(err.Error(), `pre-move hook "exit 1"`) {
+		t.Fatalf("expected hook failure, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "never")); !os.IsNotExist(err) {
+		t.Fatalf("hooks after a failure must not run")
+	}
+}
+
+func TestRunner_Plugin(t *testing.T) {
+	skipOnWindows(t)
+	pluginsDir := t.TempDir()
+	script := "#!/bin/sh\necho \"$1 $GORAVEL_KIT_HOOK_STAGE\" > plugin.out\n"
+	if err := os.WriteFile(filepath.Join(pluginsDir, plugin.Prefix+"inject"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write plugin: %v", err)
+	}
+
+	dir := t.TempDir()
+	runner := &Runner{PluginsDir: pluginsDir, Hooks: Hooks{"post-env": {{Plugin: "inject", Args: []string{"--middleware"}}}}}
+	if _, err := runner.Run(context.Background(), Post, Context{Stage: StageEnv, Dir: dir}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "plugin.out"))
+	if err != nil || strings.TrimSpace(string(content)) != "--middleware env" {
+		t.Fatalf("unexpected plugin output %q %v", content, err)
+	}
+
+	runner.Hooks = Hooks{"post-env": {{Plugin: "missing"}}}
+	if _, err := runner.Run(context.Background(), Post, Context{Stage: StageEnv, Dir: dir}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected missing plugin error, got %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package i18n
+
+// en English message catalog
+var en = map[string]string{
+	"common.usage":   "Usage",
+	"common.warning": "Warning",
+
+	"banner.welcome":      "Welcome to Goravel Kit CLI",
+	"banner.vendor":       "Developed by <<Dazhou Hulu Technology>>",
+	"banner.author":       "Author: %s",
+	"banner.dev_date":     "Development date: %s",
+	"banner.version":      "Version: %s",
+	"banner.description":  "Description: Goravel project scaffolding tool",
+	"banner.release_date": "Release date: %s",
+
+	"new.creating":                   "Creating Goravel project: %s",
+	"new.error.name_required":        "project name is required",
+	"new.probe.checking":             "Checking network connectivity...",
+	"new.probe.gitee_ok":             "Gitee is reachable",
+	"new.probe.gitee_failed":         "Gitee is unreachable, falling back to GitHub",
+	"new.strategy":                   "Template strategy: %s",
+	"new.strategy.auto_gitee":        "Gitee mirror selected automatically (network probe)",
+	"new.strategy.gitee_only":        "Gitee mirror only (user specified)",
+	"new.strategy.github_only":       "GitHub only (user specified)",
+	"new.strategy.auto":              "Automatic mirror selection (GitHub → Gitee)",
+	"new.branch":                     "Branch: %s",
+	"new.protocol":                   "Protocol: %s",
+	"new.mirrors":                    "Available mirrors:",
+	"new.timeout":                    "Timeout: %v",
+	"new.error.temp_dir":             "failed to create temporary directory",
+	"new.warn.cleanup_temp":          "failed to clean up temporary directory",
+	"new.clone.trying":               "Downloading template from %s...",
+	"new.clone.repo":                 "Repository: %s",
+	"new.clone.failed":               "Download from %s failed: %v",
+	"new.clone.next":                 "Trying the next mirror...",
+	"new.clone.all_failed":           "Download failed on all mirrors!",
+	"new.clone.solutions":            "Possible solutions:",
+	"new.clone.solution.network":     "Check your network connection",
+	"new.clone.solution.protocol":    "Switch the clone protocol with --https or --ssh",
+	"new.clone.solution.gitee_only":  "Use --gitee-only to force the Gitee mirror",
+	"new.clone.solution.github_only": "Use --github-only to force GitHub",
+	"new.clone.solution.verbose":     "Use --verbose to see detailed errors",
+	"new.clone.solution.branch":      "Check that the branch exists: %s",
+	"new.error.all_failed":           "download failed on all mirrors",
+	"new.clone.succeeded":            "Template downloaded from %s",
+	"new.clone.source_repo":          "Source repository: %s",
+	"new.processing":                 "Processing template files...",
+	"new.error.remove_git":           "failed to remove .git directory",
+	"new.removed":                    "Removed: %s",
+	"new.error.remove_existing":      "failed to remove existing directory",
+	"new.removed_existing":           "Removed existing directory: %s",
+	"new.error.existing_exclusive":   "--force, --backup and --merge cannot be used together",
+	"new.error.conflict_policy":      "unsupported --conflict value %q (skip, overwrite, rename, prompt)",
+	"new.error.conflict_prompt":      "--conflict prompt requires an interactive terminal; use skip, overwrite or rename",
+	"new.confirm_force":              "Directory '%s' already exists and will be deleted. Continue?",
+	"new.error.aborted":              "aborted, the existing directory was left untouched",
+	"new.error.git_status":           "failed to check git status of '%s'",
+	"new.error.dirty_repo":           "'%s' is a git repository with %d uncommitted changes",
+	"new.dirty_repo_remedy":          "commit or stash the changes first, or use --backup to keep a copy of the directory",
+	"new.error.backup":               "failed to back up existing directory",
+	"new.backed_up":                  "Backed up existing directory %s to %s",
+	"new.error.merge":                "failed to merge template into existing directory",
+	"new.merged":                     "Merged template into existing directory %s (%d conflicts)",
+	"new.env_kept":                   "Kept the existing .env",
+	"new.keys_kept":                  "Skipped key:generate and jwt:secret to keep the keys in the existing .env",
+	"new.prompt.yes_no":              "[y/N]",
+	"new.prompt.conflict":            "'%s' differs from the template: [s]kip, [o]verwrite, [r]ename existing to .orig (uppercase applies to all remaining)",
+	"new.error.create_project":       "failed to create project",
+	"new.structure_created":          "Project structure created",
+	"new.error.read_env_example":     "failed to read .env.example",
+	"new.error.create_env":           "failed to create .env",
+	"new.env_copied":                 "Generated .env from .env.example",
+	"new.env_example_missing":        ".env.example not found, skipping .env creation",
+	"new.warn.update_env":            "failed to update .env",
+	"new.env_updated":                "Updated .env configuration",
+	"new.running_command":            "Running: %s",
+	"new.command_failed":             "Command failed: %s",
+	"new.success":                    "Project '%s' created successfully!",
+	"new.next_steps":                 "Next steps:",
+	"new.next.configure_db":          "modify .env database configuration!",
+	"new.tip_verbose":                "Tip: use --verbose to see detailed output",
+	"move.cross_device":              "Cross-device move, copying files instead...",
+	"move.copying":                   "Copying files",
+	"move.error.mkdir":               "failed to create destination directory",
+	"move.error.copy":                "failed to copy files",
+	"move.error.cleanup":             "failed to clean up source directory",
+	"move.skipped_special":           "Skipped special file (pipe, socket or device): %s",
+	"move.error.verify":              "copy verification failed: copied %d of %d bytes, the source directory was kept",
+	"preflight.failed":               "pre-flight checks failed",
+	"preflight.name_invalid":         "invalid project name '%s': %v",
+	"preflight.name_remedy":          "use only letters, digits, '.', '-' and '_', starting with a letter or digit, e.g. my-app",
+	"preflight.name.empty":           "name must not be empty",
+	"preflight.name.separator":       "must not contain path separators or '..'",
+	"preflight.name.chars":           "contains characters that are not allowed",
+	"preflight.name.trailing_dot":    "must not end with '.'",
+	"preflight.name.reserved":        "'%s' is a reserved name on Windows",
+	"preflight.dir_exists":           "directory '%s' already exists",
+	"preflight.dir_exists_remedy":    "use --force to overwrite it, --backup to keep a copy, --merge to write into it, or choose another project name",
+	"preflight.file_exists":          "'%s' is an existing file",
+	"preflight.file_exists_remedy":   "remove the file or choose another project name",
+	"preflight.unwritable":           "target directory '%s' is not writable: %v",
+	"preflight.unwritable_remedy":    "switch to a directory you can write to and retry",
+	"preflight.tool_missing":         "command %s not found",
+	"preflight.tool_git_remedy":      "install Git: https://git-scm.com/downloads",
+	"preflight.tool_go_remedy":       "install Go and make sure the go command is on PATH: https://go.dev/dl/",
+	"preflight.disk_label_temp":      "temporary directory",
+	"preflight.disk_label_target":    "target directory",
+	"preflight.disk_low":             "%s '%s' is low on disk space: %s (at least %s required)",
+	"preflight.disk_remedy":          "free up disk space, or point TMPDIR at another temporary directory",
+	"preflight.ssh_missing":          "cloning over SSH, but no SSH private key or ssh-agent was found",
+	"preflight.ssh_remedy":           "use --https to clone over HTTPS, or run ssh-keygen -t ed25519 and add the public key to GitHub/Gitee",
+
+	"git.running":           "Running command: git %s",
+	"git.done_in":           "Download completed in %v",
+	"git.done":              "Download completed",
+	"git.error.stdout_pipe": "failed to get stdout pipe",
+	"git.error.stderr_pipe": "failed to get stderr pipe",
+	"git.error.start":       "failed to start git clone",
+	"git.error.timeout":     "download timed out after %v",
+	"git.error.auth":        "authentication failed after %v. Try switching protocol: goravel-kit-cli new %s --https",
+	"git.error.not_found":   "repository not found: %s (took %v)",
+	"git.error.branch":      "branch '%s' not found (took %v)",
+	"git.error.host_key":    "SSH host key verification failed. Please check your SSH configuration (known_hosts)",
+	"git.error.failed":      "git clone failed after %v",
+
+	"doctor.title":               "Goravel Kit environment check",
+	"doctor.summary":             "Pass: %d  Warn: %d  Fail: %d",
+	"doctor.not_installed":       "not installed or not on PATH",
+	"doctor.unknown_version":     "installed, but the version could not be determined",
+	"doctor.too_old":             "%s is older than the required %s",
+	"doctor.goenv_failed":        "unable to read go env",
+	"doctor.goenv_remedy":        "make sure Go is installed correctly",
+	"doctor.not_in_path":         "%s is not on PATH",
+	"doctor.ssh_missing":         "no SSH private key found, the default SSH clone will fail",
+	"doctor.ssh_remedy":          "run ssh-keygen -t ed25519 and add the key to GitHub/Gitee, or use --https",
+	"doctor.reachable":           "%s is reachable",
+	"doctor.unreachable":         "%s is unreachable",
+	"doctor.remedy.github_https": "check your network or proxy settings, or use --gitee-only",
+	"doctor.remedy.gitee_https":  "check your network or proxy settings, or use --github-only",
+	"doctor.remedy.ssh_port":     "port 22 may be blocked by a firewall, use --https instead",
+	"doctor.remedy.mysql":        "start a local MySQL 5.7+, or configure a remote database in .env",
+	"doctor.remedy.redis":        "start a local Redis, or configure a remote Redis in .env",
+
+	"upgrade.fetching":             "Fetching template %s (%s → %s)",
+	"upgrade.up_to_date":           "Project is already on the latest template commit %s",
+	"upgrade.no_changes":           "Template changes are already present in the project",
+	"upgrade.plan":                 "Upgrade plan (%d files):",
+	"upgrade.binary":               "Binary file %s differs",
+	"upgrade.reject_preview":       "# Would write %s:",
+	"upgrade.done":                 "Upgraded to template commit %s",
+	"upgrade.done_conflicts":       "Upgraded to template commit %s with %d conflicts; resolve the conflict markers or .rej files and commit",
+	"upgrade.error.conflict_style": "unsupported --conflict value %q (markers, rej)",
+	"template.error.read_metadata": "failed to read template metadata",
+	"upgrade.error.no_base":        "%s not found, use --base to specify the template commit the project was created from",
+	"upgrade.error.fetch":          "failed to fetch template %s",
+	"upgrade.error.plan":           "failed to compute upgrade",
+	"upgrade.error.apply":          "failed to apply upgrade",
+	"upgrade.error.write_metadata": "failed to update template metadata",
+
+	"diff.fetching":      "Rebuilding template %s at %s",
+	"diff.identical":     "Project matches template commit %s",
+	"diff.binary":        "Binary file %s differs",
+	"diff.binary_short":  "binary",
+	"diff.summary":       "%d files differ from template %s, %d insertions(+), %d deletions(-)",
+	"diff.error.no_ref":  "%s not found, use --ref to specify the template version to compare with",
+	"diff.error.fetch":   "failed to fetch template %s",
+	"diff.error.compare": "failed to compare project with template",
+
+	"features.removed":        "Removed optional modules: %s",
+	"features.modified":       "Updated %s",
+	"features.error.manifest": "failed to read template feature manifest",
+	"features.error.select":   "invalid feature selection",
+	"features.error.remove":   "failed to remove optional modules",
+
+	"new.next.frontend": "Start the frontend:",
+	"new.next.api_only": "API-only project: no frontend was generated, the API is served under /api",
+
+	"driver.applied":      "Applied drivers: %s",
+	"driver.created":      "Created %s",
+	"driver.required":     "Added dependency %s",
+	"driver.error.select": "invalid driver selection",
+	"driver.error.apply":  "failed to apply database/cache drivers",
+	"new.next.sqlite":     "SQLite database created at %s, no database server required",
+
+	"docker.created":        "Created %s",
+	"docker.skipped":        "%s already exists, skipped (use --force to overwrite)",
+	"docker.env_wired":      "Pointed database and Redis hosts in .env at the docker compose services",
+	"docker.env_kept":       "Kept the existing .env; set these values in it to use the docker compose services:",
+	"docker.services":       "Services: %s",
+	"docker.error.generate": "failed to generate Docker configuration",
+	"docker.next":           "Start everything with docker compose up -d --build",
+	"new.next.docker":       "Run with Docker:",
+
+	"ci.created":        "Created %s (jobs: %s)",
+	"ci.skipped":        "%s already exists, skipped (use --force to overwrite)",
+	"ci.error.provider": "invalid CI provider",
+	"ci.error.generate": "failed to generate CI workflow",
+
+	"make.error.resource_required": "resource name is required",
+	"make.error.fields":            "invalid fields",
+	"make.error.generate":          "failed to generate CRUD code",
+	"make.created":                 "Created %s",
+	"make.registered":              "Registered in %s",
+	"make.manual":                  "Register manually:",
+	"make.next":                    "Run go run . artisan migrate, then add a menu entry for the %s page",
+	"make.error.permissions":       "failed to generate permission seeder",
+	"make.permission.index":        "List %s",
+	"make.permission.list":         "List all %s",
+	"make.permission.option":       "%s options",
+	"make.permission.show":         "View %s",
+	"make.permission.store":        "Create %s",
+	"make.permission.update":       "Edit %s",
+	"make.permission.destroy":      "Delete %s",
+	"make.permission.custom":       "%s %s",
+	"make.permission.menu":         "Menu %s",
+	"make.permission.codes":        "Permissions: %s",
+	"make.permission.next":         "Run go run . artisan db:seed --seeder=%s, then assign the permissions to roles",
+
+	"gen.error.generate": "failed to generate API client",
+	"gen.error.stale":    "generated API client is out of date, run goravel-kit-cli gen:api-client",
+	"gen.spec":           "Reading %s (%d operations, %d schemas)",
+	"gen.updated":        "Wrote %s",
+	"gen.removed":        "Removed %s",
+	"gen.unchanged":      "API client in %s is up to date",
+	"gen.stale":          "Out of date: %s",
+
+	"routes.error.analyze": "failed to analyze routes",
+	"routes.empty":         "No routes found",
+	"routes.summary":       "%d routes",
+
+	"plugin.error.source_required": "a plugin file or Go package is required",
+	"plugin.error.name_required":   "a plugin name is required",
+	"plugin.error.install":         "failed to install plugin",
+	"plugin.error.remove":          "failed to remove plugin",
+	"plugin.error.run":             "failed to run plugin %s",
+	"plugin.installed":             "Installed plugin %s to %s",
+	"plugin.removed":               "Removed %s",
+	"plugin.empty":                 "No plugins found in %s or on PATH",
+	"plugin.conflict":              "Plugin %s has the same name as a built-in command and is ignored",
+	"plugin.shadowed":              "%s is shadowed by %s",
+
+	"hooks.error.config": "invalid hooks in the config file",
+	"hooks.error.failed": "lifecycle hook failed",
+	"hooks.running":      "Running %s hook: %s",
+}
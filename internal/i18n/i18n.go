@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 支持的语言
+const (
+	English = "en"
+	Chinese = "zh-CN"
+)
+
+// DefaultLocale 未指定语言且无法从环境变量推断时使用的语言
+const DefaultLocale = Chinese
+
+// catalogs 各语言的消息目录，key 为消息标识，value 为 fmt 格式字符串
+var catalogs = map[string]map[string]string{
+	English: en,
+	Chinese: zhCN,
+}
+
+var (
+	mu     sync.RWMutex
+	locale = DefaultLocale
+)
+
+// Locales 返回所有支持的语言
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for name := range catalogs {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize 将 zh_CN.UTF-8、en-US 等语言标识归一化为支持的语言，
+// 无法识别时返回空字符串
+func Normalize(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+
+	switch {
+	case lang == "", lang == "c", lang == "posix":
+		return ""
+	case strings.HasPrefix(lang, "zh"):
+		return Chinese
+	case strings.HasPrefix(lang, "en"):
+		return English
+	}
+	return ""
+}
+
+// Resolve 按 --lang 参数、配置文件、LC_ALL/LC_MESSAGES/LANG 的顺序确定语言
+func Resolve(flagLang, configLang string) (string, error) {
+	for _, lang := range []string{flagLang, configLang} {
+		if lang == "" {
+			continue
+		}
+		if normalized := Normalize(lang); normalized != "" {
+			return normalized, nil
+		}
+		return "", fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Locales(), ", "))
+	}
+
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if normalized := Normalize(os.Getenv(key)); normalized != "" {
+			return normalized, nil
+		}
+	}
+	return DefaultLocale, nil
+}
+
+// SetLocale 设置当前语言
+func SetLocale(lang string) error {
+	normalized := Normalize(lang)
+	if normalized == "" {
+		return fmt.Errorf("unsupported language %q", lang)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	locale = normalized
+	return nil
+}
+
+// Locale 返回当前语言
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// T 返回当前语言下 key 对应的消息，当前语言缺失时回退到英文，仍缺失时返回 key 本身
+func T(key string, args ...any) string {
+	mu.RLock()
+	message, ok := catalogs[locale][key]
+	mu.RUnlock()
+
+	if !ok {
+		if message, ok = catalogs[English][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
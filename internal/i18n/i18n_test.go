@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+func TestCatalogs_HaveSameKeys(t *testing.T) {
+	for name, catalog := range catalogs {
+		for otherName, other := range catalogs {
+			for key := range catalog {
+				if _, ok := other[key]; !ok {
+					t.Errorf("key %q exists in %s but is missing in %s", key, name, otherName)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogs_HaveSamePlaceholders(t *testing.T) {
+	for key, message := range catalogs[English] {
+		want := verbPattern.FindAllString(message, -1)
+		for name, catalog := range catalogs {
+			got := verbPattern.FindAllString(catalog[key], -1)
+			if strings.Join(got, " ") != strings.Join(want, " ") {
+				t.Errorf("placeholders of %q differ in %s: got %v, want %v", key, name, got, want)
+			}
+		}
+	}
+}
+
+// TestCatalogs_CoverSourceKeys 扫描源码中使用的所有 i18n.T 调用，确保每个 key 在所有语言中都存在
+func TestCatalogs_CoverSourceKeys(t *testing.T) {
+	keyPattern := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+	used := map[string]bool{}
+
+	// 从模块根目录开始扫描，覆盖 internal、pkg 和 main.go
+	err := filepath.Walk(filepath.Join("..", ".."), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range keyPattern.FindAllStringSubmatch(string(content), -1) {
+			used[match[1]] = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to scan sources: %v", err)
+	}
+	if len(used) == 0 {
+		t.Fatalf("expected to find i18n.T calls in sources")
+	}
+
+	var keys []string
+	for key := range used {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for name, catalog := range catalogs {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("key %q is used in sources but missing in %s", key, name)
+			}
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"zh_CN.UTF-8": Chinese,
+		"zh-CN":       Chinese,
+		"zh":          Chinese,
+		"en_US.UTF-8": English,
+		"en":          English,
+		"C":           "",
+		"fr_FR":       "",
+	}
+	for input, want := range cases {
+		if got := Normalize(input); got != want {
+			t.Fatalf("Normalize(%q)=%q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	if got, _ := Resolve("zh-CN", "en"); got != Chinese {
+		t.Fatalf("expected --lang to win, got %q", got)
+	}
+	if got, _ := Resolve("", "zh"); got != Chinese {
+		t.Fatalf("expected config to win over LANG, got %q", got)
+	}
+	if got, _ := Resolve("", ""); got != English {
+		t.Fatalf("expected LANG to be used, got %q", got)
+	}
+	if _, err := Resolve("fr", ""); err == nil {
+		t.Fatalf("expected error for unsupported language")
+	}
+}
+
+func TestT_FallsBackToEnglishAndKey(t *testing.T) {
+	defer SetLocale(Locale())
+
+	if err := SetLocale("zh-CN"); err != nil {
+		t.Fatalf("SetLocale failed: %v", err)
+	}
+	if got := T("new.branch", "master"); got != "分支: master" {
+		t.Fatalf("unexpected zh-CN message: %q", got)
+	}
+	if got := T("does.not.exist"); got != "does.not.exist" {
+		t.Fatalf("expected key fallback, got %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package i18n
+
+// zhCN 简体中文消息目录
+var zhCN = map[string]string{
+	"common.usage":   "用法",
+	"common.warning": "警告",
+
+	"banner.welcome":      "欢迎使用 Goravel Kit CLI",
+	"banner.vendor":       "<<达州葫芦科技>>研发",
+	"banner.author":       "作者: %s",
+	"banner.dev_date":     "开发时间: %s",
+	"banner.version":      "版本号: %s",
+	"banner.description":  "版本说明: Goravel 项目脚手架工具",
+	"banner.release_date": "版本时间: %s",
+
+	"new.creating":                   "开始创建 Goravel 项目: %s",
+	"new.error.name_required":        "缺少项目名称",
+	"new.probe.checking":             "检测网络连接...",
+	"new.probe.gitee_ok":             "Gitee 访问正常",
+	"new.probe.gitee_failed":         "Gitee 访问失败，自动切换到 GitHub",
+	"new.strategy":                   "模板策略: %s",
+	"new.strategy.auto_gitee":        "自动选择 Gitee 镜像 (网络检测)",
+	"new.strategy.gitee_only":        "强制使用 Gitee 镜像 (用户指定)",
+	"new.strategy.github_only":       "强制使用 GitHub 镜像 (用户指定)",
+	"new.strategy.auto":              "自动选择镜像 (GitHub → Gitee)",
+	"new.branch":                     "分支: %s",
+	"new.protocol":                   "协议: %s",
+	"new.mirrors":                    "可用镜像源:",
+	"new.timeout":                    "超时时间: %v",
+	"new.error.temp_dir":             "创建临时目录失败",
+	"new.warn.cleanup_temp":          "清理临时目录失败",
+	"new.clone.trying":               "尝试从 %s 下载模板...",
+	"new.clone.repo":                 "仓库: %s",
+	"new.clone.failed":               "%s 下载失败: %v",
+	"new.clone.next":                 "尝试下一个镜像源...",
+	"new.clone.all_failed":           "所有镜像源下载均失败！",
+	"new.clone.solutions":            "解决方案:",
+	"new.clone.solution.network":     "检查网络连接",
+	"new.clone.solution.protocol":    "使用 --https 或 --ssh 参数切换克隆协议",
+	"new.clone.solution.gitee_only":  "使用 --gitee-only 强制使用 Gitee",
+	"new.clone.solution.github_only": "使用 --github-only 强制使用 GitHub",
+	"new.clone.solution.verbose":     "使用 --verbose 查看详细错误信息",
+	"new.clone.solution.branch":      "检查分支是否存在: %s",
+	"new.error.all_failed":           "所有镜像源下载失败",
+	"new.clone.succeeded":            "成功从 %s 下载模板",
+	"new.clone.source_repo":          "源仓库: %s",
+	"new.processing":                 "处理模板文件中...",
+	"new.error.remove_git":           "移除 .git 目录失败",
+	"new.removed":                    "已移除: %s",
+	"new.error.remove_existing":      "移除已存在目录失败",
+	"new.removed_existing":           "已移除已存在目录: %s",
+	"new.error.existing_exclusive":   "--force、--backup 和 --merge 不能同时使用",
+	"new.error.conflict_policy":      "不支持的 --conflict 取值 %q（skip、overwrite、rename、prompt）",
+	"new.error.conflict_prompt":      "--conflict prompt 需要交互式终端，请使用 skip、overwrite 或 rename",
+	"new.confirm_force":              "目录 '%s' 已存在，将被删除。是否继续？",
+	"new.error.aborted":              "已取消，已有目录未做任何改动",
+	"new.error.git_status":           "检查 '%s' 的 git 状态失败",
+	"new.error.dirty_repo":           "'%s' 是 git 仓库，有 %d 处未提交的改动",
+	"new.dirty_repo_remedy":          "请先提交或暂存（stash）这些改动，或使用 --backup 保留目录副本",
+	"new.error.backup":               "备份已有目录失败",
+	"new.backed_up":                  "已将已有目录 %s 备份为 %s",
+	"new.error.merge":                "合并模板到已有目录失败",
+	"new.merged":                     "已将模板合并到已有目录 %s（%d 处冲突）",
+	"new.env_kept":                   "保留已有的 .env",
+	"new.keys_kept":                  "已跳过 key:generate 和 jwt:secret，保留已有 .env 中的密钥",
+	"new.prompt.yes_no":              "[y/N]",
+	"new.prompt.conflict":            "'%s' 与模板不同：[s] 跳过，[o] 覆盖，[r] 将已有文件重命名为 .orig（大写表示对剩余冲突都这样处理）",
+	"new.error.create_project":       "创建项目失败",
+	"new.structure_created":          "项目结构创建完成",
+	"new.error.read_env_example":     "读取 .env.example 文件失败",
+	"new.error.create_env":           "创建 .env 文件失败",
+	"new.env_copied":                 "已从 .env.example 复制生成 .env 文件",
+	"new.env_example_missing":        "未找到 .env.example，跳过 .env 文件创建",
+	"new.warn.update_env":            "更新 .env 文件失败",
+	"new.env_updated":                "已更新 .env 配置",
+	"new.running_command":            "执行命令: %s",
+	"new.command_failed":             "命令执行失败: %s",
+	"new.success":                    "项目 '%s' 创建成功！",
+	"new.next_steps":                 "下一步操作:",
+	"new.next.configure_db":          "修改 .env 中的数据库配置！",
+	"new.tip_verbose":                "提示: 使用 --verbose 参数查看详细输出",
+	"move.cross_device":              "跨磁盘操作，使用复制方式移动文件...",
+	"move.copying":                   "复制文件",
+	"move.error.mkdir":               "创建目标目录失败",
+	"move.error.copy":                "复制文件失败",
+	"move.error.cleanup":             "清理源目录失败",
+	"move.skipped_special":           "已跳过特殊文件（管道、套接字或设备）：%s",
+	"move.error.verify":              "复制校验失败：已复制 %d / %d 字节，已保留源目录",
+	"preflight.failed":               "预检失败",
+	"preflight.name_invalid":         "项目名称 '%s' 无效: %v",
+	"preflight.name_remedy":          "项目名称只能包含字母、数字、'.'、'-'、'_'，且以字母或数字开头，例如: my-app",
+	"preflight.name.empty":           "名称不能为空",
+	"preflight.name.separator":       "不能包含路径分隔符或 '..'",
+	"preflight.name.chars":           "包含不允许的字符",
+	"preflight.name.trailing_dot":    "不能以 '.' 结尾",
+	"preflight.name.reserved":        "'%s' 是 Windows 保留名称",
+	"preflight.dir_exists":           "目录 '%s' 已存在",
+	"preflight.dir_exists_remedy":    "使用 --force 覆盖、--backup 备份后重新生成、--merge 合并到已有目录，或换一个项目名称",
+	"preflight.file_exists":          "'%s' 是一个已存在的文件",
+	"preflight.file_exists_remedy":   "删除该文件或换一个项目名称",
+	"preflight.unwritable":           "目标目录 '%s' 不可写: %v",
+	"preflight.unwritable_remedy":    "切换到有写权限的目录后重试",
+	"preflight.tool_missing":         "未找到 %s 命令",
+	"preflight.tool_git_remedy":      "安装 Git: https://git-scm.com/downloads",
+	"preflight.tool_go_remedy":       "安装 Go 并确认 go 命令在 PATH 中: https://go.dev/dl/",
+	"preflight.disk_label_temp":      "临时目录",
+	"preflight.disk_label_target":    "目标目录",
+	"preflight.disk_low":             "%s '%s' 剩余空间不足: %s (至少需要 %s)",
+	"preflight.disk_remedy":          "清理磁盘空间，或通过 TMPDIR 环境变量指定其他临时目录",
+	"preflight.ssh_missing":          "当前使用 SSH 协议克隆，但未找到 SSH 私钥或 ssh-agent",
+	"preflight.ssh_remedy":           "使用 --https 参数改用 HTTPS，或执行 ssh-keygen -t ed25519 并将公钥添加到 GitHub/Gitee",
+
+	"git.running":           "执行命令: git %s",
+	"git.done_in":           "下载完成，耗时 %v",
+	"git.done":              "下载完成",
+	"git.error.stdout_pipe": "获取标准输出管道失败",
+	"git.error.stderr_pipe": "获取标准错误管道失败",
+	"git.error.start":       "启动 git clone 失败",
+	"git.error.timeout":     "下载超时，已耗时 %v",
+	"git.error.auth":        "认证失败 (耗时 %v)。请尝试切换协议: goravel-kit-cli new %s --https",
+	"git.error.not_found":   "仓库不存在: %s (耗时 %v)",
+	"git.error.branch":      "分支 '%s' 不存在 (耗时 %v)",
+	"git.error.host_key":    "SSH 主机密钥验证失败，请检查 SSH 配置 (known_hosts)",
+	"git.error.failed":      "git clone 失败 (耗时 %v)",
+
+	"doctor.title":               "Goravel Kit 环境检查",
+	"doctor.summary":             "通过: %d  警告: %d  失败: %d",
+	"doctor.not_installed":       "未安装或不在 PATH 中",
+	"doctor.unknown_version":     "已安装，但无法识别版本号",
+	"doctor.too_old":             "%s 低于模板要求的 %s",
+	"doctor.goenv_failed":        "无法读取 go env",
+	"doctor.goenv_remedy":        "确认 Go 已正确安装",
+	"doctor.not_in_path":         "%s 不在 PATH 中",
+	"doctor.ssh_missing":         "未找到 SSH 私钥，默认的 SSH 克隆方式将失败",
+	"doctor.ssh_remedy":          "ssh-keygen -t ed25519 并添加到 GitHub/Gitee，或使用 --https",
+	"doctor.reachable":           "%s 可访问",
+	"doctor.unreachable":         "%s 无法连接",
+	"doctor.remedy.github_https": "检查网络或代理设置，或使用 --gitee-only",
+	"doctor.remedy.gitee_https":  "检查网络或代理设置，或使用 --github-only",
+	"doctor.remedy.ssh_port":     "防火墙可能屏蔽了 22 端口，可使用 --https",
+	"doctor.remedy.mysql":        "启动本地 MySQL 5.7+，或在 .env 中配置远程数据库",
+	"doctor.remedy.redis":        "启动本地 Redis，或在 .env 中配置远程 Redis",
+
+	"upgrade.fetching":             "正在获取模板 %s（%s → %s）",
+	"upgrade.up_to_date":           "项目已是最新模板提交 %s",
+	"upgrade.no_changes":           "模板改动已全部存在于项目中",
+	"upgrade.plan":                 "升级计划（%d 个文件）：",
+	"upgrade.binary":               "二进制文件 %s 有差异",
+	"upgrade.reject_preview":       "# 将写入 %s：",
+	"upgrade.done":                 "已升级到模板提交 %s",
+	"upgrade.done_conflicts":       "已升级到模板提交 %s，存在 %d 处冲突，请处理冲突标记或 .rej 文件后提交",
+	"upgrade.error.conflict_style": "不支持的 --conflict 取值 %q（markers、rej）",
+	"template.error.read_metadata": "读取模板元信息失败",
+	"upgrade.error.no_base":        "未找到 %s，请使用 --base 指定项目创建时的模板提交",
+	"upgrade.error.fetch":          "获取模板 %s 失败",
+	"upgrade.error.plan":           "计算升级改动失败",
+	"upgrade.error.apply":          "应用升级改动失败",
+	"upgrade.error.write_metadata": "更新模板元信息失败",
+
+	"diff.fetching":      "正在重建模板 %s（%s）",
+	"diff.identical":     "项目与模板提交 %s 一致",
+	"diff.binary":        "二进制文件 %s 有差异",
+	"diff.binary_short":  "二进制",
+	"diff.summary":       "%d 个文件与模板 %s 不同，新增 %d 行(+)，删除 %d 行(-)",
+	"diff.error.no_ref":  "未找到 %s，请使用 --ref 指定要比较的模板版本",
+	"diff.error.fetch":   "获取模板 %s 失败",
+	"diff.error.compare": "比较项目与模板失败",
+
+	"features.removed":        "已移除可选模块: %s",
+	"features.modified":       "已更新 %s",
+	"features.error.manifest": "读取模板功能清单失败",
+	"features.error.select":   "功能选择无效",
+	"features.error.remove":   "移除可选模块失败",
+
+	"new.next.frontend": "启动前端：",
+	"new.next.api_only": "纯 API 项目：未生成前端，接口位于 /api 下",
+
+	"driver.applied":      "已应用驱动: %s",
+	"driver.created":      "已创建 %s",
+	"driver.required":     "已添加依赖 %s",
+	"driver.error.select": "驱动选择无效",
+	"driver.error.apply":  "应用数据库/缓存驱动失败",
+	"new.next.sqlite":     "已创建 SQLite 数据库 %s，无需数据库服务",
+
+	"docker.created":        "已创建 %s",
+	"docker.skipped":        "%s 已存在，已跳过（使用 --force 覆盖）",
+	"docker.env_wired":      "已将 .env 中的数据库和 Redis 地址指向 docker compose 服务",
+	"docker.env_kept":       "已保留已有的 .env，使用 docker compose 服务需在其中设置以下配置项:",
+	"docker.services":       "服务: %s",
+	"docker.error.generate": "生成 Docker 配置失败",
+	"docker.next":           "使用 docker compose up -d --build 启动全部服务",
+	"new.next.docker":       "使用 Docker 运行:",
+
+	"ci.created":        "已创建 %s（任务: %s）",
+	"ci.skipped":        "%s 已存在，已跳过（使用 --force 覆盖）",
+	"ci.error.provider": "CI 平台无效",
+	"ci.error.generate": "生成 CI 工作流失败",
+
+	"make.error.resource_required": "请提供资源名称",
+	"make.error.fields":            "字段定义无效",
+	"make.error.generate":          "生成 CRUD 代码失败",
+	"make.created":                 "已创建 %s",
+	"make.registered":              "已注册到 %s",
+	"make.manual":                  "请手动完成以下注册:",
+	"make.next":                    "执行 go run . artisan migrate 创建数据表，然后为 %s 页面添加菜单",
+	"make.error.permissions":       "生成权限种子失败",
+	"make.permission.index":        "%s列表",
+	"make.permission.list":         "%s全部列表",
+	"make.permission.option":       "%s选项",
+	"make.permission.show":         "查看%s",
+	"make.permission.store":        "新增%s",
+	"make.permission.update":       "编辑%s",
+	"make.permission.destroy":      "删除%s",
+	"make.permission.custom":       "%s %s",
+	"make.permission.menu":         "菜单 %s",
+	"make.permission.codes":        "权限: %s",
+	"make.permission.next":         "执行 go run . artisan db:seed --seeder=%s 写入数据，然后在角色管理中分配权限",
+
+	"gen.error.generate": "生成前端接口客户端失败",
+	"gen.error.stale":    "前端接口客户端不是最新的，请执行 goravel-kit-cli gen:api-client",
+	"gen.spec":           "读取 %s（%d 个接口，%d 个数据结构）",
+	"gen.updated":        "已写入 %s",
+	"gen.removed":        "已删除 %s",
+	"gen.unchanged":      "%s 中的接口客户端已是最新",
+	"gen.stale":          "需要更新: %s",
+
+	"routes.error.analyze": "分析路由失败",
+	"routes.empty":         "未找到路由",
+	"routes.summary":       "共 %d 条路由",
+
+	"plugin.error.source_required": "请指定插件文件或 Go 包路径",
+	"plugin.error.name_required":   "请指定插件名称",
+	"plugin.error.install":         "安装插件失败",
+	"plugin.error.remove":          "删除插件失败",
+	"plugin.error.run":             "运行插件 %s 失败",
+	"plugin.installed":             "已将插件 %s 安装到 %s",
+	"plugin.removed":               "已删除 %s",
+	"plugin.empty":                 "在 %s 和 PATH 中未找到插件",
+	"plugin.conflict":              "插件 %s 与内置命令同名，已被忽略",
+	"plugin.shadowed":              "%s 被 %s 覆盖",
+
+	"hooks.error.config": "配置文件中的钩子无效",
+	"hooks.error.failed": "生命周期钩子执行失败",
+	"hooks.running":      "执行 %s 钩子: %s",
+}
@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// consoleLevel 控制台日志级别，可在运行时通过 --verbose 调整
+var consoleLevel = new(slog.LevelVar)
+
+func init() {
+	consoleLevel.Set(slog.LevelWarn)
+}
+
+// Options 日志初始化参数
+type Options struct {
+	// Level 控制台日志级别: debug、info、warn、error
+	Level string
+	// File 日志文件路径，设置后所有级别的日志都会写入该文件
+	File string
+	// Console 控制台日志输出目标，默认为 os.Stderr
+	Console io.Writer
+}
+
+// ParseLevel 解析日志级别字符串
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelWarn, fmt.Errorf("unsupported log level %q (debug, info, warn, error)", level)
+}
+
+// Setup 初始化全局 slog 日志，返回用于关闭日志文件的函数
+func Setup(opts Options) (func() error, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+	consoleLevel.Set(level)
+
+	console := opts.Console
+	if console == nil {
+		console = os.Stderr
+	}
+	handlers := []slog.Handler{
+		slog.NewTextHandler(console, &slog.HandlerOptions{
+			Level: consoleLevel,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				// 控制台输出省略时间戳
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+	}
+
+	closeFn := func() error { return nil }
+	if opts.File != "" {
+		file, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		handlers = append(handlers, slog.NewTextHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		closeFn = file.Close
+	}
+
+	slog.SetDefault(slog.New(fanoutHandler(handlers)))
+	return closeFn, nil
+}
+
+// SetConsoleLevel 调整控制台日志级别
+func SetConsoleLevel(level slog.Level) {
+	consoleLevel.Set(level)
+}
+
+// Verbose 控制台是否输出调试信息
+func Verbose() bool {
+	return consoleLevel.Level() <= slog.LevelDebug
+}
+
+// LogEnvironment 记录运行环境摘要，便于用户在问题反馈中附带日志文件。
+// 控制台和日志文件都不输出调试信息时直接返回，避免每次运行都执行 go version 和 git version
+func LogEnvironment(version string, args []string) {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	cwd, _ := os.Getwd()
+	attrs := []any{
+		"version", version,
+		"args", strings.Join(args, " "),
+		"os", runtime.GOOS,
+		"arch", runtime.GOARCH,
+		"go_runtime", runtime.Version(),
+		"cwd", cwd,
+		"lang", os.Getenv("LANG"),
+	}
+	for _, tool := range []string{"go", "git"} {
+		out, err := exec.Command(tool, "version").Output()
+		if err != nil {
+			attrs = append(attrs, tool, "unavailable")
+			continue
+		}
+		attrs = append(attrs, tool, strings.TrimSpace(string(out)))
+	}
+	slog.Debug("environment", attrs...)
+}
+
+// fanoutHandler 将日志记录分发给多个 handler，每个 handler 按自己的级别过滤
+type fanoutHandler []slog.Handler
+
+func (h fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var firstErr error
+	for _, handler := range h {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (h fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (h fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"":      slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+	for input, want := range cases {
+		got, err := ParseLevel(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseLevel(%q)=%v,%v want %v", input, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("trace"); err == nil {
+		t.Fatalf("expected error for unsupported level")
+	}
+}
+
+func TestSetup_FileReceivesAllLevels(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	logFile := filepath.Join(t.TempDir(), "cli.log")
+	var console bytes.Buffer
+	closeFn, err := Setup(Options{Level: "warn", File: logFile, Console: &console})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	slog.Debug("git output", "line", "Receiving objects: 100%")
+	slog.Warn("mirror failed", "mirror", "GitHub")
+	if err := closeFn(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(content), "git output") || !strings.Contains(string(content), "mirror failed") {
+		t.Fatalf("expected both records in log file, got: %s", content)
+	}
+	if strings.Contains(console.String(), "git output") {
+		t.Fatalf("debug record should not reach console at warn level: %s", console.String())
+	}
+	if !strings.Contains(console.String(), "mirror failed") {
+		t.Fatalf("expected warn record on console, got: %s", console.String())
+	}
+	if Verbose() {
+		t.Fatalf("expected Verbose() to be false at warn level")
+	}
+
+	SetConsoleLevel(slog.LevelDebug)
+	defer SetConsoleLevel(slog.LevelWarn)
+	if !Verbose() {
+		t.Fatalf("expected Verbose() after lowering console level")
+	}
+}
+
+func TestLogEnvironment_SkipsToolsWithoutDebug(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as a fake go binary")
+	}
+	defer slog.SetDefault(slog.Default())
+
+	bin := t.TempDir()
+	marker := filepath.Join(bin, "called")
+	script := "#!/bin/sh\n: > " + marker + "\necho go version test\n"
+	if err := os.WriteFile(filepath.Join(bin, "go"), []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write fake go: %v", err)
+	}
+	t.Setenv("PATH", bin)
+
+	var console bytes.Buffer
+	if _, err := Setup(Options{Level: "warn", Console: &console}); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	LogEnvironment("test", []string{"goravel-kit-cli", "new"})
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatalf("expected go version not to run when debug logging is disabled")
+	}
+
+	SetConsoleLevel(slog.LevelDebug)
+	defer SetConsoleLevel(slog.LevelWarn)
+	LogEnvironment("test", []string{"goravel-kit-cli", "new"})
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("expected go version to run at debug level: %v", err)
+	}
+	if !strings.Contains(console.String(), "go version test") {
+		t.Fatalf("expected environment record on console, got: %s", console.String())
+	}
+}
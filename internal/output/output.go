@@ -0,0 +1,157 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// 输出格式
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// 事件类型
+const (
+	EventProbe         = "probe"
+	EventMirrorAttempt = "mirror_attempt"
+	EventCloneProgress = "clone_progress"
+	EventFileRemoved   = "file_removed"
+	EventStepResult    = "step_result"
+	EventFileChange    = "file_change"
+	EventDone          = "done"
+)
+
+// Event NDJSON 模式下输出的一条结构化事件
+type Event struct {
+	Event string         `json:"event"`
+	Time  time.Time      `json:"time"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+var (
+	mu     sync.Mutex
+	format           = FormatText
+	writer io.Writer = os.Stdout
+)
+
+// SetFormat 设置全局输出格式，非文本模式下屏蔽所有彩色文本输出
+func SetFormat(f string) error {
+	switch f {
+	case "", FormatText:
+		f = FormatText
+	case FormatJSON, FormatNDJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q (text, json, ndjson)", f)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	format = f
+	if f == FormatText {
+		color.Output = writer
+	} else {
+		color.Output = io.Discard
+	}
+	return nil
+}
+
+// SetWriter 设置输出目标，主要用于测试
+func SetWriter(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	writer = w
+	if format == FormatText {
+		color.Output = w
+	}
+}
+
+// Format 返回当前输出格式
+func Format() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return format
+}
+
+// IsText 是否为面向终端的文本输出
+func IsText() bool {
+	return Format() == FormatText
+}
+
+// Text 返回文本输出的目标，非文本模式下丢弃所有写入
+func Text() io.Writer {
+	mu.Lock()
+	defer mu.Unlock()
+	if format != FormatText {
+		return io.Discard
+	}
+	return writer
+}
+
+// Printf 仅在文本模式下输出
+func Printf(format string, args ...any) {
+	fmt.Fprintf(Text(), format, args...)
+}
+
+// Emit 在 NDJSON 模式下输出一条事件，其他模式忽略
+func Emit(event string, data map[string]any) {
+	mu.Lock()
+	defer mu.Unlock()
+	if format != FormatNDJSON {
+		return
+	}
+	line, err := json.Marshal(Event{Event: event, Time: time.Now(), Data: data})
+	if err != nil {
+		return
+	}
+	writer.Write(append(line, '\n'))
+}
+
+// Result 输出命令的最终结果：JSON 模式下打印结果对象，NDJSON 模式下作为 done 事件输出
+func Result(result any) error {
+	switch Format() {
+	case FormatJSON:
+		mu.Lock()
+		defer mu.Unlock()
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case FormatNDJSON:
+		data, err := toMap(result)
+		if err != nil {
+			return err
+		}
+		Emit(EventDone, data)
+	}
+	return nil
+}
+
+// Fail 以结果对象的形式输出错误，文本模式下不做任何处理
+func Fail(err error) {
+	if err == nil || IsText() {
+		return
+	}
+	Result(map[string]any{"success": false, "error": err.Error()})
+}
+
+func toMap(v any) (map[string]any, error) {
+	if m, ok := v.(map[string]any); ok {
+		return m, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
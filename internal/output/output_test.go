@@ -0,0 +1,94 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func withFormat(t *testing.T, f string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	SetWriter(&buf)
+	if err := SetFormat(f); err != nil {
+		t.Fatalf("SetFormat(%q) failed: %v", f, err)
+	}
+	t.Cleanup(func() {
+		SetFormat(FormatText)
+		SetWriter(os.Stdout)
+	})
+	return &buf
+}
+
+func TestSetFormat_RejectsUnknown(t *testing.T) {
+	if err := SetFormat("yaml"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+}
+
+func TestNDJSON_EmitsEventsAndDone(t *testing.T) {
+	buf := withFormat(t, FormatNDJSON)
+
+	Printf("this text must not appear\n")
+	color.New(color.FgRed).Printf("neither should this\n")
+	Emit(EventMirrorAttempt, map[string]any{"mirror": "Gitee"})
+	if err := Result(struct {
+		Success bool   `json:"success"`
+		Path    string `json:"path"`
+	}{Success: true, Path: "/tmp/app"}); err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+
+	var events []Event
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d: %s", len(events), buf.String())
+	}
+	if events[0].Event != EventMirrorAttempt || events[0].Data["mirror"] != "Gitee" {
+		t.Fatalf("unexpected first event: %+v", events[0])
+	}
+	if events[1].Event != EventDone || events[1].Data["path"] != "/tmp/app" {
+		t.Fatalf("unexpected done event: %+v", events[1])
+	}
+}
+
+func TestJSON_PrintsOnlyResult(t *testing.T) {
+	buf := withFormat(t, FormatJSON)
+
+	Emit(EventProbe, map[string]any{"reachable": true})
+	Fail(errors.New("boom"))
+
+	var result map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("expected a single JSON object, got %q: %v", buf.String(), err)
+	}
+	if result["success"] != false || result["error"] != "boom" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestText_PassesThrough(t *testing.T) {
+	buf := withFormat(t, FormatText)
+
+	Printf("hello %s\n", "world")
+	Emit(EventProbe, nil)
+	if err := Result(map[string]any{"success": true}); err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+	if buf.String() != "hello world\n" {
+		t.Fatalf("unexpected text output: %q", buf.String())
+	}
+}
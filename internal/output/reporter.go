@@ -0,0 +1,53 @@
+package output
+
+import (
+	"io"
+
+	"github.com/fatih/color"
+)
+
+// Reporter 单次操作的输出目标：文本写入 Out，结构化事件交给 Events。
+// CLI 使用 Default 返回的全局输出，库调用方可以提供自己的 Writer 和事件接收者
+type Reporter struct {
+	// Out 文本输出，为 nil 时丢弃
+	Out io.Writer
+	// Events 结构化事件接收者，为 nil 时忽略
+	Events func(event string, data map[string]any)
+	// Verbose 是否输出详细信息
+	Verbose bool
+}
+
+// Default 返回使用全局输出格式的 Reporter
+func Default(verbose bool) *Reporter {
+	return &Reporter{Out: Text(), Events: Emit, Verbose: verbose}
+}
+
+// Writer 返回文本输出目标
+func (r *Reporter) Writer() io.Writer {
+	if r == nil || r.Out == nil {
+		return io.Discard
+	}
+	return r.Out
+}
+
+// Printf 以指定颜色输出文本
+func (r *Reporter) Printf(c *color.Color, format string, args ...any) {
+	c.Fprintf(r.Writer(), format, args...)
+}
+
+// Emit 输出一条结构化事件
+func (r *Reporter) Emit(event string, data map[string]any) {
+	if r == nil || r.Events == nil {
+		return
+	}
+	r.Events(event, data)
+}
+
+// Step 输出单个处理步骤的执行结果事件
+func (r *Reporter) Step(step string, err error) {
+	data := map[string]any{"step": step, "success": err == nil}
+	if err != nil {
+		data["error"] = err.Error()
+	}
+	r.Emit(EventStepResult, data)
+}
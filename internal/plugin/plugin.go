@@ -0,0 +1,250 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix 插件可执行文件的名称前缀，goravel-kit-cli-foo 对应 goravel-kit-cli foo
+const Prefix = "goravel-kit-cli-"
+
+// 插件的来源
+const (
+	SourceDir  = "plugins_dir"
+	SourcePath = "path"
+)
+
+// Plugin 一个已发现的插件
+type Plugin struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	// Shadowed 被插件目录或 PATH 中更靠前的同名插件覆盖的路径
+	Shadowed []string `json:"shadowed,omitempty"`
+}
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidName 判断插件名称是否合法：小写字母、数字、- 和 _
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Discover 在插件目录和 PATH 中查找插件，插件目录优先，同名插件取最先找到的一个
+func Discover(pluginsDir string) []Plugin {
+	found := map[string]*Plugin{}
+	var order []string
+	scan := func(dir, source string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !executable(path) {
+				continue
+			}
+			if existing, ok := found[name]; ok {
+				if existing.Path != path {
+					existing.Shadowed = append(existing.Shadowed, path)
+				}
+				continue
+			}
+			found[name] = &Plugin{Name: name, Path: path, Source: source}
+			order = append(order, name)
+		}
+	}
+
+	if pluginsDir != "" {
+		scan(pluginsDir, SourceDir)
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir != "" && dir != pluginsDir {
+			scan(dir, SourcePath)
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(order))
+	for _, name := range order {
+		plugins = append(plugins, *found[name])
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// Find 按名称查找插件
+func Find(pluginsDir, name string) (Plugin, bool) {
+	for _, p := range Discover(pluginsDir) {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
+// pluginName 从文件名中取出插件名称，Windows 下去掉可执行文件扩展名
+func pluginName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		file = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	name, ok := strings.CutPrefix(file, Prefix)
+	if !ok || !ValidName(name) {
+		return "", false
+	}
+	return name, true
+}
+
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0111 != 0
+}
+
+// Run 执行插件，标准输入输出直接交给插件，env 追加到当前环境变量之后
+func Run(ctx context.Context, p Plugin, args, env []string) error {
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Run()
+}
+
+// ExitCode 返回插件退出码，插件未能启动时返回 -1
+func ExitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// Install 将本地可执行文件复制到插件目录，或对 Go 包路径（如 example.com/goravel-kit-cli-foo@latest）
+// 执行 go install 安装到插件目录，返回安装后的插件
+func Install(ctx context.Context, pluginsDir, source, name string) (Plugin, error) {
+	if pluginsDir == "" {
+		return Plugin{}, fmt.Errorf("plugins directory is not configured")
+	}
+	if err := os.MkdirAll(pluginsDir, 0755); err != nil {
+		return Plugin{}, err
+	}
+
+	if info, err := os.Stat(source); err == nil {
+		if info.IsDir() {
+			return Plugin{}, fmt.Errorf("%s is a directory, expected an executable", source)
+		}
+		if name == "" {
+			name = strings.TrimPrefix(filepath.Base(source), Prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+		}
+		if !ValidName(name) {
+			return Plugin{}, fmt.Errorf("invalid plugin name %q (use --name)", name)
+		}
+		target := filepath.Join(pluginsDir, Prefix+name+exeSuffix())
+		if err := copyExecutable(source, target); err != nil {
+			return Plugin{}, err
+		}
+		return Plugin{Name: name, Path: target, Source: SourceDir}, nil
+	}
+
+	// 非本地文件按 Go 包路径处理
+	pkg, _, _ := strings.Cut(source, "@")
+	base := filepath.Base(pkg)
+	if !strings.HasPrefix(base, Prefix) {
+		return Plugin{}, fmt.Errorf("%s is neither a local file nor a Go package named %s<name>", source, Prefix)
+	}
+	if !strings.Contains(source, "@") {
+		source += "@latest"
+	}
+	abs, err := filepath.Abs(pluginsDir)
+	if err != nil {
+		return Plugin{}, err
+	}
+	cmd := exec.CommandContext(ctx, "go", "install", source)
+	cmd.Env = append(os.Environ(), "GOBIN="+abs)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return Plugin{}, fmt.Errorf("go install %s: %w\n%s", source, err, strings.TrimSpace(string(out)))
+	}
+	installed := strings.TrimPrefix(base, Prefix)
+	target := filepath.Join(abs, base+exeSuffix())
+	if name != "" && name != installed {
+		if !ValidName(name) {
+			return Plugin{}, fmt.Errorf("invalid plugin name %q", name)
+		}
+		renamed := filepath.Join(abs, Prefix+name+exeSuffix())
+		if err := os.Rename(target, renamed); err != nil {
+			return Plugin{}, err
+		}
+		target, installed = renamed, name
+	}
+	return Plugin{Name: installed, Path: target, Source: SourceDir}, nil
+}
+
+// Remove 删除插件目录中的插件；PATH 中的插件不由本工具管理，需要手动删除
+func Remove(pluginsDir, name string) (string, error) {
+	p, ok := Find(pluginsDir, name)
+	if !ok {
+		return "", fmt.Errorf("plugin %q not found", name)
+	}
+	if p.Source != SourceDir {
+		return "", fmt.Errorf("plugin %q is installed at %s outside the plugins directory, remove it manually", name, p.Path)
+	}
+	if err := os.Remove(p.Path); err != nil {
+		return "", err
+	}
+	return p.Path, nil
+}
+
+func copyExecutable(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// 先写入临时文件再改名，避免正在运行的旧插件被截断
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".install-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
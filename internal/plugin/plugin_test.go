@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeScript(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0755); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in these tests")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	skipOnWindows(t)
+	root := t.TempDir()
+	pluginsDir := filepath.Join(root, "plugins")
+	binA := filepath.Join(root, "a")
+	binB := filepath.Join(root, "b")
+
+	dirDeploy := writeScript(t, pluginsDir, Prefix+"deploy", "exit 0")
+	pathDeploy := writeScript(t, binA, Prefix+"deploy", "exit 0")
+	writeScript(t, binA, Prefix+"lint", "exit 0")
+	shadowedLint := writeScript(t, binB, Prefix+"lint", "exit 0")
+	writeScript(t, binB, Prefix+"Bad Name", "exit 0")
+	writeScript(t, binB, "other-tool", "exit 0")
+	if err := os.WriteFile(filepath.Join(binB, Prefix+"noexec"), []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	t.Setenv("PATH", strings.Join([]string{binA, binB}, string(os.PathListSeparator)))
+
+	plugins := Discover(pluginsDir)
+	if len(plugins) != 2 {
+		t.Fatalf("expected 2 plugins, got %+v", plugins)
+	}
+	deploy, lint := plugins[0], plugins[1]
+	if deploy.Name != "deploy" || deploy.Path != dirDeploy || deploy.Source != SourceDir {
+		t.Fatalf("expected plugins dir to take precedence, got %+v", deploy)
+	}
+	if len(deploy.Shadowed) != 1 || deploy.Shadowed[0] != pathDeploy {
+		t.Fatalf("expected PATH plugin to be shadowed, got %+v", deploy.Shadowed)
+	}
+	if lint.Name != "lint" || lint.Source != SourcePath || len(lint.Shadowed) != 1 || lint.Shadowed[0] != shadowedLint {
+		t.Fatalf("unexpected lint plugin: %+v", lint)
+	}
+
+	if _, ok := Find(pluginsDir, "noexec"); ok {
+		t.Fatalf("non-executable files must not be plugins")
+	}
+}
+
+func TestInstallAndRemove(t *testing.T) {
+	skipOnWindows(t)
+	root := t.TempDir()
+	pluginsDir := filepath.Join(root, "plugins")
+	source := writeScript(t, filepath.Join(root, "src"), Prefix+"deploy", "exit 0")
+	t.Setenv("PATH", "")
+
+	installed, err := Install(context.Background(), pluginsDir, source, "")
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	if installed.Name != "deploy" || installed.Path != filepath.Join(pluginsDir, Prefix+"deploy") {
+		t.Fatalf("unexpected installed plugin: %+v", installed)
+	}
+	info, err := os.Stat(installed.Path)
+	if err != nil || info.Mode().Perm()&0111 == 0 {
+		t.Fatalf("expected an executable plugin, got %v %v", info, err)
+	}
+
+	renamed, err := Install(context.Background(), pluginsDir, source, "ship")
+	if err != nil || renamed.Name != "ship" {
+		t.Fatalf("expected install with --name, got %+v %v", renamed, err)
+	}
+	if _, err := Install(context.Background(), pluginsDir, source, "Bad Name"); err == nil {
+		t.Fatalf("expected invalid name error")
+	}
+	if _, err := Install(context.Background(), pluginsDir, "example.com/not-a-plugin", ""); err == nil {
+		t.Fatalf("expected error for a package without the plugin prefix")
+	}
+
+	removed, err := Remove(pluginsDir, "deploy")
+	if err != nil || removed != installed.Path {
+		t.Fatalf("Remove failed: %q %v", removed, err)
+	}
+	if _, ok := Find(pluginsDir, "deploy"); ok {
+		t.Fatalf("expected plugin to be removed")
+	}
+	if _, err := Remove(pluginsDir, "deploy"); err == nil {
+		t.Fatalf("expected not found error")
+	}
+}
+
+func TestRemoveRefusesPathPlugins(t *testing.T) {
+	skipOnWindows(t)
+	root := t.TempDir()
+	bin := filepath.Join(root, "bin")
+	path := writeScript(t, bin, Prefix+"lint", "exit 0")
+	t.Setenv("PATH", bin)
+
+	if _, err := Remove(filepath.Join(root, "plugins"), "lint"); err == nil || !strings.Contains(err.Error(), "remove it manually") {
+		t.Fatalf("expected refusal to remove PATH plugin, got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("PATH plugin must be kept: %v", err)
+	}
+}
+
+func TestRunAndExitCode(t *testing.T) {
+	skipOnWindows(t)
+	root := t.TempDir()
+	out := filepath.Join(root, "out")
+	script := writeScript(t, root, Prefix+"echo", `echo "$1 $GORAVEL_KIT_PLUGIN_NAME" > "`+out+`"; exit 3`)
+
+	err := Run(context.Background(), Plugin{Name: "echo", Path: script}, []string{"--flag"}, []string{"GORAVEL_KIT_PLUGIN_NAME=echo"})
+	if code := ExitCode(err); code != 3 {
+		t.Fatalf("expected exit code 3, got %d (%v)", code, err)
+	}
+	content, err := os.ReadFile(out)
+	if err != nil || strings.TrimSpace(string(content)) != "--flag echo" {
+		t.Fatalf("expected args and env to reach the plugin, got %q %v", content, err)
+	}
+	if code := ExitCode(os.ErrNotExist); code != -1 {
+		t.Fatalf("expected -1 for start failures, got %d", code)
+	}
+}
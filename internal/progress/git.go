@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// gitProgressPattern 匹配 git 的进度输出，例如
+// "Receiving objects:  45% (123/456), 1.20 MiB | 512.00 KiB/s"
+var gitProgressPattern = regexp.MustCompile(
+	`(Enumerating objects|Counting objects|Compressing objects|Receiving objects|Resolving deltas|Updating files):\s+(\d+)% \((\d+)/(\d+)\)(?:, ([\d.]+ [KMGT]?i?B)(?: \| ([\d.]+ [KMGT]?i?B/s))?)?`)
+
+// GitProgress 一条 git 进度信息
+type GitProgress struct {
+	Phase      string `json:"phase"`
+	Percent    int    `json:"percent"`
+	Current    int64  `json:"current"`
+	Total      int64  `json:"total"`
+	Received   string `json:"received,omitempty"`
+	Throughput string `json:"throughput,omitempty"`
+	Remote     bool   `json:"remote,omitempty"`
+}
+
+// ParseGitProgress 解析一行 git 进度输出
+func ParseGitProgress(line string) (GitProgress, bool) {
+	match := gitProgressPattern.FindStringSubmatch(line)
+	if match == nil {
+		return GitProgress{}, false
+	}
+	percent, _ := strconv.Atoi(match[2])
+	current, _ := strconv.ParseInt(match[3], 10, 64)
+	total, _ := strconv.ParseInt(match[4], 10, 64)
+	return GitProgress{
+		Phase:      match[1],
+		Percent:    percent,
+		Current:    current,
+		Total:      total,
+		Received:   match[5],
+		Throughput: match[6],
+		Remote:     strings.HasPrefix(strings.TrimSpace(line), "remote:"),
+	}, true
+}
+
+// Detail 返回用于进度条展示的附加信息（已接收字节数和速率）
+func (p GitProgress) Detail() string {
+	switch {
+	case p.Received != "" && p.Throughput != "":
+		return p.Received + " | " + p.Throughput
+	default:
+		return p.Received
+	}
+}
+
+// ScanLinesOrCR 是 bufio.SplitFunc，按 '\n' 或 '\r' 切分，
+// 用于读取 git 以 '\r' 原地刷新的进度输出。"\r\n" 会产生一个空行，调用方应忽略空行
+func ScanLinesOrCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
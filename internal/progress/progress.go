@@ -0,0 +1,138 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// barWidth 进度条宽度（字符数）
+const barWidth = 30
+
+// DefaultInterval 非终端输出时两次进度行之间的最小间隔
+const DefaultInterval = 2 * time.Second
+
+// Bar 进度渲染器：终端下原地刷新进度条，非终端下按固定间隔输出普通文本行
+type Bar struct {
+	mu       sync.Mutex
+	w        io.Writer
+	tty      bool
+	interval time.Duration
+
+	label      string
+	lastPrint  time.Time
+	lastLine   string
+	lineActive bool
+}
+
+// New 根据 w 是否为终端创建进度渲染器
+func New(w io.Writer) *Bar {
+	return &Bar{w: w, tty: IsTerminal(w), interval: DefaultInterval}
+}
+
+// NewPlain 创建始终输出普通文本行的进度渲染器
+func NewPlain(w io.Writer, interval time.Duration) *Bar {
+	return &Bar{w: w, interval: interval}
+}
+
+// IsTerminal 判断 w 是否为交互式终端
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// Update 更新当前阶段的进度，total 为 0 时只显示计数
+func (b *Bar) Update(label string, current, total int64, detail string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	line := render(label, current, total, detail)
+	phaseChanged := label != b.label
+	finished := total > 0 && current >= total
+
+	if b.tty {
+		// 切换阶段时保留上一阶段的最终状态
+		if phaseChanged && b.lineActive {
+			fmt.Fprint(b.w, "\n")
+		}
+		fmt.Fprintf(b.w, "\r\033[K%s", line)
+		b.lineActive = true
+		b.lastLine = line
+	} else if phaseChanged || finished || time.Since(b.lastPrint) >= b.interval {
+		if line != b.lastLine {
+			fmt.Fprintln(b.w, line)
+			b.lastLine = line
+		}
+		b.lastPrint = time.Now()
+	}
+	b.label = label
+}
+
+// Println 在不破坏进度条的前提下输出一行文本
+func (b *Bar) Println(text string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tty && b.lineActive {
+		fmt.Fprint(b.w, "\r\033[K")
+		fmt.Fprintln(b.w, text)
+		fmt.Fprint(b.w, b.lastLine)
+		return
+	}
+	fmt.Fprintln(b.w, text)
+}
+
+// Done 结束进度输出，终端下换行以保留最后一次进度
+func (b *Bar) Done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tty && b.lineActive {
+		fmt.Fprint(b.w, "\n")
+	}
+	b.lineActive = false
+	b.label = ""
+}
+
+// render 生成一行进度文本
+func render(label string, current, total int64, detail string) string {
+	var b strings.Builder
+	b.WriteString("📦 ")
+	b.WriteString(label)
+	if total > 0 {
+		percent := current * 100 / total
+		if percent > 100 {
+			percent = 100
+		}
+		filled := int(percent) * barWidth / 100
+		fmt.Fprintf(&b, " [%s%s] %3d%% (%d/%d)",
+			strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled), percent, current, total)
+	} else {
+		fmt.Fprintf(&b, " %d", current)
+	}
+	if detail != "" {
+		b.WriteString(" ")
+		b.WriteString(detail)
+	}
+	return b.String()
+}
+
+// FormatBytes 将字节数格式化为易读的字符串
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
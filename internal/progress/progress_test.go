@@ -0,0 +1,97 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanLinesOrCR_SplitsCarriageReturns(t *testing.T) {
+	input := "Cloning into 'x'...\nReceiving objects:  10% (1/10)\rReceiving objects:  50% (5/10)\rReceiving objects: 100% (10/10), done.\r\nResolving deltas: 100% (2/2)"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(ScanLinesOrCR)
+
+	var lines []string
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	want := []string{
+		"Cloning into 'x'...",
+		"Receiving objects:  10% (1/10)",
+		"Receiving objects:  50% (5/10)",
+		"Receiving objects: 100% (10/10), done.",
+		"Resolving deltas: 100% (2/2)",
+	}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected lines:\n got %q\nwant %q", lines, want)
+	}
+}
+
+func TestParseGitProgress(t *testing.T) {
+	p, ok := ParseGitProgress("Receiving objects:  45% (123/456), 1.20 MiB | 512.00 KiB/s")
+	if !ok {
+		t.Fatalf("expected progress line to parse")
+	}
+	if p.Phase != "Receiving objects" || p.Percent != 45 || p.Current != 123 || p.Total != 456 {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+	if p.Received != "1.20 MiB" || p.Throughput != "512.00 KiB/s" || p.Detail() != "1.20 MiB | 512.00 KiB/s" {
+		t.Fatalf("unexpected throughput fields: %+v", p)
+	}
+
+	remote, ok := ParseGitProgress("remote: Counting objects: 100% (20/20), done.")
+	if !ok || !remote.Remote || remote.Phase != "Counting objects" {
+		t.Fatalf("expected remote progress, got %+v ok=%v", remote, ok)
+	}
+
+	if _, ok := ParseGitProgress("Cloning into 'x'..."); ok {
+		t.Fatalf("expected non-progress line to be ignored")
+	}
+}
+
+func TestBar_PlainThrottlesUpdates(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewPlain(&buf, time.Hour)
+
+	bar.Update("Receiving objects", 1, 10, "")
+	bar.Update("Receiving objects", 5, 10, "")
+	bar.Update("Receiving objects", 10, 10, "")
+	bar.Update("Resolving deltas", 2, 2, "")
+	bar.Done()
+
+	output := buf.String()
+	if strings.Contains(output, "\r") || strings.Contains(output, "\033[") {
+		t.Fatalf("plain output must not contain terminal control sequences: %q", output)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected first, final and phase-change lines only, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[1], "100% (10/10)") || !strings.Contains(lines[2], "Resolving deltas") {
+		t.Fatalf("unexpected lines: %q", lines)
+	}
+}
+
+func TestIsTerminal_NonFile(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Fatalf("bytes.Buffer must not be treated as a terminal")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		512:       "512 B",
+		2048:      "2.0 KiB",
+		200 << 20: "200.0 MiB",
+	}
+	for n, want := range cases {
+		if got := FormatBytes(n); got != want {
+			t.Fatalf("FormatBytes(%d)=%q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/ci"
+	"github.com/hulutech-web/goravel-kit-cli/internal/docker"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+)
+
+// GenerateDocker 生成 Docker 配置并输出生成的文件和服务
+func GenerateDocker(projectDir string, opts docker.Options, r *output.Reporter) (*docker.Report, error) {
+	report, err := docker.Generate(projectDir, opts)
+	r.Step("docker", err)
+	if err != nil {
+		return nil, fmt.Errorf("❌ %s: %w", i18n.T("docker.error.generate"), err)
+	}
+	for _, file := range report.CreatedFiles {
+		r.Printf(color.New(color.FgHiGreen), "🐳 %s\n", i18n.T("docker.created", file))
+	}
+	for _, file := range report.SkippedFiles {
+		r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("docker.skipped", file))
+	}
+	if len(report.ModifiedFiles) > 0 {
+		r.Printf(color.New(color.FgHiGreen), "📝 %s\n", i18n.T("docker.env_wired"))
+	}
+	if len(report.EnvValues) > 0 {
+		keys := make([]string, 0, len(report.EnvValues))
+		for key := range report.EnvValues {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("docker.env_kept"))
+		for _, key := range keys {
+			r.Printf(color.New(color.FgHiWhite), "   %s=%s\n", key, report.EnvValues[key])
+		}
+	}
+	services := report.Services
+	if len(services) == 0 {
+		services = []string{"-"}
+	}
+	r.Printf(color.New(color.FgHiWhite), "   %s\n", i18n.T("docker.services", strings.Join(services, ", ")))
+	return report, nil
+}
+
+// GenerateCI 生成 CI 工作流并输出生成的文件和任务
+func GenerateCI(projectDir string, opts ci.Options, r *output.Reporter) (*ci.Report, error) {
+	report, err := ci.Generate(projectDir, opts)
+	r.Step("ci", err)
+	if err != nil {
+		return nil, fmt.Errorf("❌ %s: %w", i18n.T("ci.error.generate"), err)
+	}
+	if report.Created {
+		r.Printf(color.New(color.FgHiGreen), "⚙️  %s\n", i18n.T("ci.created", report.File, strings.Join(report.Jobs, ", ")))
+	} else {
+		r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("ci.skipped", report.File))
+	}
+	return report, nil
+}
@@ -0,0 +1,148 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+)
+
+// 合并到已有目录时冲突的处理方式
+const (
+	// ConflictSkip 保留已有文件，不写入模板文件
+	ConflictSkip = "skip"
+	// ConflictOverwrite 用模板文件替换已有文件
+	ConflictOverwrite = "overwrite"
+	// ConflictRename 将已有文件重命名为 <name>.orig 后写入模板文件
+	ConflictRename = "rename"
+)
+
+// MergeConflict 合并时一处冲突及其处理结果
+type MergeConflict struct {
+	// Path 相对项目目录的路径
+	Path string `json:"path"`
+	// Action 实际采用的处理方式：skip、overwrite 或 rename
+	Action string `json:"action"`
+	// RenamedTo 处理方式为 rename 时已有文件的新路径，相对项目目录
+	RenamedTo string `json:"renamed_to,omitempty"`
+}
+
+// Merge 将 source 目录树合并到已存在的 destination 目录：
+// 不存在的文件和目录直接创建，已存在的目录继续合并其内容，内容相同的文件和链接目标相同的符号链接保持不变，
+// 其余情况（内容不同、文件与目录类型不同）交给 resolve 决定处理方式。
+// source 保持不变，由调用方清理；管道、设备等特殊文件不复制
+func Merge(fs fsys.FS, source, destination string, resolve func(path string) (string, error)) ([]MergeConflict, error) {
+	var conflicts []MergeConflict
+	err := fsys.Walk(fs, source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		target := filepath.Join(destination, rel)
+
+		existing, err := fs.Lstat(target)
+		if os.IsNotExist(err) {
+			return place(fs, path, target, info)
+		}
+		if err != nil {
+			return err
+		}
+		if same, err := sameEntry(fs, path, info, target, existing); err != nil || same {
+			return err
+		}
+
+		action, err := resolve(rel)
+		if err != nil {
+			return err
+		}
+		conflict := MergeConflict{Path: rel, Action: action}
+		switch action {
+		case ConflictSkip:
+			conflicts = append(conflicts, conflict)
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		case ConflictOverwrite:
+			if err := fs.RemoveAll(target); err != nil {
+				return err
+			}
+		case ConflictRename:
+			renamed := BackupName(fs, target, ".orig")
+			if err := fs.Rename(target, renamed); err != nil {
+				return err
+			}
+			conflict.RenamedTo, _ = filepath.Rel(destination, renamed)
+		default:
+			return fmt.Errorf("%s: unsupported conflict action %q", rel, action)
+		}
+		conflicts = append(conflicts, conflict)
+		return place(fs, path, target, info)
+	})
+	return conflicts, err
+}
+
+// place 在 target 处创建与 source 相同的目录、符号链接或普通文件
+func place(fs fsys.FS, source, target string, info os.FileInfo) error {
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		return fs.MkdirAll(target, 0755)
+	case mode&os.ModeSymlink != 0:
+		link, err := fs.Readlink(source)
+		if err != nil {
+			return err
+		}
+		return fs.Symlink(link, target)
+	case mode.IsRegular():
+		_, err := fsys.CopyFile(fs, source, target)
+		return err
+	}
+	return nil
+}
+
+// sameEntry 判断模板条目与已有条目是否无需处理：都是目录，内容相同的普通文件，或链接目标相同的符号链接
+func sameEntry(fs fsys.FS, source string, info os.FileInfo, target string, existing os.FileInfo) (bool, error) {
+	mode, existingMode := info.Mode(), existing.Mode()
+	switch {
+	case mode.IsDir() || existingMode.IsDir():
+		return mode.IsDir() && existingMode.IsDir(), nil
+	case mode&os.ModeSymlink != 0 || existingMode&os.ModeSymlink != 0:
+		if mode.Type() != existingMode.Type() {
+			return false, nil
+		}
+		want, err := fs.Readlink(source)
+		if err != nil {
+			return false, err
+		}
+		got, err := fs.Readlink(target)
+		return err == nil && got == want, nil
+	case mode.IsRegular() && existingMode.IsRegular():
+		if info.Size() != existing.Size() {
+			return false, nil
+		}
+		want, err := fs.ReadFile(source)
+		if err != nil {
+			return false, err
+		}
+		got, err := fs.ReadFile(target)
+		return err == nil && bytes.Equal(got, want), nil
+	}
+	return false, nil
+}
+
+// BackupName 返回 path 加 suffix 后尚不存在的路径，已存在时依次追加 .1、.2……
+func BackupName(fs fsys.FS, path, suffix string) string {
+	name := path + suffix
+	for i := 1; ; i++ {
+		if _, err := fs.Lstat(name); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s%s.%d", path, suffix, i)
+	}
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+)
+
+// MetadataFile 生成的项目中记录模板来源的文件
+const MetadataFile = ".goravel-kit.json"
+
+// DefaultRepository 项目中没有模板元信息时使用的模板仓库
+const DefaultRepository = "https://github.com/hulutech-web/goravel-kit.git"
+
+// Metadata 记录项目由哪个模板仓库的哪个提交生成，供 upgrade/diff 使用
+type Metadata struct {
+	Project    string `json:"project"`
+	Repository string `json:"repository"`
+	Mirror     string `json:"mirror,omitempty"`
+	Ref        string `json:"ref"`
+	SHA        string `json:"sha"`
+	// Without 生成时移除的功能，upgrade/diff 重建模板时同样移除
+	Without []string `json:"without,omitempty"`
+	// Database/Cache 生成时选择的驱动，为空表示模板默认值
+	Database   string    `json:"database,omitempty"`
+	Cache      string    `json:"cache,omitempty"`
+	CLIVersion string    `json:"cli_version,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+}
+
+// ReadMetadata 读取项目中的模板元信息
+func ReadMetadata(projectDir string) (*Metadata, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, MetadataFile))
+	if err != nil {
+		return nil, err
+	}
+	meta := &Metadata{}
+	if err := json.Unmarshal(content, meta); err != nil {
+		return nil, fmt.Errorf("%s: %w", MetadataFile, err)
+	}
+	return meta, nil
+}
+
+// LoadMetadata 读取项目的模板元信息；旧项目没有元信息时使用默认模板仓库，
+// repo 不为空时覆盖记录的模板仓库
+func LoadMetadata(projectDir, repo string) (*Metadata, error) {
+	meta, err := ReadMetadata(projectDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("❌ %s: %w", i18n.T("template.error.read_metadata"), err)
+		}
+		meta = &Metadata{Repository: DefaultRepository}
+	}
+	if absDir, err := filepath.Abs(projectDir); err == nil && meta.Project == "" {
+		meta.Project = filepath.Base(absDir)
+	}
+	if repo != "" {
+		meta.Repository = repo
+	}
+	return meta, nil
+}
+
+// WriteMetadata 写入项目的模板元信息
+func WriteMetadata(fs fsys.FS, projectDir string, meta *Metadata) error {
+	content, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(projectDir, MetadataFile), append(content, '\n'), 0644)
+}
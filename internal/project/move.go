@@ -0,0 +1,83 @@
+package project
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/progress"
+)
+
+// Move 跨平台的目录移动函数：同一磁盘分区时直接重命名，跨分区时以 concurrency 个协程并发复制后删除源目录，
+// concurrency 小于等于 0 时使用 fsys.DefaultConcurrency。重命名的其他错误直接返回；
+// 复制失败时删除已复制的部分，保留源目录
+func Move(fs fsys.FS, source, destination string, concurrency int, r *output.Reporter) error {
+	// 尝试直接重命名（同磁盘分区时有效）
+	err := fs.Rename(source, destination)
+	if err == nil || !fsys.IsCrossDevice(err) {
+		return err
+	}
+
+	// 跨磁盘分区，使用复制+删除的方式
+	r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("move.cross_device"))
+
+	// 创建目标目录，记录它是否由本次复制创建，失败时只删除自己创建的目录
+	_, statErr := fs.Lstat(destination)
+	created := os.IsNotExist(statErr)
+	if err := fs.MkdirAll(destination, 0755); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("move.error.mkdir"), err)
+	}
+	removePartial := func() {
+		if created {
+			fs.RemoveAll(destination)
+		}
+	}
+
+	// 统计普通文件的数量和总大小，用于显示复制进度和校验复制结果
+	var totalFiles, totalBytes int64
+	fsys.Walk(fs, source, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			totalFiles++
+			totalBytes += info.Size()
+		}
+		return nil
+	})
+
+	bar := progress.New(r.Writer())
+	var copiedFiles, copiedBytes int64
+	stats, err := fsys.CopyTree(fs, source, destination, fsys.CopyOptions{
+		Concurrency: concurrency,
+		Progress: func(path string, size int64) {
+			copiedFiles++
+			copiedBytes += size
+			bar.Update(i18n.T("move.copying"), copiedFiles, totalFiles,
+				progress.FormatBytes(copiedBytes)+" / "+progress.FormatBytes(totalBytes))
+		},
+	})
+	bar.Done()
+	if err != nil {
+		// 目标目录只包含不完整的副本，删除后再返回，源目录保持不变
+		removePartial()
+		return fmt.Errorf("%s: %w", i18n.T("move.error.copy"), err)
+	}
+	for _, path := range stats.Skipped {
+		r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("move.skipped_special", path))
+	}
+
+	// 复制的字节数与源目录不一致时保留源目录，避免丢失文件
+	if stats.Bytes != totalBytes {
+		removePartial()
+		return fmt.Errorf("%s", i18n.T("move.error.verify", stats.Bytes, totalBytes))
+	}
+
+	// 删除源目录
+	if err := fs.RemoveAll(source); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("move.error.cleanup"), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,363 @@
+package project
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+)
+
+func TestUpdateEnvFile_ReplacesValues(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "goravel-kit-cli-project-env-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	envPath := filepath.Join(tempDir, ".env")
+	content := "APP_NAME=Goravel\nAPP_URL=http://localhost\nOTHER=1\n"
+	if err := os.WriteFile(envPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write .env: %v", err)
+	}
+
+	if err := UpdateEnv(fsys.OS, tempDir, "my-app"); err != nil {
+		t.Fatalf("UpdateEnv failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(envPath)
+	if err != nil {
+		t.Fatalf("failed to read updated .env: %v", err)
+	}
+	got := string(updated)
+	if !strings.Contains(got, "APP_NAME=my-app") {
+		t.Fatalf("expected APP_NAME replaced, got: %s", got)
+	}
+	if !strings.Contains(got, "APP_URL=http://localhost:3000") {
+		t.Fatalf("expected APP_URL replaced, got: %s", got)
+	}
+	if !strings.Contains(got, "OTHER=1") {
+		t.Fatalf("unexpected modification of unrelated keys, got: %s", got)
+	}
+}
+
+func TestUpdateEnvFile_NoEnvFile_NoError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "goravel-kit-cli-project-env-missing-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := UpdateEnv(fsys.OS, tempDir, "my-app"); err != nil {
+		t.Fatalf("expected no error when .env missing, got: %v", err)
+	}
+}
+
+func TestMoveDirectoryCrossPlatform(t *testing.T) {
+	baseDir, err := os.MkdirTemp("", "goravel-kit-cli-project-move-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	srcDir := filepath.Join(baseDir, "src")
+	dstDir := filepath.Join(baseDir, "dst")
+
+	if err := os.MkdirAll(filepath.Join(srcDir, "a", "b"), 0755); err != nil {
+		t.Fatalf("failed to create nested dirs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "a", "b", "file.txt"), []byte("data"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if err := Move(fsys.OS, srcDir, dstDir, 0, nil); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dstDir, "a", "b", "file.txt")); err != nil {
+		t.Fatalf("expected moved file to exist: %v", err)
+	}
+	if _, err := os.Stat(srcDir); !os.IsNotExist(err) {
+		t.Fatalf("expected src dir removed; stat err=%v", err)
+	}
+}
+
+func TestTemplateMetadata_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadMetadata(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error, got: %v", err)
+	}
+
+	meta := &Metadata{
+		Project:    "demo",
+		Repository: DefaultRepository,
+		Ref:        "master",
+		SHA:        "0123456789abcdef",
+		CreatedAt:  time.Date(2025, 8, 22, 0, 0, 0, 0, time.UTC),
+	}
+	if err := WriteMetadata(fsys.OS, dir, meta); err != nil {
+		t.Fatalf("WriteMetadata failed: %v", err)
+	}
+	got, err := ReadMetadata(dir)
+	if err != nil {
+		t.Fatalf("ReadMetadata failed: %v", err)
+	}
+	if got.SHA != meta.SHA || got.Ref != meta.Ref || got.Project != meta.Project || !got.CreatedAt.Equal(meta.CreatedAt) {
+		t.Fatalf("unexpected metadata: %+v", got)
+	}
+}
+
+// newTemplateFS 返回包含一个小模板的内存文件系统，/tmp 与 /work 位于不同的设备
+func newTemplateFS(t *testing.T) *fsys.MemFS {
+	t.Helper()
+	mem := fsys.NewMemFS()
+	mem.Mount("/tmp")
+	mem.Mount("/work")
+	files := map[string]string{
+		"/tmp/staging/main.go":            "package main\n",
+		"/tmp/staging/.env.example":       "APP_NAME=Goravel\n",
+		"/tmp/staging/app/http/kernel.go": "package http\n",
+	}
+	for name, content := range files {
+		if err := mem.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := mem.WriteFile(name, []byte(content), 0640); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := mem.MkdirAll("/work", 0755); err != nil {
+		t.Fatalf("failed to create /work: %v", err)
+	}
+	return mem
+}
+
+func TestMove_CrossDeviceFallsBackToCopy(t *testing.T) {
+	mem := newTemplateFS(t)
+
+	if err := Move(mem, "/tmp/staging", "/work/shop", 0, nil); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+
+	content, err := mem.ReadFile("/work/shop/app/http/kernel.go")
+	if err != nil || string(content) != "package http\n" {
+		t.Fatalf("expected nested file to be copied, got %q %v", content, err)
+	}
+	info, err := mem.Stat("/work/shop/main.go")
+	if err != nil || info.Mode().Perm() != 0640 {
+		t.Fatalf("expected file mode to be preserved, got %v %v", info, err)
+	}
+	if fsys.DirExists(mem, "/tmp/staging") {
+		t.Fatalf("expected source dir to be removed after copying")
+	}
+}
+
+func TestMove_DiskFullKeepsSource(t *testing.T) {
+	mem := newTemplateFS(t)
+	// 只剩下不到一个文件的空间，复制时会出现部分写入
+	mem.Capacity = mem.Used() + 5
+
+	err := Move(mem, "/tmp/staging", "/work/shop", 0, nil)
+	if !errors.Is(err, syscall.ENOSPC) {
+		t.Fatalf("expected ENOSPC, got %v", err)
+	}
+	if !fsys.FileExists(mem, "/tmp/staging/main.go") {
+		t.Fatalf("expected source to be kept when copying fails")
+	}
+	if _, err := mem.Lstat("/work/shop"); !os.IsNotExist(err) {
+		t.Fatalf("expected the partial copy to be removed, lstat err=%v", err)
+	}
+}
+
+func TestMove_RenameErrorIsNotCopied(t *testing.T) {
+	mem := newTemplateFS(t)
+	mem.Fail(fsys.OpRename, "/tmp/staging", syscall.EACCES)
+
+	err := Move(mem, "/tmp/staging", "/work/shop", 0, nil)
+	if !errors.Is(err, syscall.EACCES) {
+		t.Fatalf("expected the rename error to be returned, got %v", err)
+	}
+	if _, err := mem.Lstat("/work/shop"); !os.IsNotExist(err) {
+		t.Fatalf("expected no copy for a non cross-device error, lstat err=%v", err)
+	}
+	if !fsys.FileExists(mem, "/tmp/staging/main.go") {
+		t.Fatalf("expected source to be kept")
+	}
+}
+
+func TestMove_PermissionDenied(t *testing.T) {
+	mem := newTemplateFS(t)
+	if err := mem.Chmod("/work", 0555); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+
+	err := Move(mem, "/tmp/staging", "/work/shop", 0, nil)
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+	if !fsys.DirExists(mem, "/tmp/staging") {
+		t.Fatalf("expected source to be kept when the destination is not writable")
+	}
+}
+
+func TestMove_CleanupFailure(t *testing.T) {
+	mem := newTemplateFS(t)
+	mem.Fail(fsys.OpRemove, "/tmp/staging", syscall.EBUSY)
+
+	err := Move(mem, "/tmp/staging", "/work/shop", 0, nil)
+	if !errors.Is(err, syscall.EBUSY) {
+		t.Fatalf("expected cleanup error, got %v", err)
+	}
+	if !fsys.FileExists(mem, "/work/shop/main.go") {
+		t.Fatalf("expected files to be copied before cleanup")
+	}
+}
+
+func TestMove_CrossDevicePreservesSymlinks(t *testing.T) {
+	mem := newTemplateFS(t)
+	if err := mem.Symlink("main.go", "/tmp/staging/entry.go"); err != nil {
+		t.Fatalf("Symlink failed: %v", err)
+	}
+	if err := mem.Mknod("/tmp/staging/app.sock", fs.ModeSocket|0600); err != nil {
+		t.Fatalf("Mknod failed: %v", err)
+	}
+
+	if err := Move(mem, "/tmp/staging", "/work/shop", 0, &output.Reporter{}); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if link, err := mem.Readlink("/work/shop/entry.go"); err != nil || link != "main.go" {
+		t.Fatalf("expected symlink to be recreated, got %q %v", link, err)
+	}
+	if _, err := mem.Lstat("/work/shop/app.sock"); !os.IsNotExist(err) {
+		t.Fatalf("expected special file to be skipped, lstat err=%v", err)
+	}
+}
+
+func TestRender_InMemory(t *testing.T) {
+	mem := newTemplateFS(t)
+
+	if err := Render(mem, "/tmp/staging", "shop", &output.Reporter{}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	content, err := mem.ReadFile("/tmp/staging/.env")
+	if err != nil || string(content) != "APP_NAME=shop\n" {
+		t.Fatalf("expected .env to be rendered, got %q %v", content, err)
+	}
+}
+
+// newExistingProject 在 newTemplateFS 的基础上创建已有的 /work/shop：
+// main.go 与模板相同，.env.example 内容不同，app/http 是文件而模板中是目录，notes.md 只在已有目录中
+func newExistingProject(t *testing.T) *fsys.MemFS {
+	t.Helper()
+	mem := newTemplateFS(t)
+	files := map[string]string{
+		"/work/shop/main.go":           "package main\n",
+		"/work/shop/.env.example":      "APP_NAME=Shop\n",
+		"/work/shop/.env.example.orig": "APP_NAME=Old\n",
+		"/work/shop/app/http":          "not a directory\n",
+		"/work/shop/notes.md":          "# notes\n",
+	}
+	for name, content := range files {
+		if err := mem.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := mem.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return mem
+}
+
+func TestMerge_ConflictPolicies(t *testing.T) {
+	httpDir := filepath.Join("app", "http")
+	tests := []struct {
+		action    string
+		conflicts []MergeConflict
+		files     map[string]string
+	}{
+		{
+			action:    ConflictSkip,
+			conflicts: []MergeConflict{{Path: ".env.example", Action: ConflictSkip}, {Path: httpDir, Action: ConflictSkip}},
+			files: map[string]string{
+				".env.example": "APP_NAME=Shop\n",
+				"app/http":     "not a directory\n",
+			},
+		},
+		{
+			action:    ConflictOverwrite,
+			conflicts: []MergeConflict{{Path: ".env.example", Action: ConflictOverwrite}, {Path: httpDir, Action: ConflictOverwrite}},
+			files: map[string]string{
+				".env.example":       "APP_NAME=Goravel\n",
+				"app/http/kernel.go": "package http\n",
+			},
+		},
+		{
+			action: ConflictRename,
+			conflicts: []MergeConflict{
+				{Path: ".env.example", Action: ConflictRename, RenamedTo: ".env.example.orig.1"},
+				{Path: httpDir, Action: ConflictRename, RenamedTo: httpDir + ".orig"},
+			},
+			files: map[string]string{
+				".env.example":        "APP_NAME=Goravel\n",
+				".env.example.orig":   "APP_NAME=Old\n",
+				".env.example.orig.1": "APP_NAME=Shop\n",
+				"app/http.orig":       "not a directory\n",
+				"app/http/kernel.go":  "package http\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		mem := newExistingProject(t)
+		var asked []string
+		conflicts, err := Merge(mem, "/tmp/staging", "/work/shop", func(path string) (string, error) {
+			asked = append(asked, path)
+			return tt.action, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: Merge failed: %v", tt.action, err)
+		}
+		if strings.Join(asked, ",") != ".env.example,"+httpDir {
+			t.Fatalf("%s: expected only differing entries to be resolved, got %v", tt.action, asked)
+		}
+		if len(conflicts) != len(tt.conflicts) {
+			t.Fatalf("%s: unexpected conflicts: %+v", tt.action, conflicts)
+		}
+		for i := range conflicts {
+			if conflicts[i] != tt.conflicts[i] {
+				t.Fatalf("%s: unexpected conflict %d: %+v", tt.action, i, conflicts[i])
+			}
+		}
+		tt.files["main.go"] = "package main\n"
+		tt.files["notes.md"] = "# notes\n"
+		for name, want := range tt.files {
+			got, err := mem.ReadFile(filepath.Join("/work/shop", name))
+			if err != nil || string(got) != want {
+				t.Fatalf("%s: expected %s to contain %q, got %q %v", tt.action, name, want, got, err)
+			}
+		}
+		if !fsys.DirExists(mem, "/tmp/staging") {
+			t.Fatalf("%s: expected the source to be left for the caller to clean up", tt.action)
+		}
+	}
+}
+
+func TestMerge_ResolveErrorStopsMerge(t *testing.T) {
+	mem := newExistingProject(t)
+	errAborted := errors.New("aborted")
+
+	_, err := Merge(mem, "/tmp/staging", "/work/shop", func(path string) (string, error) {
+		return "", errAborted
+	})
+	if !errors.Is(err, errAborted) {
+		t.Fatalf("expected resolve error, got %v", err)
+	}
+	if content, _ := mem.ReadFile("/work/shop/.env.example"); string(content) != "APP_NAME=Shop\n" {
+		t.Fatalf("expected existing file to be untouched, got %q", content)
+	}
+}
@@ -0,0 +1,232 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+	"github.com/hulutech-web/goravel-kit-cli/internal/features"
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// UnnecessaryFiles 模板仓库自身的文件，不会复制到生成的项目中
+var UnnecessaryFiles = []string{".github", ".gitignore", "LICENSE", "README.md", features.ManifestFile}
+
+// Fetch 下载模板仓库指定 ref 的内容到 dir，返回实际的提交 SHA
+func Fetch(ctx context.Context, repoURL, ref, dir string) (string, error) {
+	if err := utils.FetchRef(ctx, repoURL, ref, dir); err != nil {
+		return "", err
+	}
+	return utils.HeadCommit(dir)
+}
+
+// RemoveFeatures 按模板清单移除指定的功能模块
+func RemoveFeatures(dir string, without []string, r *output.Reporter) error {
+	if len(without) == 0 {
+		return nil
+	}
+	manifest, err := features.LoadManifest(dir)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.manifest"), err)
+	}
+	removed, err := manifest.Resolve(without)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.select"), err)
+	}
+	report, err := features.Remove(dir, removed)
+	r.Step("features", err)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.remove"), err)
+	}
+	if r.Verbose {
+		for _, path := range report.RemovedPaths {
+			r.Printf(color.New(color.FgHiYellow), "🗑️  %s\n", i18n.T("new.removed", path))
+		}
+		for _, path := range report.ModifiedFiles {
+			r.Printf(color.New(color.FgHiYellow), "✂️  %s\n", i18n.T("features.modified", path))
+		}
+	}
+	return nil
+}
+
+// ApplyDrivers 在模板目录中应用 --db/--cache 选择的驱动并返回改动摘要，未选择时不做任何改动并返回 nil。
+// 新增的驱动依赖还没有 go.sum 记录，会执行 go mod tidy 补全；new 和 Prepare 都经过这里，
+// 重建的模板才能与 new 生成的 go.mod/go.sum 一致
+func ApplyDrivers(dir string, opts driver.Options, r *output.Reporter) (*driver.Report, error) {
+	if opts.Database == "" && opts.Cache == "" {
+		return nil, nil
+	}
+	report, err := driver.Apply(dir, opts)
+	r.Step("drivers", err)
+	if err != nil {
+		return nil, fmt.Errorf("❌ %s: %w", i18n.T("driver.error.apply"), err)
+	}
+	if r.Verbose {
+		for _, path := range report.ModifiedFiles {
+			r.Printf(color.New(color.FgHiYellow), "✂️  %s\n", i18n.T("features.modified", path))
+		}
+		for _, path := range report.CreatedFiles {
+			r.Printf(color.New(color.FgHiYellow), "📄 %s\n", i18n.T("driver.created", path))
+		}
+		for _, require := range report.Requires {
+			r.Printf(color.New(color.FgHiYellow), "📦 %s\n", i18n.T("driver.required", require))
+		}
+	}
+	if len(report.Requires) > 0 {
+		if err := RunCommand(dir, []string{"go", "mod", "tidy"}, r); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// RunCommand 在 dir 中执行命令，详细模式下输出命令和结果；失败时输出命令的输出并返回错误
+func RunCommand(dir string, cmdArgs []string, r *output.Reporter) error {
+	command := strings.Join(cmdArgs, " ")
+	cmd := utils.NewCommandWithDir(cmdArgs[0], cmdArgs[1:], dir)
+	slog.Info("running command", "cmd", command, "dir", dir)
+	if r.Verbose {
+		r.Printf(color.New(color.FgHiCyan), "🔧 %s\n", i18n.T("new.running_command", command))
+	}
+	commandStart := time.Now()
+	out, err := cmd.CombinedOutput()
+	slog.Debug("command output", "cmd", command, "output", string(out))
+	slog.Info("command finished", "cmd", command, "duration", time.Since(commandStart), "error", err)
+	if r.Verbose || err != nil {
+		r.Writer().Write(out)
+	}
+	r.Step(cmdArgs[len(cmdArgs)-1], err)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.command_failed", command), err)
+	}
+	return nil
+}
+
+// DriverSummary 返回驱动选择的简要描述，如 "postgres, redis"
+func DriverSummary(opts driver.Options) string {
+	var parts []string
+	if opts.Database != "" {
+		parts = append(parts, "DB_CONNECTION="+opts.Database)
+	}
+	if opts.Cache != "" {
+		parts = append(parts, "CACHE_STORE="+opts.Cache)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Strip 移除模板中的 .git 目录和模板仓库自身的文件
+func Strip(fs fsys.FS, dir string, r *output.Reporter) error {
+	gitDir := filepath.Join(dir, ".git")
+	if fsys.DirExists(fs, gitDir) {
+		if err := fs.RemoveAll(gitDir); err != nil {
+			return fmt.Errorf("❌ %s: %w", i18n.T("new.error.remove_git"), err)
+		}
+		r.Emit(output.EventFileRemoved, map[string]any{"path": ".git"})
+		if r.Verbose {
+			r.Printf(color.New(color.FgHiYellow), "🗑️  %s\n", i18n.T("new.removed", ".git"))
+		}
+	}
+
+	for _, file := range UnnecessaryFiles {
+		filePath := filepath.Join(dir, file)
+		if _, err := fs.Stat(filePath); err == nil {
+			fs.RemoveAll(filePath)
+			r.Emit(output.EventFileRemoved, map[string]any{"path": file})
+			if r.Verbose {
+				r.Printf(color.New(color.FgHiYellow), "🗑️  %s\n", i18n.T("new.removed", file))
+			}
+		}
+	}
+	return nil
+}
+
+// CreateEnv 复制 .env.example 生成 .env
+func CreateEnv(fs fsys.FS, dir string, r *output.Reporter) error {
+	envExamplePath := filepath.Join(dir, ".env.example")
+	envPath := filepath.Join(dir, ".env")
+	if !fsys.FileExists(fs, envExamplePath) {
+		if r.Verbose {
+			r.Printf(color.New(color.FgHiYellow), "⚠️  %s\n", i18n.T("new.env_example_missing"))
+		}
+		return nil
+	}
+
+	input, err := fs.ReadFile(envExamplePath)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.read_env_example"), err)
+	}
+	err = fs.WriteFile(envPath, input, 0644)
+	r.Step("env_copy", err)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.create_env"), err)
+	}
+	if r.Verbose {
+		r.Printf(color.New(color.FgHiGreen), "✅ %s\n", i18n.T("new.env_copied"))
+	}
+	return nil
+}
+
+// UpdateEnv 在 .env 中写入项目名称和访问地址，没有 .env 时不做任何改动
+func UpdateEnv(fs fsys.FS, projectDir, projectName string) error {
+	envPath := filepath.Join(projectDir, ".env")
+	if !fsys.FileExists(fs, envPath) {
+		return nil
+	}
+
+	content, err := fs.ReadFile(envPath)
+	if err != nil {
+		return err
+	}
+
+	envContent := string(content)
+	envContent = strings.Replace(envContent, "APP_NAME=Goravel", "APP_NAME="+projectName, 1)
+	envContent = strings.Replace(envContent, "APP_URL=http://localhost", "APP_URL=http://localhost:3000", 1)
+
+	return fs.WriteFile(envPath, []byte(envContent), 0644)
+}
+
+// Render 根据项目名称渲染模板：由 .env.example 生成 .env 并写入项目配置
+func Render(fs fsys.FS, dir, projectName string, r *output.Reporter) error {
+	if err := CreateEnv(fs, dir, r); err != nil {
+		return err
+	}
+	return UpdateEnv(fs, dir, projectName)
+}
+
+// Prepare 使用与 new 相同的 下载 → 裁剪功能 → 清理 → 渲染 流程，
+// 按项目元信息在临时目录中重建模板，调用方负责删除返回的目录
+func Prepare(ctx context.Context, ref string, meta *Metadata, r *output.Reporter) (string, string, error) {
+	dir, err := os.MkdirTemp("", "goravel-kit-template-*")
+	if err != nil {
+		return "", "", fmt.Errorf("❌ %s: %w", i18n.T("new.error.temp_dir"), err)
+	}
+
+	sha, err := Fetch(ctx, meta.Repository, ref, dir)
+	if err == nil {
+		err = RemoveFeatures(dir, meta.Without, r)
+	}
+	if err == nil {
+		_, err = ApplyDrivers(dir, driver.Options{Database: meta.Database, Cache: meta.Cache}, r)
+	}
+	if err == nil {
+		err = Strip(fsys.OS, dir, r)
+	}
+	if err == nil {
+		err = Render(fsys.OS, dir, meta.Project, r)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+	return dir, sha, nil
+}
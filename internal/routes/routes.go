@@ -0,0 +1,555 @@
+package routes
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Dir 项目中存放路由定义的目录
+const Dir = "routes"
+
+// Route 一条路由
+type Route struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Handler    string   `json:"handler"`
+	Middleware []string `json:"middleware"`
+	// File 注册路由的文件（相对项目根目录）
+	File string `json:"file"`
+}
+
+// Report 分析结果
+type Report struct {
+	Routes []Route `json:"routes"`
+	// Warnings 无法静态分析的路由注册，如把路由器传给其他包的函数
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// methods 路由器注册单条路由的方法与 HTTP 方法的对应关系
+var methods = map[string]string{
+	"Get":     "GET",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Patch":   "PATCH",
+	"Delete":  "DELETE",
+	"Options": "OPTIONS",
+	"Any":     "ANY",
+}
+
+// methodOrder 同一路径下路由的输出顺序
+var methodOrder = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "ANY", "STATIC"}
+
+// resourceRoutes Resource 注册的路由，与 goravel 的 route.Resource 保持一致
+var resourceRoutes = []struct {
+	method string
+	suffix string
+	action string
+}{
+	{"GET", "", "Index"},
+	{"POST", "", "Store"},
+	{"GET", "{id}", "Show"},
+	{"PUT", "{id}", "Update"},
+	{"PATCH", "{id}", "Update"},
+	{"DELETE", "{id}", "Destroy"},
+}
+
+// router 静态分析时路由器表达式的状态：分组前缀和中间件
+type router struct {
+	prefix     string
+	middleware []string
+}
+
+// with 返回追加前缀和中间件后的路由器，不修改原路由器
+func (r router) with(prefix string, middleware ...string) router {
+	return router{
+		prefix:     joinPath(r.prefix, prefix),
+		middleware: append(append([]string{}, r.middleware...), middleware...),
+	}
+}
+
+// scope 函数体中的变量：路由器变量以及其他值（如控制器实例）的定义
+type scope struct {
+	routers map[string]router
+	values  map[string]ast.Expr
+}
+
+func newScope() *scope {
+	return &scope{routers: map[string]router{}, values: map[string]ast.Expr{}}
+}
+
+// function routes 包中的一个函数
+type function struct {
+	decl *ast.FuncDecl
+	file string
+	// literals 已遇到的匿名处理函数数量，用于生成 routes.Web.func1 形式的名称
+	literals int
+}
+
+type analyzer struct {
+	fset      *token.FileSet
+	functions map[string]*function
+	consts    map[string]ast.Expr
+	walking   map[string]bool
+	report    *Report
+}
+
+// Analyze 静态解析项目 routes 目录中的 Go 文件，列出注册的路由，无需编译和启动应用。
+// 从 Api、Web 等不接收路由器参数、也未被其他函数调用的函数开始，跟踪 facades.Route() 的
+// Prefix/Middleware/Group 调用链以及把路由器传给同包函数的调用
+func Analyze(dir string) (*Report, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, Dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	a := &analyzer{
+		fset:      token.NewFileSet(),
+		functions: map[string]*function{},
+		consts:    map[string]ast.Expr{},
+		walking:   map[string]bool{},
+		report:    &Report{Routes: []Route{}},
+	}
+	for _, p := range paths {
+		if strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(a.fset, p, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		a.collect(file, path.Join(Dir, filepath.Base(p)))
+	}
+	if len(a.functions) == 0 {
+		if _, err := os.Stat(filepath.Join(dir, Dir)); err != nil {
+			return nil, fmt.Errorf("%s directory not found in %s", Dir, dir)
+		}
+		return nil, fmt.Errorf("no route functions found in %s", Dir)
+	}
+
+	called := a.calledFunctions()
+	names := make([]string, 0, len(a.functions))
+	for name := range a.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fn := a.functions[name]
+		switch {
+		case called[name]:
+		case len(routerParams(fn.decl)) == 0:
+			a.walkFunction(name, nil)
+		case containsRoutes(fn.decl):
+			a.warn(fn.decl, "%s is not registered in any route group", name)
+		}
+	}
+
+	sort.SliceStable(a.report.Routes, func(i, j int) bool {
+		ri, rj := a.report.Routes[i], a.report.Routes[j]
+		if ri.Path != rj.Path {
+			return ri.Path < rj.Path
+		}
+		if oi, oj := methodIndex(ri.Method), methodIndex(rj.Method); oi != oj {
+			return oi < oj
+		}
+		return ri.Handler < rj.Handler
+	})
+	sort.Strings(a.report.Warnings)
+	return a.report, nil
+}
+
+// collect 记录文件中的顶层函数和字符串常量
+func (a *analyzer) collect(file *ast.File, rel string) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && d.Body != nil {
+				a.functions[d.Name.Name] = &function{decl: d, file: rel}
+			}
+		case *ast.GenDecl:
+			if d.Tok != token.CONST && d.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range d.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						a.consts[name.Name] = vs.Values[i]
+					}
+				}
+			}
+		}
+	}
+}
+
+// calledFunctions 返回被同包其他函数调用或作为分组函数传入的函数
+func (a *analyzer) calledFunctions() map[string]bool {
+	called := map[string]bool{}
+	for name, fn := range a.functions {
+		ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name != name && a.functions[ident.Name] != nil {
+				called[ident.Name] = true
+			}
+			for _, arg := range call.Args {
+				if ident, ok := arg.(*ast.Ident); ok && ident.Name != name && a.functions[ident.Name] != nil {
+					called[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	return called
+}
+
+// walkFunction 以给定的路由器作为路由器参数分析同包函数
+func (a *analyzer) walkFunction(name string, routers []router) {
+	fn := a.functions[name]
+	if fn == nil || a.walking[name] {
+		return
+	}
+	a.walking[name] = true
+	defer delete(a.walking, name)
+
+	sc := newScope()
+	for i, param := range routerParams(fn.decl) {
+		if i < len(routers) {
+			sc.routers[param] = routers[i]
+		}
+	}
+	a.walkBlock(fn, fn.decl.Body.List, sc)
+}
+
+func (a *analyzer) walkBlock(fn *function, stmts []ast.Stmt, sc *scope) {
+	for _, stmt := range stmts {
+		a.walkStmt(fn, stmt, sc)
+	}
+}
+
+func (a *analyzer) walkStmt(fn *function, stmt ast.Stmt, sc *scope) {
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		a.call(fn, s.X, sc)
+	case *ast.AssignStmt:
+		if len(s.Lhs) != len(s.Rhs) {
+			return
+		}
+		for i, lhs := range s.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				a.bind(sc, ident.Name, s.Rhs[i])
+			}
+		}
+	case *ast.DeclStmt:
+		if gen, ok := s.Decl.(*ast.GenDecl); ok {
+			for _, spec := range gen.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == len(vs.Values) {
+					for i, name := range vs.Names {
+						a.bind(sc, name.Name, vs.Values[i])
+					}
+				}
+			}
+		}
+	case *ast.BlockStmt:
+		a.walkBlock(fn, s.List, sc)
+	case *ast.IfStmt:
+		a.walkBlock(fn, s.Body.List, sc)
+		if s.Else != nil {
+			a.walkStmt(fn, s.Else, sc)
+		}
+	case *ast.ForStmt:
+		a.walkBlock(fn, s.Body.List, sc)
+	case *ast.RangeStmt:
+		a.walkBlock(fn, s.Body.List, sc)
+	}
+}
+
+// bind 记录变量：路由器表达式记为路由器，其他表达式保留原始定义用于显示处理函数
+func (a *analyzer) bind(sc *scope, name string, value ast.Expr) {
+	if r, ok := a.router(value, sc); ok {
+		sc.routers[name] = r
+		return
+	}
+	sc.values[name] = value
+}
+
+// call 分析一条调用语句
+func (a *analyzer) call(fn *function, expr ast.Expr, sc *scope) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if callee := a.functions[fun.Name]; callee != nil {
+			var routers []router
+			for _, arg := range call.Args {
+				if r, ok := a.router(arg, sc); ok {
+					routers = append(routers, r)
+				}
+			}
+			a.walkFunction(fun.Name, routers)
+		}
+		return
+	case *ast.SelectorExpr:
+		base, ok := a.router(fun.X, sc)
+		if !ok {
+			for _, arg := range call.Args {
+				if _, ok := a.router(arg, sc); ok {
+					a.warn(call, "%s receives a router; routes registered outside %s/ are not listed", a.text(call.Fun), Dir)
+					break
+				}
+			}
+			return
+		}
+		a.register(fn, fun.Sel.Name, base, call.Args, sc)
+	}
+}
+
+// register 处理路由器上的方法调用
+func (a *analyzer) register(fn *function, method string, base router, args []ast.Expr, sc *scope) {
+	switch {
+	case method == "Group" && len(args) == 1:
+		switch group := args[0].(type) {
+		case *ast.FuncLit:
+			inner := newScope()
+			for k, v := range sc.routers {
+				inner.routers[k] = v
+			}
+			for k, v := range sc.values {
+				inner.values[k] = v
+			}
+			if params := funcParams(group.Type); len(params) == 1 {
+				inner.routers[params[0]] = base
+			}
+			a.walkBlock(fn, group.Body.List, inner)
+		case *ast.Ident:
+			a.walkFunction(group.Name, []router{base})
+		}
+	case methods[method] != "" && len(args) >= 2:
+		a.add(fn, methods[method], joinPath(base.prefix, a.path(args[0], sc)), a.handler(fn, args[len(args)-1], sc), base.middleware)
+	case method == "Resource" && len(args) == 2:
+		controller := a.receiver(args[1], sc)
+		for _, r := range resourceRoutes {
+			p := joinPath(joinPath(base.prefix, a.path(args[0], sc)), r.suffix)
+			a.add(fn, r.method, p, controller+"."+r.action, base.middleware)
+		}
+	case (method == "Static" || method == "StaticFile" || method == "StaticFS") && len(args) == 2:
+		a.add(fn, "STATIC", joinPath(base.prefix, a.path(args[0], sc)), a.path(args[1], sc), base.middleware)
+	}
+}
+
+func (a *analyzer) add(fn *function, method, p, handler string, middleware []string) {
+	a.report.Routes = append(a.report.Routes, Route{
+		Method:     method,
+		Path:       p,
+		Handler:    handler,
+		Middleware: append([]string{}, middleware...),
+		File:       fn.file,
+	})
+}
+
+// router 判断表达式是否为路由器：facades.Route()、路由器变量，以及在它们之上调用 Prefix/Middleware 的结果
+func (a *analyzer) router(expr ast.Expr, sc *scope) (router, bool) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return a.router(e.X, sc)
+	case *ast.Ident:
+		r, ok := sc.routers[e.Name]
+		return r, ok
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return router{}, false
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "facades" && sel.Sel.Name == "Route" {
+			return router{prefix: "/"}, true
+		}
+		base, ok := a.router(sel.X, sc)
+		if !ok {
+			return router{}, false
+		}
+		switch sel.Sel.Name {
+		case "Prefix":
+			if len(e.Args) == 1 {
+				return base.with(a.path(e.Args[0], sc)), true
+			}
+		case "Middleware":
+			names := make([]string, 0, len(e.Args))
+			for _, arg := range e.Args {
+				names = append(names, a.middleware(arg))
+			}
+			return base.with("", names...), true
+		}
+	}
+	return router{}, false
+}
+
+// path 解析路径参数：字符串字面量、同包常量以及它们的拼接，无法解析时以 {表达式} 显示
+func (a *analyzer) path(expr ast.Expr, sc *scope) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			if value, err := strconv.Unquote(e.Value); err == nil {
+				return value
+			}
+		}
+	case *ast.Ident:
+		if value, ok := sc.values[e.Name]; ok {
+			return a.path(value, newScope())
+		}
+		if value, ok := a.consts[e.Name]; ok {
+			return a.path(value, newScope())
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return a.path(e.X, sc) + a.path(e.Y, sc)
+		}
+	case *ast.ParenExpr:
+		return a.path(e.X, sc)
+	}
+	return "{" + a.text(expr) + "}"
+}
+
+// handler 返回处理函数的名称，控制器变量替换为控制器类型，匿名函数显示为 routes.Web.func1
+func (a *analyzer) handler(fn *function, expr ast.Expr, sc *scope) string {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		fn.literals++
+		return fmt.Sprintf("%s.%s.func%d", Dir, fn.decl.Name.Name, fn.literals)
+	case *ast.SelectorExpr:
+		return a.receiver(e.X, sc) + "." + e.Sel.Name
+	}
+	return a.text(expr)
+}
+
+// receiver 返回控制器的显示名称：controllers.NewUserController() 显示为 controllers.UserController
+func (a *analyzer) receiver(expr ast.Expr, sc *scope) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if value, ok := sc.values[e.Name]; ok {
+			return a.receiver(value, newScope())
+		}
+	case *ast.CallExpr:
+		switch fun := e.Fun.(type) {
+		case *ast.SelectorExpr:
+			if name, ok := strings.CutPrefix(fun.Sel.Name, "New"); ok && name != "" {
+				return a.text(fun.X) + "." + name
+			}
+		case *ast.Ident:
+			if name, ok := strings.CutPrefix(fun.Name, "New"); ok && name != "" {
+				return name
+			}
+		}
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND {
+			return a.text(lit.Type)
+		}
+	case *ast.CompositeLit:
+		return a.text(e.Type)
+	}
+	return a.text(expr)
+}
+
+// middleware 返回中间件名称，不带参数的构造调用省略括号，如 middleware.Jwt()
+func (a *analyzer) middleware(expr ast.Expr) string {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		return a.text(call.Fun)
+	}
+	return a.text(expr)
+}
+
+func (a *analyzer) warn(node ast.Node, format string, args ...any) {
+	pos := a.fset.Position(node.Pos())
+	location := fmt.Sprintf("%s:%d", path.Join(Dir, filepath.Base(pos.Filename)), pos.Line)
+	a.report.Warnings = append(a.report.Warnings, location+": "+fmt.Sprintf(format, args...))
+}
+
+// text 返回表达式的源码，多行表达式合并为一行
+func (a *analyzer) text(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, a.fset, expr); err != nil {
+		return "?"
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// routerParams 返回函数中类型为 route.Router 的参数名称
+func routerParams(decl *ast.FuncDecl) []string {
+	var names []string
+	for _, field := range decl.Type.Params.List {
+		if !isRouterType(field.Type) {
+			continue
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// funcParams 返回匿名分组函数的参数名称
+func funcParams(typ *ast.FuncType) []string {
+	var names []string
+	for _, field := range typ.Params.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+func isRouterType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		return t.Sel.Name == "Router"
+	case *ast.Ident:
+		return t.Name == "Router"
+	}
+	return false
+}
+
+// containsRoutes 判断函数体中是否调用了注册路由的方法
+func containsRoutes(decl *ast.FuncDecl) bool {
+	found := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && (methods[sel.Sel.Name] != "" || sel.Sel.Name == "Resource") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// joinPath 拼接路由前缀和路径，结果以 / 开头且不以 / 结尾
+func joinPath(prefix, p string) string {
+	joined := "/" + strings.Trim(strings.Trim(prefix, "/")+"/"+strings.Trim(p, "/"), "/")
+	for strings.Contains(joined, "//") {
+		joined = strings.ReplaceAll(joined, "//", "/")
+	}
+	return joined
+}
+
+func methodIndex(method string) int {
+	for i, m := range methodOrder {
+		if m == method {
+			return i
+		}
+	}
+	return len(methodOrder)
+}
@@ -0,0 +1,190 @@
+package routes
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeRoutes(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, Dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+const apiRoutes = `package routes
+
+import (
+	"github.com/goravel/framework/contracts/route"
+	"github.com/goravel/framework/facades"
+
+	"goravel/app/http/controllers"
+	"goravel/app/http/middleware"
+	"goravel/packages/socket"
+)
+
+const wsPrefix = "ws"
+
+func Api() {
+	authController := controllers.NewAuthController()
+	facades.Route().Prefix("api").Group(func(api route.Router) {
+		api.Prefix("admin").Group(func(admin route.Router) {
+			admin.Post("auth/login", authController.Login)
+			admin.Middleware(middleware.Jwt()).Group(func(router route.Router) {
+				router.Get("user/own", controllers.NewUserController().Own)
+				router.Resource("role", controllers.NewRoleController())
+				Article(router)
+			})
+		})
+		ws := api.Prefix(wsPrefix)
+		ws.Post("register", func(ctx http.Context) http.Response { return nil })
+		socket.Register(ws)
+	})
+}
+`
+
+const articleRoutes = `package routes
+
+import (
+	"github.com/goravel/framework/contracts/route"
+
+	"goravel/app/http/controllers"
+)
+
+// Article 注册Article的资源路由
+func Article(router route.Router) {
+	controller := controllers.NewArticleController()
+	router.Get("article/list", controller.List)
+	router.Resource("article", controller)
+}
+
+func Orphan(router route.Router) {
+	router.Get("orphan", controllers.NewOrphanController().Index)
+}
+`
+
+const webRoutes = `package routes
+
+import "github.com/goravel/framework/facades"
+
+func Web() {
+	facades.Route().Get("/", func(ctx http.Context) http.Response { return nil })
+	facades.Route().Static("assets", "./public/assets")
+	facades.Route().Prefix("crud").Group(Crud)
+}
+
+func Crud(router route.Router) {
+	router.Middleware(middleware.Cors(), middleware.Throttle("global")).Any("tables", crud.Tables)
+}
+`
+
+func TestAnalyze(t *testing.T) {
+	dir := writeRoutes(t, map[string]string{"api.go": apiRoutes, "article.go": articleRoutes, "web.go": webRoutes})
+
+	report, err := Analyze(dir)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	jwt := []string{"middleware.Jwt"}
+	want := []Route{
+		{Method: "GET", Path: "/", Handler: "routes.Web.func1", Middleware: []string{}, File: "routes/web.go"},
+		{Method: "POST", Path: "/api/admin/auth/login", Handler: "controllers.AuthController.Login", Middleware: []string{}, File: "routes/api.go"},
+		{Method: "GET", Path: "/api/admin/role", Handler: "controllers.RoleController.Index", Middleware: jwt, File: "routes/api.go"},
+		{Method: "POST", Path: "/api/admin/role", Handler: "controllers.RoleController.Store", Middleware: jwt, File: "routes/api.go"},
+		{Method: "GET", Path: "/api/admin/role/{id}", Handler: "controllers.RoleController.Show", Middleware: jwt, File: "routes/api.go"},
+		{Method: "PUT", Path: "/api/admin/role/{id}", Handler: "controllers.RoleController.Update", Middleware: jwt, File: "routes/api.go"},
+		{Method: "PATCH", Path: "/api/admin/role/{id}", Handler: "controllers.RoleController.Update", Middleware: jwt, File: "routes/api.go"},
+		{Method: "DELETE", Path: "/api/admin/role/{id}", Handler: "controllers.RoleController.Destroy", Middleware: jwt, File: "routes/api.go"},
+		{Method: "GET", Path: "/api/admin/user/own", Handler: "controllers.UserController.Own", Middleware: jwt, File: "routes/api.go"},
+		{Method: "POST", Path: "/api/ws/register", Handler: "routes.Api.func1", Middleware: []string{}, File: "routes/api.go"},
+		{Method: "STATIC", Path: "/assets", Handler: "./public/assets", Middleware: []string{}, File: "routes/web.go"},
+		{Method: "ANY", Path: "/crud/tables", Handler: "crud.Tables", Middleware: []string{"middleware.Cors", `middleware.Throttle("global")`}, File: "routes/web.go"},
+	}
+
+	var article []Route
+	var rest []Route
+	for _, route := range report.Routes {
+		if strings.HasPrefix(route.Path, "/api/admin/article") {
+			article = append(article, route)
+		} else {
+			rest = append(rest, route)
+		}
+	}
+	if !reflect.DeepEqual(rest, want) {
+		t.Fatalf("unexpected routes:\n got: %+v\nwant: %+v", rest, want)
+	}
+
+	// 同包函数中注册的路由继承调用处分组的前缀和中间件
+	if len(article) != 7 {
+		t.Fatalf("expected 7 article routes, got %+v", article)
+	}
+	first := article[0]
+	if first.Path != "/api/admin/article" || first.Handler != "controllers.ArticleController.Index" || first.File != "routes/article.go" || !reflect.DeepEqual(first.Middleware, jwt) {
+		t.Fatalf("unexpected article route: %+v", first)
+	}
+	if list := article[2]; list.Path != "/api/admin/article/list" || list.Handler != "controllers.ArticleController.List" {
+		t.Fatalf("unexpected article list route: %+v", list)
+	}
+
+	wantWarnings := []string{
+		"routes/api.go:27: socket.Register receives a router; routes registered outside routes/ are not listed",
+		"routes/article.go:16: Orphan is not registered in any route group",
+	}
+	if !reflect.DeepEqual(report.Warnings, wantWarnings) {
+		t.Fatalf("unexpected warnings: %q", report.Warnings)
+	}
+}
+
+func TestAnalyzeIsStable(t *testing.T) {
+	dir := writeRoutes(t, map[string]string{"api.go": apiRoutes, "article.go": articleRoutes, "web.go": webRoutes})
+	first, err := Analyze(dir)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		report, err := Analyze(dir)
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if !reflect.DeepEqual(report, first) {
+			t.Fatalf("output changed between runs")
+		}
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	if _, err := Analyze(t.TempDir()); err == nil || !strings.Contains(err.Error(), "routes directory not found") {
+		t.Fatalf("expected missing directory error, got %v", err)
+	}
+	dir := writeRoutes(t, map[string]string{"api.go": "package routes\n\nfunc Api( {\n"})
+	if _, err := Analyze(dir); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	cases := map[[2]string]string{
+		{"/", ""}:           "/",
+		{"/", "/"}:          "/",
+		{"/api", "users/"}:  "/api/users",
+		{"api/", "/{id}"}:   "/api/{id}",
+		{"/api//admin", ""}: "/api/admin",
+	}
+	for in, want := range cases {
+		if got := joinPath(in[0], in[1]); got != want {
+			t.Fatalf("joinPath(%q, %q) = %q, want %q", in[0], in[1], got, want)
+		}
+	}
+}
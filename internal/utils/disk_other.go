@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package utils
+
+import (
+	"errors"
+	"runtime"
+)
+
+// FreeDiskSpace 当前平台不支持获取可用空间，返回错误，调用方应跳过磁盘空间检查
+func FreeDiskSpace(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not supported on " + runtime.GOOS)
+}
@@ -2,22 +2,16 @@ package utils
 
 import (
 	"os"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
 )
 
 func DirectoryExists(path string) bool {
-	info, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		return false
-	}
-	return info.IsDir()
+	return fsys.DirExists(fsys.OS, path)
 }
 
 func FileExists(path string) bool {
-	info, err := os.Stat(path)
-	if os.IsNotExist(err) {
-		return false
-	}
-	return !info.IsDir()
+	return fsys.FileExists(fsys.OS, path)
 }
 
 func MoveDirectory(source, destination string) error {
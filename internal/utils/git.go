@@ -3,15 +3,28 @@ package utils
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/logger"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/progress"
 )
 
-func CloneRepositoryWithContext(ctx context.Context, repoURL, branch, targetDir string, verbose bool) error {
+func CloneRepositoryWithContext(ctx context.Context, repoURL, branch, targetDir string) error {
+	return CloneRepositoryWithReporter(ctx, repoURL, branch, targetDir, output.Default(logger.Verbose()))
+}
+
+// CloneRepositoryWithReporter 浅克隆仓库，进度和结果输出到 reporter
+func CloneRepositoryWithReporter(ctx context.Context, repoURL, branch, targetDir string, reporter *output.Reporter) error {
 	args := []string{"clone", "--progress", "--depth", "1"}
 
 	if branch != "" && branch != "master" {
@@ -21,20 +34,22 @@ func CloneRepositoryWithContext(ctx context.Context, repoURL, branch, targetDir
 	args = append(args, repoURL, targetDir)
 
 	cmd := exec.CommandContext(ctx, "git", args...)
+	killProcessGroupOnCancel(cmd)
 
-	if verbose {
-		fmt.Printf("🔧 Running command: git %s\n", strings.Join(args, " "))
+	slog.Info("running command", "cmd", "git "+strings.Join(args, " "))
+	if reporter.Verbose {
+		fmt.Fprintf(reporter.Writer(), "🔧 %s\n", i18n.T("git.running", strings.Join(args, " ")))
 	}
 
 	// 获取标准输出管道
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
-		return fmt.Errorf("failed to get stdout pipe: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("git.error.stdout_pipe"), err)
 	}
 
 	stderrPipe, err := cmd.StderrPipe()
 	if err != nil {
-		return fmt.Errorf("failed to get stderr pipe: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("git.error.stderr_pipe"), err)
 	}
 
 	// 记录开始时间
@@ -42,72 +57,198 @@ func CloneRepositoryWithContext(ctx context.Context, repoURL, branch, targetDir
 
 	// 启动命令
 	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start git clone: %w", err)
+		return fmt.Errorf("%s: %w", i18n.T("git.error.start"), err)
 	}
 
-	// 实时读取输出
-	go streamOutput(stdoutPipe, "git", verbose)
-	go streamOutput(stderrPipe, "git", verbose)
-
-	// 等待命令完成
+	// 实时读取输出，同时保留完整的 stderr 用于错误分类
+	var stderr strings.Builder
+	var wg sync.WaitGroup
+	bar := progress.New(reporter.Writer())
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		streamOutput(stdoutPipe, "stdout", nil, bar, reporter)
+	}()
+	go func() {
+		defer wg.Done()
+		streamOutput(stderrPipe, "stderr", &stderr, bar, reporter)
+	}()
+
+	// 必须在读取完所有输出后再等待命令结束
+	wg.Wait()
+	bar.Done()
 	err = cmd.Wait()
 	duration := time.Since(startTime)
 
 	if err != nil {
+		// 失败详情只写入日志文件和详细模式，终端上由调用方输出本地化的提示
+		slog.Info("git clone failed",
+			"repo", repoURL,
+			"branch", branch,
+			"duration", duration,
+			"error", err,
+			"stderr", stderr.String(),
+		)
+
 		// 检查超时
 		if ctx.Err() == context.DeadlineExceeded {
-			return fmt.Errorf("download timed out after %v", duration)
+			return fmt.Errorf("%s", i18n.T("git.error.timeout", duration))
 		}
 
-		// 处理特定错误类型
+		// 根据 git 的错误输出处理特定错误类型
+		detail := stderr.String() + "\n" + err.Error()
 		switch {
-		case strings.Contains(err.Error(), "Authentication failed"),
-			strings.Contains(err.Error(), "could not read Username"),
-			strings.Contains(err.Error(), "Permission denied"):
-			return fmt.Errorf("authentication failed after %v. Try: goravel-kit-cli new %s --ssh", duration, filepath.Base(targetDir))
+		case strings.Contains(detail, "Authentication failed"),
+			strings.Contains(detail, "could not read Username"),
+			strings.Contains(detail, "Permission denied"):
+			return fmt.Errorf("%s", i18n.T("git.error.auth", duration, filepath.Base(targetDir)))
 
-		case strings.Contains(err.Error(), "Repository not found"):
-			return fmt.Errorf("repository not found: %s (took %v)", repoURL, duration)
+		case strings.Contains(detail, "Repository not found"),
+			strings.Contains(detail, "does not appear to be a git repository"),
+			strings.Contains(detail, "does not exist"):
+			return fmt.Errorf("%s", i18n.T("git.error.not_found", repoURL, duration))
 
-		case strings.Contains(err.Error(), "could not find remote ref"):
-			return fmt.Errorf("branch '%s' not found (took %v)", branch, duration)
+		case strings.Contains(detail, "could not find remote ref"),
+			strings.Contains(detail, "not found in upstream origin"):
+			return fmt.Errorf("%s", i18n.T("git.error.branch", branch, duration))
 
-		case strings.Contains(err.Error(), "Host key verification failed"):
-			return fmt.Errorf("SSH host key verification failed. Please check your SSH configuration")
+		case strings.Contains(detail, "Host key verification failed"):
+			return fmt.Errorf("%s", i18n.T("git.error.host_key"))
 
 		default:
-			return fmt.Errorf("git clone failed after %v: %w", duration, err)
+			return fmt.Errorf("%s: %w", i18n.T("git.error.failed", duration), err)
 		}
 	}
 
-	if verbose {
-		fmt.Printf("✅ Download completed in %v\n", duration)
+	slog.Info("git clone finished", "repo", repoURL, "branch", branch, "duration", duration)
+	if reporter.Verbose {
+		fmt.Fprintf(reporter.Writer(), "✅ %s\n", i18n.T("git.done_in", duration))
 	} else {
-		fmt.Printf("✅ Download completed\n")
+		fmt.Fprintf(reporter.Writer(), "✅ %s\n", i18n.T("git.done"))
 	}
 
 	return nil
 }
 
-// streamOutput 实时流式输出
-func streamOutput(reader io.Reader, prefix string, verbose bool) {
+// streamOutput 实时读取 git 输出，所有行都写入日志，进度行交给 bar 渲染；
+// git 使用 '\r' 原地刷新进度，因此按 '\r' 和 '\n' 切分。capture 不为空时同时保存原始输出
+func streamOutput(reader io.Reader, stream string, capture io.Writer, bar *progress.Bar, reporter *output.Reporter) {
 	scanner := bufio.NewScanner(reader)
+	scanner.Split(progress.ScanLinesOrCR)
 	for scanner.Scan() {
-		line := scanner.Text()
-		if verbose {
-			fmt.Printf("%s: %s\n", prefix, line)
-		} else {
-			// 非详细模式只显示进度信息
-			if strings.Contains(line, "Receiving objects:") ||
-				strings.Contains(line, "Resolving deltas:") ||
-				strings.Contains(line, "remote:") {
-				fmt.Printf("📦 %s\n", strings.TrimSpace(line))
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if capture != nil {
+			fmt.Fprintln(capture, line)
+		}
+
+		p, ok := progress.ParseGitProgress(line)
+		if !ok {
+			slog.Debug("git output", "stream", stream, "line", line)
+			continue
+		}
+
+		reporter.Emit(output.EventCloneProgress, map[string]any{
+			"phase":      p.Phase,
+			"percent":    p.Percent,
+			"current":    p.Current,
+			"total":      p.Total,
+			"received":   p.Received,
+			"throughput": p.Throughput,
+		})
+		// 远端的计数/压缩阶段很快，只渲染本地接收和解析阶段
+		if !p.Remote {
+			bar.Update(p.Phase, p.Current, p.Total, p.Detail())
+		}
+		if p.Current >= p.Total {
+			slog.Debug("git progress", "stream", stream, "line", line)
+		}
+	}
+}
+
+// FetchRef 将仓库的指定 ref（分支、标签或提交 SHA）浅克隆到 targetDir
+func FetchRef(ctx context.Context, repoURL, ref, targetDir string) error {
+	steps := [][]string{
+		{"init", "-q", targetDir},
+		{"-C", targetDir, "fetch", "-q", "--depth", "1", repoURL, ref},
+		{"-C", targetDir, "-c", "advice.detachedHead=false", "checkout", "-q", "FETCH_HEAD"},
+	}
+	for _, args := range steps {
+		startTime := time.Now()
+		cmd := exec.CommandContext(ctx, "git", args...)
+		killProcessGroupOnCancel(cmd)
+		out, err := cmd.CombinedOutput()
+		slog.Info("running command", "cmd", "git "+strings.Join(args, " "), "duration", time.Since(startTime), "error", err)
+		slog.Debug("command output", "output", string(out))
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("%s", i18n.T("git.error.timeout", time.Since(startTime)))
 			}
+			return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// HeadCommit 返回仓库当前 HEAD 的提交 SHA
+func HeadCommit(repoDir string) (string, error) {
+	out, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// InsideWorkTree 判断 dir 是否位于 git 工作区中，包括 monorepo 的子目录；git 不可用时返回 false
+func InsideWorkTree(dir string) bool {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--is-inside-work-tree").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// UncommittedChanges 返回 dir 下未提交的改动（git status --porcelain 的输出行），包括未跟踪的文件，
+// 不包括所在仓库中 dir 以外的改动；dir 不是 git 仓库或 git 不可用时返回错误
+func UncommittedChanges(dir string) ([]string, error) {
+	var stderr strings.Builder
+	cmd := exec.Command("git", "-C", dir, "status", "--porcelain", "--", ".")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var changes []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			changes = append(changes, line)
+		}
+	}
+	return changes, nil
+}
+
+// MergeFile 使用 git merge-file 对 current、base、other 三个文件做三方合并，
+// 返回合并结果和冲突数量；labels 依次为三个文件在冲突标记中的名称
+func MergeFile(current, base, other string, labels [3]string) ([]byte, int, error) {
+	args := []string{"merge-file", "-p",
+		"-L", labels[0], "-L", labels[1], "-L", labels[2],
+		current, base, other,
+	}
+	var stderr strings.Builder
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// 退出码为正数时表示冲突数量
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return out, exitErr.ExitCode(), nil
 		}
+		return nil, 0, fmt.Errorf("git merge-file: %w: %s", err, strings.TrimSpace(stderr.String()))
 	}
+	return out, 0, nil
 }
 
 // 保持兼容性
 func CloneRepository(repoURL, branch, targetDir string) error {
-	return CloneRepositoryWithContext(context.Background(), repoURL, branch, targetDir, false)
+	return CloneRepositoryWithContext(context.Background(), repoURL, branch, targetDir)
 }
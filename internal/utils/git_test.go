@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newLocalRepo 创建一个包含单次提交的本地仓库
+func newLocalRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := filepath.Join(t.TempDir(), "repo")
+	steps := [][]string{
+		{"init", "-q", "-b", "master", dir},
+		{"-C", dir, "-c", "user.email=test@example.com", "-c", "user.name=test", "commit", "-q", "--allow-empty", "-m", "init"},
+	}
+	for _, args := range steps {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestCloneRepositoryWithContext_ClassifiesMissingBranch(t *testing.T) {
+	repo := newLocalRepo(t)
+
+	err := CloneRepositoryWithContext(context.Background(), "file://"+repo, "does-not-exist", filepath.Join(t.TempDir(), "clone"))
+	if err == nil {
+		t.Fatalf("expected clone of missing branch to fail")
+	}
+	if !strings.Contains(err.Error(), "does-not-exist") {
+		t.Fatalf("expected branch error mentioning the branch, got: %v", err)
+	}
+}
+
+func TestCloneRepositoryWithContext_AndHeadCommit(t *testing.T) {
+	repo := newLocalRepo(t)
+	target := filepath.Join(t.TempDir(), "clone")
+
+	if err := CloneRepositoryWithContext(context.Background(), "file://"+repo, "master", target); err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+
+	want, err := HeadCommit(repo)
+	if err != nil {
+		t.Fatalf("HeadCommit(repo) failed: %v", err)
+	}
+	got, err := HeadCommit(target)
+	if err != nil || got != want {
+		t.Fatalf("expected cloned HEAD %q, got %q (err=%v)", want, got, err)
+	}
+}
+
+func TestUncommittedChanges(t *testing.T) {
+	repo := newLocalRepo(t)
+
+	changes, err := UncommittedChanges(repo)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected a clean repository, got %v %v", changes, err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	changes, err = UncommittedChanges(repo)
+	if err != nil || len(changes) != 1 || changes[0] != "?? notes.txt" {
+		t.Fatalf("expected the untracked file to be reported, got %v %v", changes, err)
+	}
+	if _, err := UncommittedChanges(t.TempDir()); err == nil {
+		t.Fatalf("expected an error outside a git repository")
+	}
+
+	// 子目录只报告自身的改动
+	sub := filepath.Join(repo, "apps", "shop")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if !InsideWorkTree(sub) || InsideWorkTree(t.TempDir()) {
+		t.Fatalf("expected only the subdirectory to be inside a work tree")
+	}
+	changes, err = UncommittedChanges(sub)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes in the subdirectory, got %v %v", changes, err)
+	}
+}
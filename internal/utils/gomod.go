@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	goModModule = regexp.MustCompile(`(?m)^module\s+"?([^\s"]+)"?`)
+	goModGo     = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)`)
+	goMinor     = regexp.MustCompile(`^\d+\.\d+`)
+)
+
+// GoMod go.mod 中的模块路径和 Go 版本
+type GoMod struct {
+	Module string
+	Go     string
+}
+
+// ReadGoMod 读取项目 go.mod 中的模块路径和 go 指令声明的版本
+func ReadGoMod(dir string) (*GoMod, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	match := goModModule.FindSubmatch(content)
+	if match == nil {
+		return nil, fmt.Errorf("go.mod: module path not found")
+	}
+	mod := &GoMod{Module: string(match[1])}
+	if match := goModGo.FindSubmatch(content); match != nil {
+		mod.Go = string(match[1])
+	}
+	return mod, nil
+}
+
+// MinorVersion 返回 Go 版本的主版本.次版本部分，如 1.22.3 返回 1.22
+func (m *GoMod) MinorVersion() string {
+	if match := goMinor.FindString(m.Go); match != "" {
+		return match
+	}
+	return m.Go
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadGoMod(t *testing.T) {
+	dir := t.TempDir()
+	content := "// comment\nmodule example.com/my-app\n\ngo 1.22.3\n\ntoolchain go1.23.1\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	mod, err := ReadGoMod(dir)
+	if err != nil {
+		t.Fatalf("ReadGoMod failed: %v", err)
+	}
+	if mod.Module != "example.com/my-app" || mod.Go != "1.22.3" || mod.MinorVersion() != "1.22" {
+		t.Fatalf("unexpected go.mod: %+v (minor %s)", mod, mod.MinorVersion())
+	}
+
+	if _, err := ReadGoMod(t.TempDir()); err == nil {
+		t.Fatalf("expected error for missing go.mod")
+	}
+}
@@ -6,8 +6,12 @@ import (
 )
 
 func CheckGiteeAccess() bool {
-	timeout := 10 * time.Second
-	conn, err := net.DialTimeout("tcp", "gitee.com:443", timeout)
+	return CheckHostAccess("gitee.com:443", 10*time.Second)
+}
+
+// CheckHostAccess 检查 TCP 地址（host:port）在超时时间内是否可以连接
+func CheckHostAccess(address string, timeout time.Duration) bool {
+	conn, err := net.DialTimeout("tcp", address, timeout)
 	if err != nil {
 		return false
 	}
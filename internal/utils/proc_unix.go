@@ -0,0 +1,17 @@
+//go:build !windows
+
+package utils
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroupOnCancel 让命令在独立的进程组中运行，上下文取消时结束整个进程组。
+// git 通过 git-remote-http 等子进程访问远程仓库，只结束 git 本身时子进程仍会占用输出管道，导致超时后读取输出一直阻塞
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
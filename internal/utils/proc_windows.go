@@ -0,0 +1,14 @@
+//go:build windows
+
+package utils
+
+import (
+	"os/exec"
+	"time"
+)
+
+// killProcessGroupOnCancel 上下文取消时结束命令；Windows 下子进程不会随之结束，
+// 等待一段时间后强制关闭输出管道，避免子进程占用管道导致一直阻塞
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.WaitDelay = 5 * time.Second
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sshKeyNames 常见的 SSH 私钥文件名
+var sshKeyNames = []string{"id_ed25519", "id_ecdsa", "id_rsa", "id_dsa"}
+
+// FindSSHKeys 返回 ~/.ssh 下存在的默认私钥路径，以及 ~/.ssh/config 中 IdentityFile 指定且存在的私钥路径
+func FindSSHKeys() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var keys []string
+	seen := map[string]bool{}
+	add := func(path string) {
+		if !seen[path] && FileExists(path) {
+			seen[path] = true
+			keys = append(keys, path)
+		}
+	}
+	for _, name := range sshKeyNames {
+		add(filepath.Join(home, ".ssh", name))
+	}
+	for _, path := range sshConfigIdentityFiles(home) {
+		add(path)
+	}
+	return keys
+}
+
+// sshConfigIdentityFiles 返回 ~/.ssh/config 中所有 IdentityFile 的路径，~ 展开为 home，相对路径相对 ~/.ssh
+func sshConfigIdentityFiles(home string) []string {
+	file, err := os.Open(filepath.Join(home, ".ssh", "config"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var paths []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// 关键字与值之间可以是空白或 =，关键字不区分大小写
+		key, value, ok := strings.Cut(strings.Replace(line, "=", " ", 1), " ")
+		if !ok || !strings.EqualFold(key, "IdentityFile") {
+			continue
+		}
+		path := strings.Trim(strings.TrimSpace(value), `"`)
+		switch {
+		case path == "" || strings.EqualFold(path, "none"):
+			continue
+		case path == "~" || strings.HasPrefix(path, "~/"):
+			path = filepath.Join(home, path[1:])
+		case !filepath.IsAbs(path):
+			path = filepath.Join(home, ".ssh", path)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// HasSSHAgent 判断当前环境是否有可用的 ssh-agent
+func HasSSHAgent() bool {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return false
+	}
+	_, err := os.Stat(sock)
+	return err == nil
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFindSSHKeys_IdentityFileFromConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("HOME does not set the home directory on Windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(filepath.Join(sshDir, "keys"), 0700); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	config := strings.Join([]string{
+		"Host github.com",
+		"    IdentityFile ~/.ssh/keys/work",
+		"Host gitee.com",
+		`    identityfile="keys/gitee"`,
+		"    IdentityFile ~/.ssh/missing",
+		"",
+	}, "\n")
+	files := map[string]string{"config": config, "keys/work": "key", "keys/gitee": "key"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(sshDir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	keys := FindSSHKeys()
+	want := []string{filepath.Join(sshDir, "keys", "work"), filepath.Join(sshDir, "keys", "gitee")}
+	if strings.Join(keys, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected keys from IdentityFile, got %v", keys)
+	}
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// CommandVersion 执行 `name args...` 并返回输出中的第一个版本号
+// 例如 `go version` => "1.23.0"，`node --version` => "20.11.1"
+func CommandVersion(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	return ExtractVersion(string(output)), nil
+}
+
+// ExtractVersion 从任意文本中提取第一个形如 x.y[.z] 的版本号
+func ExtractVersion(text string) string {
+	match := versionPattern.FindString(text)
+	return strings.TrimSpace(match)
+}
+
+// CompareVersions 比较两个版本号，a<b 返回 -1，a==b 返回 0，a>b 返回 1
+// 缺失的段按 0 处理，因此 "1.18" 与 "1.18.0" 相等
+func CompareVersions(a, b string) int {
+	pa := parseVersion(a)
+	pb := parseVersion(b)
+	for i := 0; i < 3; i++ {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseVersion(v string) [3]int {
+	var parts [3]int
+	match := versionPattern.FindStringSubmatch(v)
+	if match == nil {
+		return parts
+	}
+	for i := 0; i < 3; i++ {
+		parts[i], _ = strconv.Atoi(match[i+1])
+	}
+	return parts
+}
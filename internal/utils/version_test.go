@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestExtractVersion(t *testing.T) {
+	cases := map[string]string{
+		"go version go1.23.0 linux/amd64": "1.23.0",
+		"v20.11.1\n":                      "20.11.1",
+		"git version 2.39.5":              "2.39.5",
+		"9.1":                             "9.1",
+		"no version here":                 "",
+	}
+	for input, want := range cases {
+		if got := ExtractVersion(input); got != want {
+			t.Fatalf("ExtractVersion(%q)=%q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.18", "1.18.0", 0},
+		{"1.23.0", "1.18", 1},
+		{"14.0", "20.11.1", -1},
+		{"1.9", "1.10", -1},
+	}
+	for _, tc := range cases {
+		if got := CompareVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("CompareVersions(%q, %q)=%d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
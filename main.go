@@ -5,24 +5,112 @@ import (
 	"os"
 
 	"github.com/hulutech-web/goravel-kit-cli/internal/commands"
+	"github.com/hulutech-web/goravel-kit-cli/internal/config"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/logger"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
 	"github.com/urfave/cli/v2"
 )
 
+const version = "v1.0.0"
+
 func main() {
+	closeLog := func() error { return nil }
+
+	// 插件目录可能在配置文件中指定，需要在注册命令前读取配置；读取失败时由 Before 报错
+	cfg, cfgErr := config.Load()
+	if cfgErr == nil {
+		config.Set(cfg)
+	}
+	builtin := []*cli.Command{commands.NewCommand, commands.DoctorCommand, commands.UpgradeCommand, commands.DiffCommand, commands.AddCommand, commands.MakeCrudCommand, commands.MakePermissionsCommand, commands.GenApiClientCommand, commands.RoutesCommand, commands.PluginCommand}
+
 	app := &cli.App{
 		Name:     "goravel-kit-cli",
 		Usage:    "A CLI tool to create new Goravel applications from templates",
-		Version:  "v1.0.0",
-		Commands: []*cli.Command{commands.NewCommand},
+		Version:  version,
+		Commands: append(builtin, commands.PluginCommands(builtin)...),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Output format: text, json or ndjson (event stream)",
+				Value:   output.FormatText,
+			},
+			&cli.StringFlag{
+				Name:  "lang",
+				Usage: "Message language: en or zh-CN (defaults to config, then LANG)",
+			},
+			&cli.StringFlag{
+				Name:  "log-level",
+				Usage: "Console log level: debug, info, warn or error",
+				Value: "warn",
+			},
+			&cli.StringFlag{
+				Name:  "log-file",
+				Usage: "Write a full debug log (git output, commands, timings) to this file",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if err := output.SetFormat(c.String("output")); err != nil {
+				return err
+			}
+
+			closeFn, err := logger.Setup(logger.Options{
+				Level: c.String("log-level"),
+				File:  c.String("log-file"),
+			})
+			if err != nil {
+				return err
+			}
+			closeLog = closeFn
+			logger.LogEnvironment(version, os.Args)
+
+			if cfgErr != nil {
+				return cfgErr
+			}
+
+			lang, err := i18n.Resolve(c.String("lang"), cfg.Lang)
+			if err != nil {
+				return err
+			}
+			return i18n.SetLocale(lang)
+		},
+		After: func(c *cli.Context) error {
+			return closeLog()
+		},
 		Description: `Goravel Kit CLI - Quickly create new Goravel projects from template.
 
 Examples:
   goravel-kit-cli new my-app
   goravel-kit-cli new my-app --ssh --verbose
-  goravel-kit-cli new my-app --branch develop --force`,
+  goravel-kit-cli new my-app --branch develop --force
+  goravel-kit-cli new my-app --without websocket,pdf
+  goravel-kit-cli new my-api --api-only
+  goravel-kit-cli new my-app --db sqlite --cache memory
+  goravel-kit-cli new my-app --db postgres --docker
+  goravel-kit-cli new my-app --docker --ci github
+  goravel-kit-cli new my-app --no-hooks
+  goravel-kit-cli --output ndjson new my-app
+  goravel-kit-cli doctor
+  goravel-kit-cli upgrade --dry-run
+  goravel-kit-cli diff --stat
+  goravel-kit-cli add docker
+  goravel-kit-cli add ci --provider gitlab
+  goravel-kit-cli make:crud Article --fields title:string,views:int
+  goravel-kit-cli make:permissions Article --title 文章 --parent content
+  goravel-kit-cli gen:api-client
+  goravel-kit-cli gen:api-client --check
+  goravel-kit-cli routes --json
+  goravel-kit-cli plugin install ./goravel-kit-cli-deploy
+  goravel-kit-cli plugin list
+  goravel-kit-cli deploy --env staging`,
 	}
 
-	if err := app.Run(os.Args); err != nil {
+	if err := app.Run(commands.InterspersedArgs(app, os.Args)); err != nil {
+		if !output.IsText() {
+			output.Fail(err)
+			os.Exit(1)
+		}
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,80 @@
+package scaffold
+
+import (
+	"fmt"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// 合并到已有目录时冲突的处理方式
+const (
+	ConflictSkip      = project.ConflictSkip
+	ConflictOverwrite = project.ConflictOverwrite
+	ConflictRename    = project.ConflictRename
+	// ConflictPrompt 通过 Generator.Prompt 逐个询问
+	ConflictPrompt = "prompt"
+)
+
+// MergeConflict 合并时一处冲突及其处理结果
+type MergeConflict = project.MergeConflict
+
+// Prompter 在终端上与用户交互
+type Prompter interface {
+	// Confirm 显示 question，返回用户是否同意继续
+	Confirm(question string) (bool, error)
+	// ResolveConflict 询问合并时与模板不同的已有文件 path（相对项目目录）如何处理，
+	// 返回 ConflictSkip、ConflictOverwrite 或 ConflictRename
+	ResolveConflict(path string) (string, error)
+}
+
+// checkExisting 在克隆前检查已有的目标目录能否按 Force/Backup/Merge 处理：
+// 删除或覆盖前确认目录不是有未提交改动的 git 仓库，Force 在有 Prompt 时需要用户确认
+func (g *Generator) checkExisting(opts Options) error {
+	dir := opts.path()
+	if !fsys.DirExists(fsys.Or(g.FS), dir) {
+		return nil
+	}
+	switch {
+	case opts.Force:
+		if err := checkClean(dir); err != nil {
+			return err
+		}
+		if g.Prompt == nil {
+			return nil
+		}
+		ok, err := g.Prompt.Confirm(i18n.T("new.confirm_force", dir))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%s", i18n.T("new.error.aborted"))
+		}
+	case opts.Merge:
+		if opts.Conflict == ConflictPrompt && g.Prompt == nil {
+			return fmt.Errorf("❌ %s", i18n.T("new.error.conflict_prompt"))
+		}
+		if opts.Conflict == ConflictOverwrite {
+			return checkClean(dir)
+		}
+	}
+	return nil
+}
+
+// checkClean dir 位于 git 工作区（可以是 monorepo 的子目录）且其中有未提交的改动（包括未跟踪的文件）时返回错误，
+// 避免删除或覆盖尚未保存的工作
+func checkClean(dir string) error {
+	if !utils.InsideWorkTree(dir) {
+		return nil
+	}
+	changes, err := utils.UncommittedChanges(dir)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.git_status", dir), err)
+	}
+	if len(changes) > 0 {
+		return fmt.Errorf("❌ %s\n   💡 %s", i18n.T("new.error.dirty_repo", dir, len(changes)), i18n.T("new.dirty_repo_remedy"))
+	}
+	return nil
+}
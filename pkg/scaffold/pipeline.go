@@ -0,0 +1,430 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/ci"
+	"github.com/hulutech-web/goravel-kit-cli/internal/docker"
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+	"github.com/hulutech-web/goravel-kit-cli/internal/features"
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/hooks"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/project"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// pipeline 一次生成的流程，按 hooks.Stages 的顺序执行各阶段，每个阶段前后执行对应的钩子
+type pipeline struct {
+	ctx     context.Context
+	opts    Options
+	r       *output.Reporter
+	mirrors []Mirror
+	// projectDir 项目最终目录的绝对路径
+	projectDir string
+	// stagingDir 克隆和处理模板的临时目录
+	stagingDir string
+	// workDir 钩子的工作目录，move 阶段完成后切换为项目目录
+	workDir     string
+	hasFrontend bool
+	result      Result
+	hooks       *hooks.Runner
+	// files strip、move、env 阶段使用的文件系统，nil 时为真实文件系统
+	files fsys.FS
+	// prompt 逐个询问合并冲突的处理方式，nil 时为非交互环境
+	prompt Prompter
+	// keepEnv 合并前项目目录中已有 .env，env 阶段不再生成
+	keepEnv bool
+}
+
+// run 依次执行所有阶段
+func (p *pipeline) run() error {
+	stages := []struct {
+		name string
+		fn   func() error
+	}{
+		{hooks.StageClone, p.clone},
+		{hooks.StageFeatures, p.selectFeatures},
+		{hooks.StageDrivers, p.applyDrivers},
+		{hooks.StageStrip, p.strip},
+		{hooks.StageMove, p.move},
+		{hooks.StageEnv, p.env},
+		{hooks.StageDocker, p.generateDocker},
+		{hooks.StageCI, p.generateCI},
+		{hooks.StageCommands, p.runCommands},
+	}
+	for _, stage := range stages {
+		if err := p.runHooks(hooks.Pre, stage.name); err != nil {
+			return err
+		}
+		slog.Debug("stage started", "stage", stage.name, "dir", p.workDir)
+		if err := stage.fn(); err != nil {
+			return err
+		}
+		if err := p.runHooks(hooks.Post, stage.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fs 返回 strip、move、env 阶段使用的文件系统
+func (p *pipeline) fs() fsys.FS {
+	return fsys.Or(p.files)
+}
+
+// drivers 返回选择的数据库和缓存驱动
+func (p *pipeline) drivers() driver.Options {
+	return driver.Options{Database: p.opts.Database, Cache: p.opts.Cache}
+}
+
+// runHooks 执行某个阶段的 pre/post 钩子，可选钩子失败时只输出警告
+func (p *pipeline) runHooks(when, stage string) error {
+	key := hooks.Key(when, stage)
+	if len(p.hooks.Hooks[key]) == 0 {
+		return nil
+	}
+	warnings, err := p.hooks.Run(p.ctx, when, hooks.Context{
+		Stage:      stage,
+		Dir:        p.workDir,
+		ProjectDir: p.projectDir,
+		Project:    p.opts.Name,
+		Repository: p.result.RepoURL,
+		Mirror:     p.result.Mirror,
+		Ref:        p.opts.Ref,
+		SHA:        p.result.SHA,
+		Without:    p.result.Without,
+		Database:   p.opts.Database,
+		Cache:      p.opts.Cache,
+		CLIVersion: p.opts.CLIVersion,
+	})
+	for _, warning := range warnings {
+		p.r.Printf(color.New(color.FgHiYellow), "⚠️  %s: %v\n", i18n.T("common.warning"), warning)
+	}
+	p.r.Step("hook:"+key, err)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("hooks.error.failed"), err)
+	}
+	return nil
+}
+
+// clone 依次尝试各个镜像源，将模板克隆到临时目录，并加载模板清单中的钩子
+func (p *pipeline) clone() error {
+	var downloadError error
+	var successMirror string
+	var successRepoURL string
+	ref := p.opts.Ref
+
+	// 尝试从各个镜像源下载
+	for _, mirror := range p.mirrors {
+		repoURL := mirror.cloneURL(p.opts.Protocol)
+
+		p.r.Printf(color.New(color.FgHiGreen), "\n📥 %s\n", i18n.T("new.clone.trying", mirror.Name))
+		p.r.Printf(color.New(color.FgHiCyan), "   📍 %s\n", i18n.T("new.clone.repo", repoURL))
+		p.r.Printf(color.New(color.FgHiCyan), "   🌿 %s\n", i18n.T("new.branch", ref))
+
+		// 使用带超时的上下文
+		ctx, cancel := context.WithTimeout(p.ctx, p.opts.Timeout)
+
+		// 下载模板
+		p.r.Emit(EventMirrorAttempt, map[string]any{"mirror": mirror.Name, "url": repoURL, "ref": ref, "status": "started"})
+		slog.Info("mirror attempt", "mirror", mirror.Name, "url", repoURL, "branch", ref, "timeout", p.opts.Timeout)
+		err := utils.CloneRepositoryWithReporter(ctx, repoURL, ref, p.stagingDir, p.r)
+		cancel()
+
+		if err != nil {
+			downloadError = err
+			p.r.Emit(EventMirrorAttempt, map[string]any{"mirror": mirror.Name, "url": repoURL, "ref": ref, "status": "failed", "error": err.Error()})
+			slog.Info("mirror failed", "mirror", mirror.Name, "error", err)
+			p.r.Printf(color.New(color.FgHiRed), "❌ %s\n", i18n.T("new.clone.failed", mirror.Name, err))
+			// 被中断的克隆会在临时目录中留下部分内容，清空后才能克隆下一个镜像源，git clone 会重新创建该目录
+			if err := os.RemoveAll(p.stagingDir); err != nil {
+				slog.Warn("failed to reset staging dir", "dir", p.stagingDir, "error", err)
+			}
+
+			// 如果不是最后一个镜像源，继续尝试下一个
+			if hasNextMirror(p.mirrors, mirror.Name) {
+				p.r.Printf(color.New(color.FgHiYellow), "🔄 %s\n", i18n.T("new.clone.next"))
+				continue
+			}
+		} else {
+			successMirror = mirror.Name
+			successRepoURL = repoURL
+			downloadError = nil
+			p.r.Emit(EventMirrorAttempt, map[string]any{"mirror": mirror.Name, "url": repoURL, "ref": ref, "status": "succeeded"})
+			break
+		}
+	}
+
+	// 检查下载结果
+	if downloadError != nil || successMirror == "" {
+		yellow := color.New(color.FgHiYellow)
+		p.r.Printf(color.New(color.FgHiRed), "\n❌ %s\n", i18n.T("new.clone.all_failed"))
+		p.r.Printf(yellow, "💡 %s\n", i18n.T("new.clone.solutions"))
+		p.r.Printf(yellow, "   1. %s\n", i18n.T("new.clone.solution.network"))
+		p.r.Printf(yellow, "   2. %s\n", i18n.T("new.clone.solution.protocol"))
+		p.r.Printf(yellow, "   3. %s\n", i18n.T("new.clone.solution.gitee_only"))
+		p.r.Printf(yellow, "   4. %s\n", i18n.T("new.clone.solution.github_only"))
+		p.r.Printf(yellow, "   5. %s\n", i18n.T("new.clone.solution.verbose"))
+		p.r.Printf(yellow, "   6. %s\n", i18n.T("new.clone.solution.branch", ref))
+		return fmt.Errorf("%s", i18n.T("new.error.all_failed"))
+	}
+
+	p.r.Printf(color.New(color.FgHiGreen), "\n✅ %s\n", i18n.T("new.clone.succeeded", successMirror))
+	p.r.Printf(color.New(color.FgHiCyan), "   📍 %s\n", i18n.T("new.clone.source_repo", successRepoURL))
+	p.r.Printf(color.New(color.FgHiCyan), "   🌿 %s\n", i18n.T("new.branch", ref))
+	p.r.Printf(color.New(color.FgHiGreen), "🔄 %s\n", i18n.T("new.processing"))
+
+	p.result.Mirror = successMirror
+	p.result.RepoURL = successRepoURL
+	if sha, err := utils.HeadCommit(p.stagingDir); err == nil {
+		p.result.SHA = sha
+	}
+
+	// 模板清单中的钩子从 post-clone 开始生效，排在调用方提供的钩子之后
+	manifest, err := features.LoadManifest(p.stagingDir)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.manifest"), err)
+	}
+	if !p.opts.DisableHooks && len(manifest.Hooks) > 0 {
+		p.hooks.Hooks = hooks.Merge(p.hooks.Hooks, manifest.Hooks)
+	}
+	return nil
+}
+
+// selectFeatures 按 Features/Without/APIOnly 移除可选功能模块
+func (p *pipeline) selectFeatures() error {
+	manifest, err := features.LoadManifest(p.stagingDir)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.manifest"), err)
+	}
+	exclude := append([]string{}, p.opts.Without...)
+	if p.opts.APIOnly {
+		exclude = append(exclude, manifest.FrontendFeatures()...)
+	}
+	removedFeatures, err := manifest.Select(p.opts.Features, exclude)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("features.error.select"), err)
+	}
+	without := features.FeatureNames(removedFeatures)
+	// 前端模块被移除（APIOnly 或 Without frontend）时按纯 API 项目处理
+	for _, feature := range removedFeatures {
+		if feature.Frontend {
+			p.hasFrontend = false
+		}
+	}
+	if len(without) > 0 {
+		if err := project.RemoveFeatures(p.stagingDir, without, p.r); err != nil {
+			return err
+		}
+		p.r.Printf(color.New(color.FgHiGreen), "🧩 %s\n", i18n.T("features.removed", strings.Join(without, ", ")))
+	}
+	p.result.Without = without
+	p.result.APIOnly = !p.hasFrontend
+	return nil
+}
+
+// applyDrivers 按 Database/Cache 切换数据库和缓存驱动
+func (p *pipeline) applyDrivers() error {
+	drivers := p.drivers()
+	if _, err := project.ApplyDrivers(p.stagingDir, drivers, p.r); err != nil {
+		return err
+	}
+	if drivers.Database != "" || drivers.Cache != "" {
+		p.r.Printf(color.New(color.FgHiGreen), "🗄️  %s\n", i18n.T("driver.applied", project.DriverSummary(drivers)))
+	}
+	p.result.Database = drivers.Database
+	p.result.Cache = drivers.Cache
+	return nil
+}
+
+// strip 移除 .git 目录和其他不必要的文件
+func (p *pipeline) strip() error {
+	return project.Strip(p.fs(), p.stagingDir, p.r)
+}
+
+// move 将处理好的模板移动到项目目录，目标目录已存在时先删除
+func (p *pipeline) move() error {
+	projectDir := p.projectDir
+	if fsys.DirExists(p.fs(), projectDir) {
+		switch {
+		case p.opts.Merge:
+			return p.merge()
+		case p.opts.Backup:
+			if err := p.backup(); err != nil {
+				return err
+			}
+		case p.opts.Force:
+			// 克隆期间目录中可能产生了新的改动，删除前再检查一次
+			if err := checkClean(projectDir); err != nil {
+				return err
+			}
+			if err := p.fs().RemoveAll(projectDir); err != nil {
+				return fmt.Errorf("❌ %s: %w", i18n.T("new.error.remove_existing"), err)
+			}
+			if p.opts.Verbose {
+				p.r.Printf(color.New(color.FgHiYellow), "🗑️  %s\n", i18n.T("new.removed_existing", projectDir))
+			}
+		default:
+			return fmt.Errorf("❌ %s", i18n.T("preflight.dir_exists", projectDir))
+		}
+	}
+
+	// 移动到目标位置
+	if err := project.Move(p.fs(), p.stagingDir, projectDir, p.opts.CopyConcurrency, p.r); err != nil {
+		p.r.Step("move", err)
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.create_project"), err)
+	}
+	p.r.Step("move", nil)
+	slog.Info("project moved", "from", p.stagingDir, "to", projectDir)
+	p.r.Printf(color.New(color.FgHiGreen), "📁 %s\n", i18n.T("new.structure_created"))
+	p.workDir = p.projectDir
+	return nil
+}
+
+// backup 将已有的项目目录重命名为 <name>.bak-<时间戳>
+func (p *pipeline) backup() error {
+	projectDir := p.projectDir
+	backup := project.BackupName(p.fs(), projectDir, ".bak-"+time.Now().Format("20060102-150405"))
+	if err := p.fs().Rename(projectDir, backup); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.backup"), err)
+	}
+	p.result.Backup = backup
+	slog.Info("existing project backed up", "from", projectDir, "to", backup)
+	p.r.Printf(color.New(color.FgHiYellow), "📦 %s\n", i18n.T("new.backed_up", projectDir, backup))
+	return nil
+}
+
+// merge 将模板合并到已有的项目目录，已有的 .env 保持不变
+func (p *pipeline) merge() error {
+	projectDir := p.projectDir
+	p.keepEnv = fsys.FileExists(p.fs(), filepath.Join(projectDir, ".env"))
+	conflicts, err := project.Merge(p.fs(), p.stagingDir, projectDir, p.resolveConflict)
+	p.result.Conflicts = conflicts
+	p.r.Step("move", err)
+	if err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("new.error.merge"), err)
+	}
+	slog.Info("template merged", "from", p.stagingDir, "to", projectDir, "conflicts", len(conflicts))
+	for _, conflict := range conflicts {
+		line := "   - " + conflict.Path + ": " + conflict.Action
+		if conflict.RenamedTo != "" {
+			line += " → " + conflict.RenamedTo
+		}
+		p.r.Printf(color.New(color.FgHiYellow), "%s\n", line)
+	}
+	p.r.Printf(color.New(color.FgHiGreen), "🔀 %s\n", i18n.T("new.merged", projectDir, len(conflicts)))
+	p.workDir = p.projectDir
+	return nil
+}
+
+// resolveConflict 按 Conflict 选项决定合并冲突的处理方式，prompt 时询问用户
+func (p *pipeline) resolveConflict(path string) (string, error) {
+	if p.opts.Conflict != ConflictPrompt {
+		return p.opts.Conflict, nil
+	}
+	if p.prompt == nil {
+		return "", fmt.Errorf("%s", i18n.T("new.error.conflict_prompt"))
+	}
+	return p.prompt.ResolveConflict(path)
+}
+
+// env 记录模板来源，并生成和更新 .env
+func (p *pipeline) env() error {
+	// 记录模板来源，供 upgrade/diff 使用
+	now := time.Now().UTC()
+	if err := project.WriteMetadata(p.fs(), p.projectDir, &project.Metadata{
+		Project:    p.opts.Name,
+		Repository: p.result.RepoURL,
+		Mirror:     p.result.Mirror,
+		Ref:        p.opts.Ref,
+		SHA:        p.result.SHA,
+		Without:    p.result.Without,
+		Database:   p.opts.Database,
+		Cache:      p.opts.Cache,
+		CLIVersion: p.opts.CLIVersion,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}); err != nil {
+		slog.Warn("failed to write template metadata", "error", err)
+	}
+	// 合并到已有项目时不覆盖其中的 .env
+	if p.keepEnv {
+		p.r.Printf(color.New(color.FgHiYellow), "📝 %s\n", i18n.T("new.env_kept"))
+		return nil
+	}
+	// 创建 .env 文件，通过复制 .env.example 得到
+	if err := project.CreateEnv(p.fs(), p.projectDir, p.r); err != nil {
+		return err
+	}
+
+	// 更新环境文件
+	err := project.UpdateEnv(p.fs(), p.projectDir, p.opts.Name)
+	p.r.Step("env_update", err)
+	if err != nil {
+		p.r.Printf(color.New(color.FgHiYellow), "⚠️  %s: %s: %v\n", i18n.T("common.warning"), i18n.T("new.warn.update_env"), err)
+	} else {
+		p.r.Printf(color.New(color.FgHiGreen), "📝 %s\n", i18n.T("new.env_updated"))
+	}
+	return nil
+}
+
+// generateDocker 按 Docker 生成 Docker 配置，失败时不影响已创建的项目；合并时保留的 .env 不会被修改
+func (p *pipeline) generateDocker() error {
+	if !p.opts.Docker {
+		return nil
+	}
+	if _, err := project.GenerateDocker(p.projectDir, docker.Options{
+		Project:  p.opts.Name,
+		Database: p.opts.Database,
+		Cache:    p.opts.Cache,
+		KeepEnv:  p.keepEnv,
+	}, p.r); err != nil {
+		p.r.Printf(color.New(color.FgHiYellow), "⚠️  %s: %v\n", i18n.T("common.warning"), err)
+	} else {
+		p.result.Docker = true
+	}
+	return nil
+}
+
+// generateCI 按 CI 生成 CI 工作流，在 Docker 配置之后生成以便包含镜像构建任务
+func (p *pipeline) generateCI() error {
+	if p.opts.CI == "" {
+		return nil
+	}
+	if report, err := project.GenerateCI(p.projectDir, ci.Options{Provider: p.opts.CI}, p.r); err != nil {
+		p.r.Printf(color.New(color.FgHiYellow), "⚠️  %s: %v\n", i18n.T("common.warning"), err)
+	} else {
+		p.result.CI = report.File
+	}
+	return nil
+}
+
+// runCommands 在项目根目录下依次执行 go run . artisan key:generate 和 go run . artisan jwt:secret，
+// 任一命令失败时返回错误。合并时保留了已有的 .env，两个命令都会重新生成其中的密钥，因此跳过
+func (p *pipeline) runCommands() error {
+	if p.keepEnv {
+		p.r.Printf(color.New(color.FgHiYellow), "🔑 %s\n", i18n.T("new.keys_kept"))
+		return nil
+	}
+	commands := [][]string{
+		{"go", "run", ".", "artisan", "key:generate"},
+		{"go", "run", ".", "artisan", "jwt:secret"},
+	}
+
+	for _, cmdArgs := range commands {
+		if err := project.RunCommand(p.projectDir, cmdArgs, p.r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
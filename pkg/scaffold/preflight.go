@@ -0,0 +1,193 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/progress"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// minFreeDiskSpace 克隆模板并生成项目所需的最小剩余磁盘空间
+const minFreeDiskSpace = 200 << 20
+
+// projectNamePattern 项目名称需同时是合法目录名和 Go 模块路径元素
+var projectNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// windowsReservedNames Windows 下不能作为文件名的保留名称
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// preflightOptions 预检所需的参数
+type preflightOptions struct {
+	projectName string
+	// dir 项目目录所在的父目录，为空时使用当前目录
+	dir     string
+	force   bool
+	useSSH  bool
+	tempDir string
+	// reporter 输出不阻止创建的警告，如找不到 SSH 凭证
+	reporter *output.Reporter
+}
+
+// preflightIssue 预检发现的问题及修复建议
+type preflightIssue struct {
+	problem string
+	remedy  string
+}
+
+// runPreflight 在克隆模板前检查项目名称、工具、磁盘空间和认证方式，
+// 将发现的所有问题合并为一个错误返回；认证方式只能推测，有问题时仅输出警告
+func runPreflight(opts preflightOptions) error {
+	for _, warning := range checkAuthMethod(opts.useSSH) {
+		opts.reporter.Printf(color.New(color.FgHiYellow), "⚠️  %s\n   💡 %s\n", warning.problem, warning.remedy)
+	}
+
+	var issues []preflightIssue
+
+	if err := validateProjectName(opts.projectName); err != nil {
+		issues = append(issues, preflightIssue{
+			problem: i18n.T("preflight.name_invalid", opts.projectName, err),
+			remedy:  i18n.T("preflight.name_remedy"),
+		})
+	} else {
+		issues = append(issues, checkTargetDirectory(filepath.Join(opts.dir, opts.projectName), opts.force)...)
+	}
+
+	issues = append(issues, checkRequiredTools()...)
+	issues = append(issues, checkDiskSpace(i18n.T("preflight.disk_label_temp"), opts.tempDir)...)
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("❌ " + i18n.T("preflight.failed") + ":")
+	for _, issue := range issues {
+		b.WriteString("\n   - " + issue.problem)
+		if issue.remedy != "" {
+			b.WriteString("\n     💡 " + issue.remedy)
+		}
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// validateProjectName 校验项目名称可安全地用作目录名和 Go 模块名
+func validateProjectName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%s", i18n.T("preflight.name.empty"))
+	case strings.ContainsAny(name, `/\`) || strings.Contains(name, ".."):
+		return fmt.Errorf("%s", i18n.T("preflight.name.separator"))
+	case !projectNamePattern.MatchString(name):
+		return fmt.Errorf("%s", i18n.T("preflight.name.chars"))
+	case strings.HasSuffix(name, "."):
+		return fmt.Errorf("%s", i18n.T("preflight.name.trailing_dot"))
+	}
+
+	base := strings.ToUpper(strings.SplitN(name, ".", 2)[0])
+	if windowsReservedNames[base] {
+		return fmt.Errorf("%s", i18n.T("preflight.name.reserved", name))
+	}
+	return nil
+}
+
+// checkTargetDirectory 检查项目目录 projectDir 是否已存在以及父目录是否可写
+func checkTargetDirectory(projectDir string, force bool) []preflightIssue {
+	var issues []preflightIssue
+
+	if utils.DirectoryExists(projectDir) && !force {
+		issues = append(issues, preflightIssue{
+			problem: i18n.T("preflight.dir_exists", projectDir),
+			remedy:  i18n.T("preflight.dir_exists_remedy"),
+		})
+	} else if utils.FileExists(projectDir) {
+		issues = append(issues, preflightIssue{
+			problem: i18n.T("preflight.file_exists", projectDir),
+			remedy:  i18n.T("preflight.file_exists_remedy"),
+		})
+	}
+
+	parent, err := filepath.Abs(filepath.Dir(projectDir))
+	if err != nil {
+		parent = filepath.Dir(projectDir)
+	}
+	probe, err := os.CreateTemp(parent, ".goravel-kit-preflight-*")
+	if err != nil {
+		issues = append(issues, preflightIssue{
+			problem: i18n.T("preflight.unwritable", parent, err),
+			remedy:  i18n.T("preflight.unwritable_remedy"),
+		})
+		return issues
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	return append(issues, checkDiskSpace(i18n.T("preflight.disk_label_target"), parent)...)
+}
+
+// checkRequiredTools 检查创建项目依赖的命令行工具
+func checkRequiredTools() []preflightIssue {
+	var issues []preflightIssue
+	tools := []struct {
+		name   string
+		remedy string
+	}{
+		{name: "git", remedy: i18n.T("preflight.tool_git_remedy")},
+		{name: "go", remedy: i18n.T("preflight.tool_go_remedy")},
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool.name); err != nil {
+			issues = append(issues, preflightIssue{
+				problem: i18n.T("preflight.tool_missing", tool.name),
+				remedy:  tool.remedy,
+			})
+		}
+	}
+	return issues
+}
+
+// checkDiskSpace 检查目录所在磁盘剩余空间是否足够
+func checkDiskSpace(label, dir string) []preflightIssue {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	free, err := utils.FreeDiskSpace(dir)
+	if err != nil {
+		// 无法获取磁盘信息时不阻止创建
+		return nil
+	}
+	if free < minFreeDiskSpace {
+		return []preflightIssue{{
+			problem: i18n.T("preflight.disk_low", label, dir, progress.FormatBytes(int64(free)), progress.FormatBytes(minFreeDiskSpace)),
+			remedy:  i18n.T("preflight.disk_remedy"),
+		}}
+	}
+	return nil
+}
+
+// checkAuthMethod 检查 SSH 方式克隆时是否有可用的 SSH 凭证。
+// 密钥也可能来自 ~/.ssh/config 之外的配置或硬件令牌，找不到时只作为警告，不阻止克隆
+func checkAuthMethod(useSSH bool) []preflightIssue {
+	if !useSSH {
+		return nil
+	}
+	if len(utils.FindSSHKeys()) > 0 || utils.HasSSHAgent() {
+		return nil
+	}
+	return []preflightIssue{{
+		problem: i18n.T("preflight.ssh_missing"),
+		remedy:  i18n.T("preflight.ssh_remedy"),
+	}}
+}
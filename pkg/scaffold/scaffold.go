@@ -0,0 +1,424 @@
+// Package scaffold 提供从 goravel-kit 模板生成新项目的 Go API，
+// goravel-kit-cli new 命令只是它的命令行适配层。
+//
+//	gen := &scaffold.Generator{Out: os.Stdout, Stderr: os.Stderr}
+//	result, err := gen.Generate(ctx, scaffold.Options{Name: "my-app", Dir: "/srv/projects", Database: "postgres"})
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/ci"
+	"github.com/hulutech-web/goravel-kit-cli/internal/driver"
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/hooks"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+	"github.com/hulutech-web/goravel-kit-cli/internal/utils"
+)
+
+// 生成过程中发送给 EventSink 的事件类型
+const (
+	EventProbe         = output.EventProbe
+	EventMirrorAttempt = output.EventMirrorAttempt
+	EventCloneProgress = output.EventCloneProgress
+	EventFileRemoved   = output.EventFileRemoved
+	EventStepResult    = output.EventStepResult
+)
+
+// 克隆模板使用的协议
+const (
+	ProtocolSSH   = "ssh"
+	ProtocolHTTPS = "https"
+)
+
+// DefaultRef 未指定时使用的模板分支
+const DefaultRef = "master"
+
+// DefaultTimeout 未指定时单个镜像源的克隆超时时间
+const DefaultTimeout = 3 * time.Minute
+
+// Hook 和 Hooks 生成阶段的钩子，键为 "<pre|post>-<stage>"，如 post-clone、pre-move
+type (
+	Hook  = hooks.Hook
+	Hooks = hooks.Hooks
+)
+
+// FS 文件系统接口，语义与 os 包中的同名函数一致
+type FS = fsys.FS
+
+// Stages 生成阶段，按执行顺序排列
+var Stages = hooks.Stages
+
+// EventSink 接收生成过程中的结构化事件
+type EventSink func(event string, data map[string]any)
+
+// Mirror 模板仓库的一个镜像源
+type Mirror struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	SSHURL string `json:"ssh_url,omitempty"`
+}
+
+// DefaultMirrors 内置的模板镜像源：GitHub 和 Gitee
+func DefaultMirrors() []Mirror {
+	return []Mirror{
+		{
+			Name:   "GitHub",
+			URL:    "https://github.com/hulutech-web/goravel-kit.git",
+			SSHURL: "git@github.com:hulutech-web/goravel-kit.git",
+		},
+		{
+			Name:   "Gitee",
+			URL:    "https://gitee.com/hulutech/goravel-kit.git",
+			SSHURL: "git@gitee.com:hulutech/goravel-kit.git",
+		},
+	}
+}
+
+// Options 生成项目的参数
+type Options struct {
+	// Name 项目名称，同时是相对 Dir 的项目目录
+	Name string
+	// Dir 项目目录所在的父目录，为空时使用当前目录。所有文件和 git 操作都基于它，调用方无需切换工作目录
+	Dir string
+	// Ref 模板分支，默认 master
+	Ref string
+	// Protocol 克隆协议，ssh（默认）或 https；镜像源没有 SSH 地址时使用 URL
+	Protocol string
+	// Timeout 单个镜像源的克隆超时时间，默认 3 分钟
+	Timeout time.Duration
+	// Mirrors 依次尝试的镜像源，为空时使用 DefaultMirrors，并按 GiteeOnly/GitHubOnly 和网络探测结果筛选
+	Mirrors    []Mirror
+	GiteeOnly  bool
+	GitHubOnly bool
+
+	// Features 只保留这些可选模块，Without 移除这些可选模块
+	Features []string
+	Without  []string
+	// APIOnly 移除前端模块，生成纯 API 项目
+	APIOnly bool
+	// Database/Cache 数据库和缓存驱动，为空时使用模板默认值
+	Database string
+	Cache    string
+	// Docker 生成 Dockerfile 和 docker-compose.yml
+	Docker bool
+	// CI 生成 CI 工作流：github、gitlab 或 gitea
+	CI string
+
+	// CopyConcurrency 临时目录与项目目录不在同一磁盘分区时同时复制的文件数，小于等于 0 时使用默认值
+	CopyConcurrency int
+
+	// Force 目标目录已存在时删除后重新生成。目录是有未提交改动的 git 仓库时拒绝删除，
+	// Generator.Prompt 不为 nil 时先请求确认
+	Force bool
+	// Backup 目标目录已存在时先重命名为 <name>.bak-<时间戳>，再生成新项目
+	Backup bool
+	// Merge 目标目录已存在时将模板写入该目录，内容不同的已有文件按 Conflict 处理
+	Merge bool
+	// Conflict 合并时的冲突处理方式：skip、overwrite、rename 或 prompt，
+	// 为空时 Generator.Prompt 不为 nil 则逐个询问，否则跳过
+	Conflict string
+	// Hooks 各阶段的钩子，在模板清单中的钩子之前执行
+	Hooks Hooks
+	// DisableHooks 不执行任何钩子，包括模板清单中的钩子
+	DisableHooks bool
+	// PluginsDir 查找插件钩子的插件目录
+	PluginsDir string
+	// SkipPreflight 跳过克隆前的名称、工具、磁盘和认证检查
+	SkipPreflight bool
+	// Verbose 输出详细信息
+	Verbose bool
+	// CLIVersion 记录在项目元信息中的生成工具版本
+	CLIVersion string
+}
+
+// Result 生成结果
+type Result struct {
+	Project  string   `json:"project"`
+	Path     string   `json:"path"`
+	Mirror   string   `json:"mirror"`
+	RepoURL  string   `json:"repo_url"`
+	Protocol string   `json:"protocol"`
+	Ref      string   `json:"ref"`
+	SHA      string   `json:"sha,omitempty"`
+	Without  []string `json:"without,omitempty"`
+	APIOnly  bool     `json:"api_only"`
+	Database string   `json:"database,omitempty"`
+	Cache    string   `json:"cache,omitempty"`
+	Docker   bool     `json:"docker"`
+	CI       string   `json:"ci,omitempty"`
+	// Backup 使用 Backup 时已有目录的新路径
+	Backup string `json:"backup,omitempty"`
+	// Conflicts 使用 Merge 时的冲突及处理结果
+	Conflicts []MergeConflict `json:"conflicts,omitempty"`
+}
+
+// Generator 从模板生成项目。零值可用，此时不输出任何文本和事件
+type Generator struct {
+	// Out 面向终端的文本进度输出，为 nil 时丢弃
+	Out io.Writer
+	// Events 结构化事件接收者，为 nil 时忽略
+	Events EventSink
+	// Stderr 钩子命令的标准错误输出，为 nil 时丢弃
+	Stderr io.Writer
+	// FS strip、move、env 阶段使用的文件系统，为 nil 时使用真实文件系统。
+	// 克隆、功能裁剪、驱动、Docker、CI 和命令阶段调用 git、go 等外部程序或直接读写磁盘，不经过 FS，
+	// 因此 FS 应当看到与磁盘相同的内容，通常是包装真实文件系统以注入故障或记录操作的实现
+	FS FS
+	// Prompt 确认删除已有目录和逐个处理合并冲突，为 nil 时视为非交互环境：Force 不再确认，Conflict 不能为 prompt
+	Prompt Prompter
+}
+
+// Generate 按 opts 生成项目，ctx 取消时中止克隆和钩子
+func (g *Generator) Generate(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	startTime := time.Now()
+	reporter := &output.Reporter{Out: g.Out, Events: g.Events, Verbose: opts.Verbose}
+
+	// 克隆前预检，尽早发现名称、工具、磁盘和认证问题
+	if !opts.SkipPreflight {
+		if err := runPreflight(preflightOptions{
+			projectName: opts.Name,
+			dir:         opts.Dir,
+			force:       opts.Force || opts.Backup || opts.Merge,
+			useSSH:      opts.usesSSH(),
+			tempDir:     os.TempDir(),
+			reporter:    reporter,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// 已有目录的安全检查不受 SkipPreflight 影响，在克隆前完成，避免下载后才发现无法继续
+	if opts.Conflict == "" {
+		opts.Conflict = ConflictSkip
+		if g.Prompt != nil {
+			opts.Conflict = ConflictPrompt
+		}
+	}
+	if err := g.checkExisting(opts); err != nil {
+		return nil, err
+	}
+
+	mirrors := opts.Mirrors
+	if len(mirrors) == 0 {
+		mirrors = selectMirrors(opts, reporter)
+	}
+
+	reporter.Printf(color.New(color.FgHiBlue), "🌿 %s\n", i18n.T("new.branch", opts.Ref))
+	reporter.Printf(color.New(color.FgHiBlue), "🔗 %s\n", i18n.T("new.protocol", opts.Protocol))
+	if opts.Verbose {
+		reporter.Printf(color.New(color.FgHiMagenta), "📡 %s\n", i18n.T("new.mirrors"))
+		for _, mirror := range mirrors {
+			reporter.Printf(color.New(color.FgHiMagenta), "   - %s: %s\n", mirror.Name, mirror.cloneURL(opts.Protocol))
+		}
+		reporter.Printf(color.New(color.FgHiYellow), "⏱️  %s\n", i18n.T("new.timeout", opts.Timeout))
+	}
+
+	// 创建临时目录
+	tempDir, err := os.MkdirTemp("", "goravel-kit-*")
+	if err != nil {
+		return nil, fmt.Errorf("❌ %s: %w", i18n.T("new.error.temp_dir"), err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil && opts.Verbose {
+			reporter.Printf(color.New(color.FgHiRed), "⚠️  %s: %s: %v\n", i18n.T("common.warning"), i18n.T("new.warn.cleanup_temp"), err)
+		}
+	}()
+
+	projectDir, err := filepath.Abs(opts.path())
+	if err != nil {
+		projectDir = opts.path()
+	}
+	var set Hooks
+	if !opts.DisableHooks {
+		set = opts.Hooks
+	}
+	p := &pipeline{
+		ctx:         ctx,
+		opts:        opts,
+		r:           reporter,
+		mirrors:     mirrors,
+		projectDir:  projectDir,
+		stagingDir:  tempDir,
+		workDir:     tempDir,
+		prompt:      g.Prompt,
+		files:       g.FS,
+		hasFrontend: true,
+		result:      Result{Project: opts.Name, Path: projectDir, Ref: opts.Ref, Protocol: opts.Protocol},
+		hooks: &hooks.Runner{
+			Hooks:      set,
+			PluginsDir: opts.PluginsDir,
+			Stdout:     reporter.Writer(),
+			Stderr:     g.Stderr,
+			Before: func(key string, hook hooks.Hook) {
+				reporter.Printf(color.New(color.FgHiCyan), "🪝 %s\n", i18n.T("hooks.running", key, hook.String()))
+			},
+		},
+	}
+	if err := p.run(); err != nil {
+		return nil, err
+	}
+	slog.Info("project created", "project", opts.Name, "mirror", p.result.Mirror, "sha", p.result.SHA, "duration", time.Since(startTime))
+	return &p.result, nil
+}
+
+// Validate 校验参数并填充默认值，Generate 会自动调用，调用方也可以提前调用以便在输出任何内容前发现参数错误
+func (o *Options) Validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("%s", i18n.T("new.error.name_required"))
+	}
+	if o.Ref == "" {
+		o.Ref = DefaultRef
+	}
+	switch o.Protocol {
+	case "":
+		o.Protocol = ProtocolSSH
+	case ProtocolSSH, ProtocolHTTPS:
+	default:
+		return fmt.Errorf("unsupported protocol %q (ssh, https)", o.Protocol)
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	o.Database = strings.ToLower(o.Database)
+	o.Cache = strings.ToLower(o.Cache)
+	if err := (driver.Options{Database: o.Database, Cache: o.Cache}).Validate(); err != nil {
+		return fmt.Errorf("❌ %s: %w", i18n.T("driver.error.select"), err)
+	}
+	o.CI = strings.ToLower(o.CI)
+	if o.CI != "" {
+		if _, err := ci.LookupProvider(o.CI); err != nil {
+			return fmt.Errorf("❌ %s: %w", i18n.T("ci.error.provider"), err)
+		}
+	}
+	existing := 0
+	for _, set := range []bool{o.Force, o.Backup, o.Merge} {
+		if set {
+			existing++
+		}
+	}
+	if existing > 1 {
+		return fmt.Errorf("%s", i18n.T("new.error.existing_exclusive"))
+	}
+	switch o.Conflict {
+	case "", ConflictSkip, ConflictOverwrite, ConflictRename, ConflictPrompt:
+	default:
+		return fmt.Errorf("%s", i18n.T("new.error.conflict_policy", o.Conflict))
+	}
+	o.Mirrors = append([]Mirror(nil), o.Mirrors...)
+	for i := range o.Mirrors {
+		if o.Mirrors[i].URL == "" {
+			return fmt.Errorf("mirror %d (%s): url is required", i, o.Mirrors[i].Name)
+		}
+		if o.Mirrors[i].Name == "" {
+			o.Mirrors[i].Name = o.Mirrors[i].URL
+		}
+	}
+	if !o.DisableHooks {
+		if err := o.Hooks.Validate(); err != nil {
+			return fmt.Errorf("❌ %s: %w", i18n.T("hooks.error.config"), err)
+		}
+	}
+	return nil
+}
+
+// selectMirrors 按 GiteeOnly/GitHubOnly 和网络探测结果从内置镜像源中选择
+func selectMirrors(opts Options, r *output.Reporter) []Mirror {
+	giteeOnly, githubOnly := opts.GiteeOnly, opts.GitHubOnly
+	// 智能选择镜像源策略
+	var autoDetectedGiteeOnly bool
+	var networkStatus string
+
+	// 如果不是强制指定了镜像源，就自动检测网络
+	if !giteeOnly && !githubOnly {
+		r.Printf(color.New(color.FgHiCyan), "🌐 %s\n", i18n.T("new.probe.checking"))
+
+		giteeReachable := utils.CheckGiteeAccess()
+		if giteeReachable {
+			networkStatus = i18n.T("new.probe.gitee_ok")
+			autoDetectedGiteeOnly = true
+			// 自动启用 gitee-only 模式
+			giteeOnly = true
+		} else {
+			networkStatus = i18n.T("new.probe.gitee_failed")
+			autoDetectedGiteeOnly = true
+		}
+		r.Printf(color.New(color.FgHiCyan), "   %s\n", networkStatus)
+		r.Emit(EventProbe, map[string]any{"host": "gitee.com:443", "reachable": giteeReachable})
+	}
+
+	// 显示当前使用的镜像源策略
+	var strategy string
+	switch {
+	case giteeOnly && autoDetectedGiteeOnly:
+		strategy = i18n.T("new.strategy.auto_gitee")
+	case giteeOnly:
+		strategy = i18n.T("new.strategy.gitee_only")
+	case githubOnly:
+		strategy = i18n.T("new.strategy.github_only")
+	default:
+		strategy = i18n.T("new.strategy.auto")
+	}
+	r.Printf(color.New(color.FgHiBlue), "📦 %s\n", i18n.T("new.strategy", strategy))
+
+	var mirrors []Mirror
+	for _, mirror := range DefaultMirrors() {
+		if (mirror.Name == "GitHub" && giteeOnly) || (mirror.Name == "Gitee" && githubOnly) {
+			continue
+		}
+		mirrors = append(mirrors, mirror)
+	}
+	return mirrors
+}
+
+// cloneURL 按协议选择克隆地址
+func (m Mirror) cloneURL(protocol string) string {
+	if protocol == ProtocolSSH && m.SSHURL != "" {
+		return m.SSHURL
+	}
+	return m.URL
+}
+
+// path 返回项目目录：Dir 下的 Name
+func (o *Options) path() string {
+	return filepath.Join(o.Dir, o.Name)
+}
+
+// usesSSH 判断克隆时是否会使用 SSH 地址
+func (o *Options) usesSSH() bool {
+	if o.Protocol != ProtocolSSH {
+		return false
+	}
+	if len(o.Mirrors) == 0 {
+		return true
+	}
+	for _, mirror := range o.Mirrors {
+		if mirror.SSHURL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNextMirror 检查 current 之后是否还有可以尝试的镜像源
+func hasNextMirror(mirrors []Mirror, current string) bool {
+	for i, mirror := range mirrors {
+		if mirror.Name == current {
+			return i < len(mirrors)-1
+		}
+	}
+	return false
+}
@@ -0,0 +1,526 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hulutech-web/goravel-kit-cli/internal/fsys"
+	"github.com/hulutech-web/goravel-kit-cli/internal/hooks"
+	"github.com/hulutech-web/goravel-kit-cli/internal/i18n"
+	"github.com/hulutech-web/goravel-kit-cli/internal/output"
+)
+
+func TestHasNextMirror(t *testing.T) {
+	mirrors := []Mirror{{Name: "GitHub"}, {Name: "Gitee"}}
+
+	if !hasNextMirror(mirrors, "GitHub") {
+		t.Fatalf("expected next mirror after GitHub")
+	}
+	if hasNextMirror(mirrors, "Gitee") {
+		t.Fatalf("expected no next mirror after last enabled mirror")
+	}
+
+	// 被 GiteeOnly 等选项排除的镜像源不在列表中
+	if hasNextMirror([]Mirror{{Name: "Gitee"}}, "Gitee") {
+		t.Fatalf("expected no next mirror when at last enabled entry")
+	}
+}
+
+func TestValidateProjectName(t *testing.T) {
+	valid := []string{"my-app", "myapp", "my_app.v2", "App1"}
+	for _, name := range valid {
+		if err := validateProjectName(name); err != nil {
+			t.Fatalf("expected %q to be valid, got: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "../evil", "a/b", `a\b`, "-app", ".hidden", "my app", "app.", "con", "NUL.txt", "项目"}
+	for _, name := range invalid {
+		if err := validateProjectName(name); err == nil {
+			t.Fatalf("expected %q to be rejected", name)
+		}
+	}
+}
+
+func TestRunPreflight_ReportsAllIssues(t *testing.T) {
+	err := runPreflight(preflightOptions{projectName: "../evil", tempDir: t.TempDir()})
+	if err == nil {
+		t.Fatalf("expected preflight to fail for invalid project name")
+	}
+	if !strings.Contains(err.Error(), "../evil") || !strings.Contains(err.Error(), "💡") {
+		t.Fatalf("expected actionable message mentioning project name, got: %v", err)
+	}
+}
+
+func TestRunPreflight_MissingSSHKeyOnlyWarns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("HOME does not set the home directory on Windows")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	var buf strings.Builder
+	err := runPreflight(preflightOptions{projectName: "shop", useSSH: true, tempDir: t.TempDir(), reporter: &output.Reporter{Out: &buf}})
+	if err != nil && strings.Contains(err.Error(), i18n.T("preflight.ssh_missing")) {
+		t.Fatalf("expected a missing SSH key not to fail preflight, got %v", err)
+	}
+	if !strings.Contains(buf.String(), i18n.T("preflight.ssh_missing")) {
+		t.Fatalf("expected a warning about the missing SSH key, got %q", buf.String())
+	}
+}
+
+func TestCheckTargetDirectory_ExistingWithoutForce(t *testing.T) {
+	baseDir := t.TempDir()
+	target := filepath.Join(baseDir, "exists")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	if issues := checkTargetDirectory(target, false); len(issues) == 0 {
+		t.Fatalf("expected an issue for existing directory without --force")
+	}
+	if issues := checkTargetDirectory(target, true); len(issues) != 0 {
+		t.Fatalf("expected no issues with --force, got: %+v", issues)
+	}
+}
+
+func TestPipeline_MoveHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are sh commands in this test")
+	}
+	baseDir := t.TempDir()
+	staging := filepath.Join(baseDir, "staging")
+	project := filepath.Join(baseDir, "shop")
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		t.Fatalf("failed to create staging dir: %v", err)
+	}
+
+	p := &pipeline{
+		ctx:        context.Background(),
+		opts:       Options{Name: project},
+		projectDir: project,
+		stagingDir: staging,
+		workDir:    staging,
+		hooks: &hooks.Runner{Hooks: hooks.Hooks{
+			"pre-move":  {{Run: "echo internal > middleware.txt"}},
+			"post-move": {{Run: `test "$(pwd)" = "$GORAVEL_KIT_PROJECT_DIR" && cat > hook-context.json`}},
+			"pre-env":   {{Run: "exit 3"}},
+		}},
+	}
+	if err := p.runHooks(hooks.Pre, hooks.StageMove); err != nil {
+		t.Fatalf("pre-move hook failed: %v", err)
+	}
+	if err := p.move(); err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	if err := p.runHooks(hooks.Post, hooks.StageMove); err != nil {
+		t.Fatalf("post-move hook failed: %v", err)
+	}
+
+	// pre-move 钩子在临时目录中的修改随模板一起移动到项目目录
+	if content, err := os.ReadFile(filepath.Join(project, "middleware.txt")); err != nil || strings.TrimSpace(string(content)) != "internal" {
+		t.Fatalf("expected pre-move hook output in project, got %q %v", content, err)
+	}
+	content, err := os.ReadFile(filepath.Join(project, "hook-context.json"))
+	if err != nil || !strings.Contains(string(content), `"stage":"move","when":"post","dir":"`+project+`"`) {
+		t.Fatalf("expected post-move hook to run in the project dir, got %q %v", content, err)
+	}
+
+	if err := p.runHooks(hooks.Pre, hooks.StageEnv); err == nil || !strings.Contains(err.Error(), `pre-env hook "exit 3"`) {
+		t.Fatalf("expected failing hook to abort, got %v", err)
+	}
+}
+
+func TestPipeline_RunCommandsReturnsFailure(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":  "module shop\n\ngo 1.21\n",
+		"main.go": "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\tfmt.Println(\"APP_KEY is not writable\")\n\tos.Exit(2)\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	var out strings.Builder
+	p := &pipeline{ctx: context.Background(), opts: Options{Name: "shop"}, projectDir: dir, r: &output.Reporter{Out: &out}}
+	err := p.runCommands()
+	if err == nil || !strings.Contains(err.Error(), "key:generate") {
+		t.Fatalf("expected key:generate failure to be returned, got %v", err)
+	}
+	if !strings.Contains(out.String(), "APP_KEY is not writable") {
+		t.Fatalf("expected command output on failure, got %q", out.String())
+	}
+}
+
+func TestPipeline_StripMoveEnvInMemory(t *testing.T) {
+	mem := fsys.NewMemFS()
+	mem.Mount("/tmp")
+	files := map[string]string{
+		"/tmp/staging/.git/HEAD":    "ref: refs/heads/master\n",
+		"/tmp/staging/README.md":    "# template\n",
+		"/tmp/staging/.env.example": "APP_NAME=Goravel\nAPP_URL=http://localhost\n",
+		"/work/shop/stale.txt":      "old\n",
+	}
+	for name, content := range files {
+		if err := mem.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := mem.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	p := &pipeline{
+		ctx:        context.Background(),
+		opts:       Options{Name: "shop", Dir: "/work", Force: true},
+		r:          &output.Reporter{},
+		projectDir: "/work/shop",
+		stagingDir: "/tmp/staging",
+		workDir:    "/tmp/staging",
+		files:      mem,
+	}
+	for _, stage := range []func() error{p.strip, p.move, p.env} {
+		if err := stage(); err != nil {
+			t.Fatalf("stage failed: %v", err)
+		}
+	}
+
+	for _, removed := range []string{"/work/shop/.git", "/work/shop/README.md", "/work/shop/stale.txt", "/tmp/staging"} {
+		if _, err := mem.Stat(removed); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed, stat err=%v", removed, err)
+		}
+	}
+	content, err := mem.ReadFile("/work/shop/.env")
+	if err != nil || !strings.Contains(string(content), "APP_NAME=shop") {
+		t.Fatalf("expected .env to be rendered in the project dir, got %q %v", content, err)
+	}
+	if !fsys.FileExists(mem, "/work/shop/.goravel-kit.json") {
+		t.Fatalf("expected template metadata to be written")
+	}
+	if p.workDir != "/work/shop" {
+		t.Fatalf("expected hooks to run in the project dir after move, got %s", p.workDir)
+	}
+}
+
+func TestPipeline_MoveFailsWhenDiskIsFull(t *testing.T) {
+	mem := fsys.NewMemFS()
+	mem.Mount("/tmp")
+	if err := mem.MkdirAll("/tmp/staging", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := mem.WriteFile("/tmp/staging/main.go", []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	mem.Capacity = mem.Used() + 1
+
+	p := &pipeline{
+		ctx:        context.Background(),
+		opts:       Options{Name: "/shop"},
+		r:          &output.Reporter{},
+		projectDir: "/shop",
+		stagingDir: "/tmp/staging",
+		workDir:    "/tmp/staging",
+		files:      mem,
+	}
+	err := p.move()
+	if !errors.Is(err, syscall.ENOSPC) {
+		t.Fatalf("expected ENOSPC from move, got %v", err)
+	}
+	if p.workDir != "/tmp/staging" {
+		t.Fatalf("expected work dir to stay in staging after a failed move, got %s", p.workDir)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := Options{Name: "shop", Database: "Postgres", CI: "GitHub"}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if opts.Ref != DefaultRef || opts.Protocol != ProtocolSSH || opts.Timeout != DefaultTimeout {
+		t.Fatalf("expected defaults to be filled, got %+v", opts)
+	}
+	if opts.Database != "postgres" || opts.CI != "github" {
+		t.Fatalf("expected driver and CI names to be normalized, got %+v", opts)
+	}
+
+	invalid := map[string]Options{
+		"name":     {},
+		"protocol": {Name: "shop", Protocol: "ftp"},
+		"database": {Name: "shop", Database: "oracle"},
+		"ci":       {Name: "shop", CI: "jenkins"},
+		"hooks":    {Name: "shop", Hooks: Hooks{"after-move": {{Run: "true"}}}},
+		"mirror":   {Name: "shop", Mirrors: []Mirror{{Name: "intranet"}}},
+		"existing": {Name: "shop", Force: true, Merge: true},
+		"conflict": {Name: "shop", Merge: true, Conflict: "theirs"},
+	}
+	for name, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+
+	// 禁用钩子时不校验钩子定义
+	disabled := Options{Name: "shop", Hooks: Hooks{"after-move": {{Run: "true"}}}, DisableHooks: true}
+	if err := disabled.Validate(); err != nil {
+		t.Fatalf("expected hooks to be ignored when disabled, got %v", err)
+	}
+}
+
+// newExistingPipeline 创建内存中的 pipeline：/tmp/staging 为处理后的模板，/work/shop 为已有的项目目录
+func newExistingPipeline(t *testing.T, opts Options) (*pipeline, *fsys.MemFS) {
+	t.Helper()
+	mem := fsys.NewMemFS()
+	mem.Mount("/tmp")
+	files := map[string]string{
+		"/tmp/staging/main.go":      "package main\n",
+		"/tmp/staging/go.mod":       "module shop\n",
+		"/tmp/staging/.env.example": "APP_NAME=Goravel\n",
+		"/work/shop/main.go":        "package main // custom\n",
+		"/work/shop/.env":           "APP_NAME=shop\nDB_PASSWORD=secret\n",
+	}
+	for name, content := range files {
+		if err := mem.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := mem.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	opts.Name = "/work/shop"
+	return &pipeline{
+		ctx:        context.Background(),
+		opts:       opts,
+		r:          &output.Reporter{},
+		projectDir: "/work/shop",
+		stagingDir: "/tmp/staging",
+		workDir:    "/tmp/staging",
+		files:      mem,
+	}, mem
+}
+
+func TestPipeline_MoveRefusesExistingDirectory(t *testing.T) {
+	p, mem := newExistingPipeline(t, Options{})
+
+	if err := p.move(); err == nil || !strings.Contains(err.Error(), "/work/shop") {
+		t.Fatalf("expected move to refuse the existing dir, got %v", err)
+	}
+	if content, _ := mem.ReadFile("/work/shop/main.go"); string(content) != "package main // custom\n" {
+		t.Fatalf("expected existing project to be untouched, got %q", content)
+	}
+}
+
+func TestPipeline_BackupExistingProject(t *testing.T) {
+	p, mem := newExistingPipeline(t, Options{Backup: true})
+
+	if err := p.move(); err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	if !strings.HasPrefix(p.result.Backup, "/work/shop.bak-") {
+		t.Fatalf("expected backup path in result, got %q", p.result.Backup)
+	}
+	if content, _ := mem.ReadFile(filepath.Join(p.result.Backup, "main.go")); string(content) != "package main // custom\n" {
+		t.Fatalf("expected existing project to be kept in the backup, got %q", content)
+	}
+	if content, _ := mem.ReadFile("/work/shop/main.go"); string(content) != "package main\n" {
+		t.Fatalf("expected a fresh project, got %q", content)
+	}
+	if fsys.FileExists(mem, "/work/shop/.env") {
+		t.Fatalf("expected the old .env to move with the backup")
+	}
+}
+
+// fakePrompter 按预设结果回答确认和冲突询问，并记录询问过的内容
+type fakePrompter struct {
+	confirm   bool
+	action    string
+	questions []string
+	conflicts []string
+}
+
+func (p *fakePrompter) Confirm(question string) (bool, error) {
+	p.questions = append(p.questions, question)
+	return p.confirm, nil
+}
+
+func (p *fakePrompter) ResolveConflict(path string) (string, error) {
+	p.conflicts = append(p.conflicts, path)
+	return p.action, nil
+}
+
+func TestPipeline_MergeKeepsExistingEnv(t *testing.T) {
+	prompt := &fakePrompter{action: ConflictRename}
+	p, mem := newExistingPipeline(t, Options{Merge: true, Conflict: ConflictPrompt})
+	p.prompt = prompt
+
+	// /work/shop 只存在于内存中，commands 阶段若执行 go run 会失败
+	for _, stage := range []func() error{p.move, p.env, p.runCommands} {
+		if err := stage(); err != nil {
+			t.Fatalf("stage failed: %v", err)
+		}
+	}
+	if strings.Join(prompt.conflicts, ",") != "main.go" {
+		t.Fatalf("expected to be asked about main.go only, got %v", prompt.conflicts)
+	}
+	if len(p.result.Conflicts) != 1 || p.result.Conflicts[0].RenamedTo != "main.go.orig" {
+		t.Fatalf("unexpected conflicts in result: %+v", p.result.Conflicts)
+	}
+	for name, want := range map[string]string{
+		"/work/shop/main.go":      "package main\n",
+		"/work/shop/main.go.orig": "package main // custom\n",
+		"/work/shop/go.mod":       "module shop\n",
+		"/work/shop/.env":         "APP_NAME=shop\nDB_PASSWORD=secret\n",
+	} {
+		if content, err := mem.ReadFile(name); err != nil || string(content) != want {
+			t.Fatalf("expected %s to contain %q, got %q %v", name, want, content, err)
+		}
+	}
+	if p.workDir != "/work/shop" {
+		t.Fatalf("expected hooks to run in the project dir after merge, got %s", p.workDir)
+	}
+}
+
+func TestPipeline_MergeWithDockerKeepsExistingEnv(t *testing.T) {
+	root := t.TempDir()
+	staging := filepath.Join(root, "staging")
+	projectDir := filepath.Join(root, "shop")
+	existingEnv := "APP_NAME=shop\nDB_CONNECTION=postgres\nDB_HOST=127.0.0.1\nDB_PASSWORD=secret\n"
+	for name, content := range map[string]string{
+		filepath.Join(staging, "main.go"):      "package main\n",
+		filepath.Join(staging, "go.mod"):       "module shop\n\ngo 1.22\n",
+		filepath.Join(staging, ".env.example"): "APP_NAME=Goravel\nDB_CONNECTION=postgres\nDB_HOST=127.0.0.1\n",
+		filepath.Join(projectDir, ".env"):      existingEnv,
+	} {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("MkdirAll failed: %v", err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	p := &pipeline{
+		ctx:        context.Background(),
+		opts:       Options{Name: "shop", Merge: true, Conflict: ConflictSkip, Docker: true},
+		r:          &output.Reporter{Out: &out},
+		projectDir: projectDir,
+		stagingDir: staging,
+		workDir:    staging,
+	}
+	for _, stage := range []func() error{p.move, p.env, p.generateDocker} {
+		if err := stage(); err != nil {
+			t.Fatalf("stage failed: %v", err)
+		}
+	}
+	if !p.result.Docker {
+		t.Fatalf("expected docker configuration to be generated, output:\n%s", out.String())
+	}
+	if content, err := os.ReadFile(filepath.Join(projectDir, ".env")); err != nil || string(content) != existingEnv {
+		t.Fatalf("expected existing .env to be unchanged, got %q %v", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "docker-compose.yml")); err != nil {
+		t.Fatalf("expected docker-compose.yml: %v", err)
+	}
+	if !strings.Contains(out.String(), i18n.T("docker.env_kept")) || !strings.Contains(out.String(), "DB_HOST=db") {
+		t.Fatalf("expected values to set in .env to be printed, got:\n%s", out.String())
+	}
+}
+
+// newDirtyRepo 创建一个有未提交改动的 git 仓库
+func newDirtyRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := filepath.Join(t.TempDir(), "shop")
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	return dir
+}
+
+func TestCheckExisting_RefusesDirtyRepository(t *testing.T) {
+	dir := newDirtyRepo(t)
+	prompt := &fakePrompter{confirm: true}
+	g := &Generator{Prompt: prompt}
+
+	for _, opts := range []Options{
+		{Name: dir, Force: true},
+		{Name: dir, Merge: true, Conflict: ConflictOverwrite},
+	} {
+		err := g.checkExisting(opts)
+		if err == nil || !strings.Contains(err.Error(), i18n.T("new.error.dirty_repo", dir, 1)) {
+			t.Fatalf("expected %+v to be refused for a dirty repository, got %v", opts, err)
+		}
+	}
+	if len(prompt.questions) != 0 {
+		t.Fatalf("expected no confirmation before refusing, got %v", prompt.questions)
+	}
+	for _, opts := range []Options{
+		{Name: dir, Backup: true},
+		{Name: dir, Merge: true, Conflict: ConflictSkip},
+		{Name: dir, Merge: true, Conflict: ConflictRename},
+	} {
+		if err := g.checkExisting(opts); err != nil {
+			t.Fatalf("expected %+v to keep uncommitted work and be allowed, got %v", opts, err)
+		}
+	}
+}
+
+func TestCheckExisting_RefusesDirtyMonorepoSubdirectory(t *testing.T) {
+	root := newDirtyRepo(t)
+	dir := filepath.Join(root, "apps", "shop")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	g := &Generator{}
+	// 仓库根目录的改动不属于 apps/shop
+	if err := g.checkExisting(Options{Name: "shop", Dir: filepath.Join(root, "apps"), Force: true}); err != nil {
+		t.Fatalf("expected changes outside the project dir to be ignored, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "todo.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	err := g.checkExisting(Options{Name: "shop", Dir: filepath.Join(root, "apps"), Force: true})
+	if err == nil || !strings.Contains(err.Error(), i18n.T("new.error.dirty_repo", dir, 1)) {
+		t.Fatalf("expected a dirty monorepo subdirectory to be refused, got %v", err)
+	}
+}
+
+func TestCheckExisting_ForceNeedsConfirmation(t *testing.T) {
+	dir := t.TempDir()
+
+	declined := &fakePrompter{}
+	err := (&Generator{Prompt: declined}).checkExisting(Options{Name: dir, Force: true})
+	if err == nil || len(declined.questions) != 1 || !strings.Contains(declined.questions[0], dir) {
+		t.Fatalf("expected a declined confirmation to abort, got %v %v", err, declined.questions)
+	}
+	accepted := &fakePrompter{confirm: true}
+	if err := (&Generator{Prompt: accepted}).checkExisting(Options{Name: dir, Force: true}); err != nil {
+		t.Fatalf("expected an accepted confirmation to continue, got %v", err)
+	}
+	// 非交互环境（如 CI）中 --force 不需要确认
+	if err := (&Generator{}).checkExisting(Options{Name: dir, Force: true}); err != nil {
+		t.Fatalf("expected --force without a prompter to continue, got %v", err)
+	}
+	if err := (&Generator{}).checkExisting(Options{Name: dir, Merge: true, Conflict: ConflictPrompt}); err == nil {
+		t.Fatalf("expected --conflict prompt without a terminal to fail")
+	}
+}